@@ -415,6 +415,129 @@ func (c *Client) FeedIcon(feedID int64) (*FeedIcon, error) {
 	return feedIcon, nil
 }
 
+// FeedDiagnostics gets the most recent refreshes of a feed.
+func (c *Client) FeedDiagnostics(feedID int64) (*FeedDiagnosticsResult, error) {
+	body, err := c.request.Get(fmt.Sprintf("/v1/feeds/%d/diagnostics", feedID))
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	var result FeedDiagnosticsResult
+	if err := json.NewDecoder(body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("miniflux: response error (%v)", err)
+	}
+
+	return &result, nil
+}
+
+// FeedSuggestions gets the stale and moved feeds of the user.
+func (c *Client) FeedSuggestions() ([]*FeedSuggestion, error) {
+	body, err := c.request.Get("/v1/feeds/suggestions")
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	var suggestions []*FeedSuggestion
+	if err := json.NewDecoder(body).Decode(&suggestions); err != nil {
+		return nil, fmt.Errorf("miniflux: response error (%v)", err)
+	}
+
+	return suggestions, nil
+}
+
+// DismissFeedSuggestions hides the current suggestions about a feed.
+func (c *Client) DismissFeedSuggestions(feedID int64) error {
+	_, err := c.request.Put(fmt.Sprintf("/v1/feeds/%d/dismiss-suggestions", feedID), nil)
+	return err
+}
+
+// EntryRules gets the rules of the user.
+func (c *Client) EntryRules() ([]*EntryRule, error) {
+	body, err := c.request.Get("/v1/rules")
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	var rules []*EntryRule
+	if err := json.NewDecoder(body).Decode(&rules); err != nil {
+		return nil, fmt.Errorf("miniflux: response error (%v)", err)
+	}
+
+	return rules, nil
+}
+
+// EntryRule gets a single rule.
+func (c *Client) EntryRule(ruleID int64) (*EntryRule, error) {
+	body, err := c.request.Get(fmt.Sprintf("/v1/rules/%d", ruleID))
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	var rule *EntryRule
+	if err := json.NewDecoder(body).Decode(&rule); err != nil {
+		return nil, fmt.Errorf("miniflux: response error (%v)", err)
+	}
+
+	return rule, nil
+}
+
+// CreateEntryRule creates a new rule.
+func (c *Client) CreateEntryRule(ruleRequest *EntryRuleRequest) (*EntryRule, error) {
+	body, err := c.request.Post("/v1/rules", ruleRequest)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	var rule *EntryRule
+	if err := json.NewDecoder(body).Decode(&rule); err != nil {
+		return nil, fmt.Errorf("miniflux: response error (%v)", err)
+	}
+
+	return rule, nil
+}
+
+// UpdateEntryRule updates a rule.
+func (c *Client) UpdateEntryRule(ruleID int64, ruleRequest *EntryRuleRequest) (*EntryRule, error) {
+	body, err := c.request.Put(fmt.Sprintf("/v1/rules/%d", ruleID), ruleRequest)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	var rule *EntryRule
+	if err := json.NewDecoder(body).Decode(&rule); err != nil {
+		return nil, fmt.Errorf("miniflux: response error (%v)", err)
+	}
+
+	return rule, nil
+}
+
+// DeleteEntryRule removes a rule.
+func (c *Client) DeleteEntryRule(ruleID int64) error {
+	return c.request.Delete(fmt.Sprintf("/v1/rules/%d", ruleID))
+}
+
+// PreviewEntryRule returns the recent entries matching a rule without applying its actions.
+func (c *Client) PreviewEntryRule(ruleRequest *EntryRuleRequest) (*EntryResultSet, error) {
+	body, err := c.request.Post("/v1/rules/preview", ruleRequest)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	var result EntryResultSet
+	if err := json.NewDecoder(body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("miniflux: response error (%v)", err)
+	}
+
+	return &result, nil
+}
+
 // FeedEntry gets a single feed entry.
 func (c *Client) FeedEntry(feedID, entryID int64) (*Entry, error) {
 	body, err := c.request.Get(fmt.Sprintf("/v1/feeds/%d/entries/%d", feedID, entryID))
@@ -517,6 +640,62 @@ func (c *Client) CategoryEntries(categoryID int64, filter *Filter) (*EntryResult
 	return &result, nil
 }
 
+// SimilarEntries fetch entries similar to the given entry, ordered by similarity score.
+func (c *Client) SimilarEntries(entryID int64, filter *Filter) (*EntryResultSet, error) {
+	path := buildFilterQueryString(fmt.Sprintf("/v1/entries/%d/similar", entryID), filter)
+
+	body, err := c.request.Get(path)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	var result EntryResultSet
+	if err := json.NewDecoder(body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("miniflux: response error (%v)", err)
+	}
+
+	return &result, nil
+}
+
+// MarkSimilarEntriesAsRead marks as read the unread entries similar to the given entry.
+func (c *Client) MarkSimilarEntriesAsRead(entryID int64) error {
+	_, err := c.request.Put(fmt.Sprintf("/v1/entries/%d/similar/mark-all-as-read", entryID), nil)
+	return err
+}
+
+// EntryRevisions fetch the previous versions of an entry, the most recent first.
+func (c *Client) EntryRevisions(entryID int64) (EntryRevisions, error) {
+	body, err := c.request.Get(fmt.Sprintf("/v1/entries/%d/revisions", entryID))
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	var revisions EntryRevisions
+	if err := json.NewDecoder(body).Decode(&revisions); err != nil {
+		return nil, fmt.Errorf("miniflux: response error (%v)", err)
+	}
+
+	return revisions, nil
+}
+
+// EntryRevisionDiff fetch the changes between a revision and the version that replaced it.
+func (c *Client) EntryRevisionDiff(entryID, revisionID int64) (*EntryRevisionDiff, error) {
+	body, err := c.request.Get(fmt.Sprintf("/v1/entries/%d/revisions/%d/diff", entryID, revisionID))
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	var diff EntryRevisionDiff
+	if err := json.NewDecoder(body).Decode(&diff); err != nil {
+		return nil, fmt.Errorf("miniflux: response error (%v)", err)
+	}
+
+	return &diff, nil
+}
+
 // UpdateEntries updates the status of a list of entries.
 func (c *Client) UpdateEntries(entryIDs []int64, status string) error {
 	type payload struct {
@@ -550,6 +729,98 @@ func (c *Client) ToggleBookmark(entryID int64) error {
 	return err
 }
 
+// UpdateEntryUserTags replaces the tags added by the user to an entry.
+func (c *Client) UpdateEntryUserTags(entryID int64, tags []string) error {
+	_, err := c.request.Put(fmt.Sprintf("/v1/entries/%d/tags", entryID), map[string][]string{"tags": tags})
+	return err
+}
+
+// UserTags gets the tags added by the user to the entries.
+func (c *Client) UserTags() ([]*UserTag, error) {
+	body, err := c.request.Get("/v1/tags")
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	var tags []*UserTag
+	if err := json.NewDecoder(body).Decode(&tags); err != nil {
+		return nil, fmt.Errorf("miniflux: response error (%v)", err)
+	}
+
+	return tags, nil
+}
+
+// RenameUserTag renames a user tag, or merges it when the new name is already used.
+func (c *Client) RenameUserTag(name, newName string) error {
+	_, err := c.request.Put("/v1/tags/"+url.PathEscape(name), map[string]string{"name": newName})
+	return err
+}
+
+// DeleteUserTag removes a user tag from all the entries.
+func (c *Client) DeleteUserTag(name string) error {
+	return c.request.Delete("/v1/tags/" + url.PathEscape(name))
+}
+
+// EntryAnnotations gets the annotations of an entry.
+func (c *Client) EntryAnnotations(entryID int64) ([]*EntryAnnotation, error) {
+	body, err := c.request.Get(fmt.Sprintf("/v1/entries/%d/annotations", entryID))
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	var annotations []*EntryAnnotation
+	if err := json.NewDecoder(body).Decode(&annotations); err != nil {
+		return nil, fmt.Errorf("miniflux: response error (%v)", err)
+	}
+
+	return annotations, nil
+}
+
+// CreateEntryAnnotation highlights a passage of an entry.
+func (c *Client) CreateEntryAnnotation(entryID int64, annotationRequest *EntryAnnotationRequest) (*EntryAnnotation, error) {
+	body, err := c.request.Post(fmt.Sprintf("/v1/entries/%d/annotations", entryID), annotationRequest)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	var annotation *EntryAnnotation
+	if err := json.NewDecoder(body).Decode(&annotation); err != nil {
+		return nil, fmt.Errorf("miniflux: response error (%v)", err)
+	}
+
+	return annotation, nil
+}
+
+// UpdateEntryAnnotation updates the text or the note of an annotation.
+func (c *Client) UpdateEntryAnnotation(entryID, annotationID int64, annotationChanges *EntryAnnotationModificationRequest) (*EntryAnnotation, error) {
+	body, err := c.request.Put(fmt.Sprintf("/v1/entries/%d/annotations/%d", entryID, annotationID), annotationChanges)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	var annotation *EntryAnnotation
+	if err := json.NewDecoder(body).Decode(&annotation); err != nil {
+		return nil, fmt.Errorf("miniflux: response error (%v)", err)
+	}
+
+	return annotation, nil
+}
+
+// DeleteEntryAnnotation removes an annotation.
+func (c *Client) DeleteEntryAnnotation(entryID, annotationID int64) error {
+	return c.request.Delete(fmt.Sprintf("/v1/entries/%d/annotations/%d", entryID, annotationID))
+}
+
+// SaveEntryAnnotations sends the annotations of an entry to the third-party services supporting highlights.
+func (c *Client) SaveEntryAnnotations(entryID int64) error {
+	_, err := c.request.Post(fmt.Sprintf("/v1/entries/%d/annotations/save", entryID), nil)
+	return err
+}
+
 // SaveEntry sends an entry to a third-party service.
 func (c *Client) SaveEntry(entryID int64) error {
 	_, err := c.request.Post(fmt.Sprintf("/v1/entries/%d/save", entryID), nil)
@@ -677,6 +948,10 @@ func buildFilterQueryString(path string, filter *Filter) string {
 			values.Set("search", filter.Search)
 		}
 
+		if filter.SearchMode != "" {
+			values.Set("search_mode", filter.SearchMode)
+		}
+
 		if filter.CategoryID > 0 {
 			values.Set("category_id", strconv.FormatInt(filter.CategoryID, 10))
 		}
@@ -689,6 +964,14 @@ func buildFilterQueryString(path string, filter *Filter) string {
 			values.Add("status", status)
 		}
 
+		for _, tag := range filter.UserTags {
+			values.Add("user_tags", tag)
+		}
+
+		if filter.CollapseSimilar {
+			values.Set("collapse_similar", "true")
+		}
+
 		path = fmt.Sprintf("%s?%s", path, values.Encode())
 	}
 
@@ -15,33 +15,46 @@ const (
 	EntryStatusRemoved = "removed"
 )
 
+// Entry search modes.
+const (
+	SearchModeFullText = "fulltext"
+	SearchModeSemantic = "semantic"
+)
+
 // User represents a user in the system.
 type User struct {
-	ID                     int64      `json:"id"`
-	Username               string     `json:"username"`
-	Password               string     `json:"password,omitempty"`
-	IsAdmin                bool       `json:"is_admin"`
-	Theme                  string     `json:"theme"`
-	Language               string     `json:"language"`
-	Timezone               string     `json:"timezone"`
-	EntryDirection         string     `json:"entry_sorting_direction"`
-	EntryOrder             string     `json:"entry_sorting_order"`
-	Stylesheet             string     `json:"stylesheet"`
-	GoogleID               string     `json:"google_id"`
-	OpenIDConnectID        string     `json:"openid_connect_id"`
-	EntriesPerPage         int        `json:"entries_per_page"`
-	KeyboardShortcuts      bool       `json:"keyboard_shortcuts"`
-	ShowReadingTime        bool       `json:"show_reading_time"`
-	EntrySwipe             bool       `json:"entry_swipe"`
-	GestureNav             string     `json:"gesture_nav"`
-	LastLoginAt            *time.Time `json:"last_login_at"`
-	DisplayMode            string     `json:"display_mode"`
-	DefaultReadingSpeed    int        `json:"default_reading_speed"`
-	CJKReadingSpeed        int        `json:"cjk_reading_speed"`
-	DefaultHomePage        string     `json:"default_home_page"`
-	CategoriesSortingOrder string     `json:"categories_sorting_order"`
-	MarkReadOnView         bool       `json:"mark_read_on_view"`
-	MediaPlaybackRate      float64    `json:"media_playback_rate"`
+	ID                          int64      `json:"id"`
+	Username                    string     `json:"username"`
+	Password                    string     `json:"password,omitempty"`
+	IsAdmin                     bool       `json:"is_admin"`
+	Theme                       string     `json:"theme"`
+	Language                    string     `json:"language"`
+	Timezone                    string     `json:"timezone"`
+	EntryDirection              string     `json:"entry_sorting_direction"`
+	EntryOrder                  string     `json:"entry_sorting_order"`
+	Stylesheet                  string     `json:"stylesheet"`
+	GoogleID                    string     `json:"google_id"`
+	OpenIDConnectID             string     `json:"openid_connect_id"`
+	EntriesPerPage              int        `json:"entries_per_page"`
+	KeyboardShortcuts           bool       `json:"keyboard_shortcuts"`
+	ShowReadingTime             bool       `json:"show_reading_time"`
+	EntrySwipe                  bool       `json:"entry_swipe"`
+	GestureNav                  string     `json:"gesture_nav"`
+	LastLoginAt                 *time.Time `json:"last_login_at"`
+	DisplayMode                 string     `json:"display_mode"`
+	DefaultReadingSpeed         int        `json:"default_reading_speed"`
+	CJKReadingSpeed             int        `json:"cjk_reading_speed"`
+	DefaultHomePage             string     `json:"default_home_page"`
+	CategoriesSortingOrder      string     `json:"categories_sorting_order"`
+	MarkReadOnView              bool       `json:"mark_read_on_view"`
+	MediaPlaybackRate           float64    `json:"media_playback_rate"`
+	CollapseSimilarEntries      bool       `json:"collapse_similar_entries"`
+	DuplicateEntriesAction      string     `json:"duplicate_entries_action"`
+	DuplicateEntriesThreshold   float64    `json:"duplicate_entries_threshold"`
+	DuplicateEntriesCategoryIDs []int64    `json:"duplicate_entries_category_ids"`
+	DigestFrequency             string     `json:"digest_frequency"`
+	DigestEntries               string     `json:"digest_entries"`
+	DigestEmail                 string     `json:"digest_email"`
 }
 
 func (u User) String() string {
@@ -59,29 +72,36 @@ type UserCreationRequest struct {
 
 // UserModificationRequest represents the request to update a user.
 type UserModificationRequest struct {
-	Username               *string  `json:"username"`
-	Password               *string  `json:"password"`
-	IsAdmin                *bool    `json:"is_admin"`
-	Theme                  *string  `json:"theme"`
-	Language               *string  `json:"language"`
-	Timezone               *string  `json:"timezone"`
-	EntryDirection         *string  `json:"entry_sorting_direction"`
-	EntryOrder             *string  `json:"entry_sorting_order"`
-	Stylesheet             *string  `json:"stylesheet"`
-	GoogleID               *string  `json:"google_id"`
-	OpenIDConnectID        *string  `json:"openid_connect_id"`
-	EntriesPerPage         *int     `json:"entries_per_page"`
-	KeyboardShortcuts      *bool    `json:"keyboard_shortcuts"`
-	ShowReadingTime        *bool    `json:"show_reading_time"`
-	EntrySwipe             *bool    `json:"entry_swipe"`
-	GestureNav             *string  `json:"gesture_nav"`
-	DisplayMode            *string  `json:"display_mode"`
-	DefaultReadingSpeed    *int     `json:"default_reading_speed"`
-	CJKReadingSpeed        *int     `json:"cjk_reading_speed"`
-	DefaultHomePage        *string  `json:"default_home_page"`
-	CategoriesSortingOrder *string  `json:"categories_sorting_order"`
-	MarkReadOnView         *bool    `json:"mark_read_on_view"`
-	MediaPlaybackRate      *float64 `json:"media_playback_rate"`
+	Username                    *string  `json:"username"`
+	Password                    *string  `json:"password"`
+	IsAdmin                     *bool    `json:"is_admin"`
+	Theme                       *string  `json:"theme"`
+	Language                    *string  `json:"language"`
+	Timezone                    *string  `json:"timezone"`
+	EntryDirection              *string  `json:"entry_sorting_direction"`
+	EntryOrder                  *string  `json:"entry_sorting_order"`
+	Stylesheet                  *string  `json:"stylesheet"`
+	GoogleID                    *string  `json:"google_id"`
+	OpenIDConnectID             *string  `json:"openid_connect_id"`
+	EntriesPerPage              *int     `json:"entries_per_page"`
+	KeyboardShortcuts           *bool    `json:"keyboard_shortcuts"`
+	ShowReadingTime             *bool    `json:"show_reading_time"`
+	EntrySwipe                  *bool    `json:"entry_swipe"`
+	GestureNav                  *string  `json:"gesture_nav"`
+	DisplayMode                 *string  `json:"display_mode"`
+	DefaultReadingSpeed         *int     `json:"default_reading_speed"`
+	CJKReadingSpeed             *int     `json:"cjk_reading_speed"`
+	DefaultHomePage             *string  `json:"default_home_page"`
+	CategoriesSortingOrder      *string  `json:"categories_sorting_order"`
+	MarkReadOnView              *bool    `json:"mark_read_on_view"`
+	MediaPlaybackRate           *float64 `json:"media_playback_rate"`
+	CollapseSimilarEntries      *bool    `json:"collapse_similar_entries"`
+	DuplicateEntriesAction      *string  `json:"duplicate_entries_action"`
+	DuplicateEntriesThreshold   *float64 `json:"duplicate_entries_threshold"`
+	DuplicateEntriesCategoryIDs *[]int64 `json:"duplicate_entries_category_ids"`
+	DigestFrequency             *string  `json:"digest_frequency"`
+	DigestEntries               *string  `json:"digest_entries"`
+	DigestEmail                 *string  `json:"digest_email"`
 }
 
 // Users represents a list of users.
@@ -117,76 +137,106 @@ type Subscriptions []*Subscription
 
 // Feed represents a Miniflux feed.
 type Feed struct {
-	ID                          int64     `json:"id"`
-	UserID                      int64     `json:"user_id"`
-	FeedURL                     string    `json:"feed_url"`
-	SiteURL                     string    `json:"site_url"`
-	Title                       string    `json:"title"`
-	CheckedAt                   time.Time `json:"checked_at,omitempty"`
-	EtagHeader                  string    `json:"etag_header,omitempty"`
-	LastModifiedHeader          string    `json:"last_modified_header,omitempty"`
-	ParsingErrorMsg             string    `json:"parsing_error_message,omitempty"`
-	ParsingErrorCount           int       `json:"parsing_error_count,omitempty"`
-	Disabled                    bool      `json:"disabled"`
-	IgnoreHTTPCache             bool      `json:"ignore_http_cache"`
-	AllowSelfSignedCertificates bool      `json:"allow_self_signed_certificates"`
-	FetchViaProxy               bool      `json:"fetch_via_proxy"`
-	ScraperRules                string    `json:"scraper_rules"`
-	RewriteRules                string    `json:"rewrite_rules"`
-	BlocklistRules              string    `json:"blocklist_rules"`
-	KeeplistRules               string    `json:"keeplist_rules"`
-	Crawler                     bool      `json:"crawler"`
-	UserAgent                   string    `json:"user_agent"`
-	Cookie                      string    `json:"cookie"`
-	Username                    string    `json:"username"`
-	Password                    string    `json:"password"`
-	Category                    *Category `json:"category,omitempty"`
-	HideGlobally                bool      `json:"hide_globally"`
-	DisableHTTP2                bool      `json:"disable_http2"`
+	ID                          int64            `json:"id"`
+	UserID                      int64            `json:"user_id"`
+	FeedURL                     string           `json:"feed_url"`
+	SiteURL                     string           `json:"site_url"`
+	Title                       string           `json:"title"`
+	CheckedAt                   time.Time        `json:"checked_at,omitempty"`
+	EtagHeader                  string           `json:"etag_header,omitempty"`
+	LastModifiedHeader          string           `json:"last_modified_header,omitempty"`
+	ParsingErrorMsg             string           `json:"parsing_error_message,omitempty"`
+	ParsingErrorCount           int              `json:"parsing_error_count,omitempty"`
+	Disabled                    bool             `json:"disabled"`
+	IgnoreHTTPCache             bool             `json:"ignore_http_cache"`
+	AllowSelfSignedCertificates bool             `json:"allow_self_signed_certificates"`
+	FetchViaProxy               bool             `json:"fetch_via_proxy"`
+	ScraperRules                string           `json:"scraper_rules"`
+	RewriteRules                string           `json:"rewrite_rules"`
+	BlocklistRules              string           `json:"blocklist_rules"`
+	KeeplistRules               string           `json:"keeplist_rules"`
+	Crawler                     bool             `json:"crawler"`
+	UserAgent                   string           `json:"user_agent"`
+	Cookie                      string           `json:"cookie"`
+	Username                    string           `json:"username"`
+	Password                    string           `json:"password"`
+	Category                    *Category        `json:"category,omitempty"`
+	HideGlobally                bool             `json:"hide_globally"`
+	DisableHTTP2                bool             `json:"disable_http2"`
+	PageWatcherRules            PageWatcherRules `json:"page_watcher_rules"`
+	JSONMapping                 JSONMapping      `json:"json_mapping"`
+	MarkUnreadOnChange          bool             `json:"mark_unread_on_change"`
+	StaleSince                  *time.Time       `json:"stale_since"`
+	RedirectedURL               string           `json:"redirected_url"`
+}
+
+// PageWatcherRules contains the CSS selectors used to generate a feed from a HTML page.
+type PageWatcherRules struct {
+	ItemSelector    string `json:"item_selector"`
+	TitleSelector   string `json:"title_selector"`
+	LinkSelector    string `json:"link_selector"`
+	DateSelector    string `json:"date_selector"`
+	ContentSelector string `json:"content_selector"`
+}
+
+// JSONMapping contains the path expressions used to generate a feed from an arbitrary JSON document.
+type JSONMapping struct {
+	ItemsPath   string `json:"items_path"`
+	IDPath      string `json:"id_path"`
+	TitlePath   string `json:"title_path"`
+	URLPath     string `json:"url_path"`
+	DatePath    string `json:"date_path"`
+	ContentPath string `json:"content_path"`
 }
 
 // FeedCreationRequest represents the request to create a feed.
 type FeedCreationRequest struct {
-	FeedURL                     string `json:"feed_url"`
-	CategoryID                  int64  `json:"category_id"`
-	UserAgent                   string `json:"user_agent"`
-	Cookie                      string `json:"cookie"`
-	Username                    string `json:"username"`
-	Password                    string `json:"password"`
-	Crawler                     bool   `json:"crawler"`
-	Disabled                    bool   `json:"disabled"`
-	IgnoreHTTPCache             bool   `json:"ignore_http_cache"`
-	AllowSelfSignedCertificates bool   `json:"allow_self_signed_certificates"`
-	FetchViaProxy               bool   `json:"fetch_via_proxy"`
-	ScraperRules                string `json:"scraper_rules"`
-	RewriteRules                string `json:"rewrite_rules"`
-	BlocklistRules              string `json:"blocklist_rules"`
-	KeeplistRules               string `json:"keeplist_rules"`
-	HideGlobally                bool   `json:"hide_globally"`
-	DisableHTTP2                bool   `json:"disable_http2"`
+	FeedURL                     string           `json:"feed_url"`
+	CategoryID                  int64            `json:"category_id"`
+	UserAgent                   string           `json:"user_agent"`
+	Cookie                      string           `json:"cookie"`
+	Username                    string           `json:"username"`
+	Password                    string           `json:"password"`
+	Crawler                     bool             `json:"crawler"`
+	Disabled                    bool             `json:"disabled"`
+	IgnoreHTTPCache             bool             `json:"ignore_http_cache"`
+	AllowSelfSignedCertificates bool             `json:"allow_self_signed_certificates"`
+	FetchViaProxy               bool             `json:"fetch_via_proxy"`
+	ScraperRules                string           `json:"scraper_rules"`
+	RewriteRules                string           `json:"rewrite_rules"`
+	BlocklistRules              string           `json:"blocklist_rules"`
+	KeeplistRules               string           `json:"keeplist_rules"`
+	HideGlobally                bool             `json:"hide_globally"`
+	DisableHTTP2                bool             `json:"disable_http2"`
+	PageWatcherRules            PageWatcherRules `json:"page_watcher_rules"`
+	JSONMapping                 JSONMapping      `json:"json_mapping"`
+	MarkUnreadOnChange          bool             `json:"mark_unread_on_change"`
 }
 
 // FeedModificationRequest represents the request to update a feed.
 type FeedModificationRequest struct {
-	FeedURL                     *string `json:"feed_url"`
-	SiteURL                     *string `json:"site_url"`
-	Title                       *string `json:"title"`
-	ScraperRules                *string `json:"scraper_rules"`
-	RewriteRules                *string `json:"rewrite_rules"`
-	BlocklistRules              *string `json:"blocklist_rules"`
-	KeeplistRules               *string `json:"keeplist_rules"`
-	Crawler                     *bool   `json:"crawler"`
-	UserAgent                   *string `json:"user_agent"`
-	Cookie                      *string `json:"cookie"`
-	Username                    *string `json:"username"`
-	Password                    *string `json:"password"`
-	CategoryID                  *int64  `json:"category_id"`
-	Disabled                    *bool   `json:"disabled"`
-	IgnoreHTTPCache             *bool   `json:"ignore_http_cache"`
-	AllowSelfSignedCertificates *bool   `json:"allow_self_signed_certificates"`
-	FetchViaProxy               *bool   `json:"fetch_via_proxy"`
-	HideGlobally                *bool   `json:"hide_globally"`
-	DisableHTTP2                *bool   `json:"disable_http2"`
+	FeedURL                     *string           `json:"feed_url"`
+	SiteURL                     *string           `json:"site_url"`
+	Title                       *string           `json:"title"`
+	ScraperRules                *string           `json:"scraper_rules"`
+	RewriteRules                *string           `json:"rewrite_rules"`
+	BlocklistRules              *string           `json:"blocklist_rules"`
+	KeeplistRules               *string           `json:"keeplist_rules"`
+	Crawler                     *bool             `json:"crawler"`
+	UserAgent                   *string           `json:"user_agent"`
+	Cookie                      *string           `json:"cookie"`
+	Username                    *string           `json:"username"`
+	Password                    *string           `json:"password"`
+	CategoryID                  *int64            `json:"category_id"`
+	Disabled                    *bool             `json:"disabled"`
+	IgnoreHTTPCache             *bool             `json:"ignore_http_cache"`
+	AllowSelfSignedCertificates *bool             `json:"allow_self_signed_certificates"`
+	FetchViaProxy               *bool             `json:"fetch_via_proxy"`
+	HideGlobally                *bool             `json:"hide_globally"`
+	DisableHTTP2                *bool             `json:"disable_http2"`
+	PageWatcherRules            *PageWatcherRules `json:"page_watcher_rules"`
+	JSONMapping                 *JSONMapping      `json:"json_mapping"`
+	MarkUnreadOnChange          *bool             `json:"mark_unread_on_change"`
 }
 
 // FeedIcon represents the feed icon.
@@ -196,6 +246,117 @@ type FeedIcon struct {
 	Data     string `json:"data"`
 }
 
+// FeedDiagnostic represents a single feed refresh.
+type FeedDiagnostic struct {
+	ID           int64     `json:"id"`
+	UserID       int64     `json:"user_id"`
+	FeedID       int64     `json:"feed_id"`
+	CheckedAt    time.Time `json:"checked_at"`
+	StatusCode   int       `json:"status_code"`
+	Duration     int64     `json:"duration"`
+	ResponseSize int64     `json:"response_size"`
+	NotModified  bool      `json:"not_modified"`
+	ErrorMsg     string    `json:"error_message"`
+	NewEntries   int       `json:"new_entries"`
+}
+
+// FeedDiagnosticsSummary aggregates the recent refreshes of a feed.
+type FeedDiagnosticsSummary struct {
+	Checks            int        `json:"checks"`
+	Errors            int        `json:"errors"`
+	NotModified       int        `json:"not_modified"`
+	AverageDuration   int64      `json:"average_duration"`
+	NewEntries        int        `json:"new_entries"`
+	LastNewEntriesAt  *time.Time `json:"last_new_entries_at"`
+	SuccessRate       float64    `json:"success_rate"`
+	CacheHitRate      float64    `json:"cache_hit_rate"`
+	FirstCheckedAt    *time.Time `json:"first_checked_at"`
+	ConsecutiveErrors int        `json:"consecutive_errors"`
+}
+
+// FeedDiagnosticsResult represents the response of the feed diagnostics endpoint.
+type FeedDiagnosticsResult struct {
+	Summary     *FeedDiagnosticsSummary `json:"summary"`
+	Diagnostics []*FeedDiagnostic       `json:"diagnostics"`
+}
+
+// FeedSuggestion proposes to unsubscribe from a stale feed or to update the URL of a moved feed.
+type FeedSuggestion struct {
+	FeedID      int64      `json:"feed_id"`
+	FeedTitle   string     `json:"feed_title"`
+	FeedURL     string     `json:"feed_url"`
+	SiteURL     string     `json:"site_url"`
+	Reason      string     `json:"reason"`
+	LastEntryAt *time.Time `json:"last_entry_at,omitempty"`
+	NewFeedURL  string     `json:"new_feed_url,omitempty"`
+}
+
+// EntryRule applies actions to new entries matching its conditions.
+type EntryRule struct {
+	ID         int64                 `json:"id"`
+	UserID     int64                 `json:"user_id"`
+	Title      string                `json:"title"`
+	Disabled   bool                  `json:"disabled"`
+	MatchAll   bool                  `json:"match_all"`
+	Conditions []*EntryRuleCondition `json:"conditions"`
+	Actions    []*EntryRuleAction    `json:"actions"`
+	CreatedAt  time.Time             `json:"created_at"`
+}
+
+// EntryRuleCondition compares an entry field with a value.
+type EntryRuleCondition struct {
+	Field    string `json:"field"`
+	Operator string `json:"operator"`
+	Value    string `json:"value"`
+}
+
+// EntryRuleAction is applied to the entries matching a rule.
+type EntryRuleAction struct {
+	Type  string `json:"type"`
+	Value string `json:"value,omitempty"`
+}
+
+// EntryRuleRequest represents the request to create, update or preview a rule.
+type EntryRuleRequest struct {
+	Title      string                `json:"title"`
+	Disabled   bool                  `json:"disabled"`
+	MatchAll   bool                  `json:"match_all"`
+	Conditions []*EntryRuleCondition `json:"conditions"`
+	Actions    []*EntryRuleAction    `json:"actions"`
+}
+
+// UserTag represents a tag added by the user to some entries.
+type UserTag struct {
+	Name       string `json:"name"`
+	EntryCount int    `json:"entry_count"`
+}
+
+// EntryAnnotation represents a passage highlighted in an entry, with an optional note.
+type EntryAnnotation struct {
+	ID          int64     `json:"id"`
+	UserID      int64     `json:"user_id"`
+	EntryID     int64     `json:"entry_id"`
+	Text        string    `json:"text"`
+	Note        string    `json:"note"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+	EntryTitle  string    `json:"entry_title"`
+	EntryURL    string    `json:"entry_url"`
+	EntryAuthor string    `json:"entry_author"`
+}
+
+// EntryAnnotationRequest represents the request to create an annotation.
+type EntryAnnotationRequest struct {
+	Text string `json:"text"`
+	Note string `json:"note"`
+}
+
+// EntryAnnotationModificationRequest represents the request to update an annotation.
+type EntryAnnotationModificationRequest struct {
+	Text *string `json:"text"`
+	Note *string `json:"note"`
+}
+
 type FeedCounters struct {
 	ReadCounters   map[int64]int `json:"reads"`
 	UnreadCounters map[int64]int `json:"unreads"`
@@ -206,25 +367,29 @@ type Feeds []*Feed
 
 // Entry represents a subscription item in the system.
 type Entry struct {
-	ID          int64      `json:"id"`
-	Date        time.Time  `json:"published_at"`
-	ChangedAt   time.Time  `json:"changed_at"`
-	CreatedAt   time.Time  `json:"created_at"`
-	Feed        *Feed      `json:"feed,omitempty"`
-	Hash        string     `json:"hash"`
-	URL         string     `json:"url"`
-	CommentsURL string     `json:"comments_url"`
-	Title       string     `json:"title"`
-	Status      string     `json:"status"`
-	Content     string     `json:"content"`
-	Author      string     `json:"author"`
-	ShareCode   string     `json:"share_code"`
-	Enclosures  Enclosures `json:"enclosures,omitempty"`
-	Tags        []string   `json:"tags"`
-	ReadingTime int        `json:"reading_time"`
-	UserID      int64      `json:"user_id"`
-	FeedID      int64      `json:"feed_id"`
-	Starred     bool       `json:"starred"`
+	ID            int64      `json:"id"`
+	Date          time.Time  `json:"published_at"`
+	ChangedAt     time.Time  `json:"changed_at"`
+	CreatedAt     time.Time  `json:"created_at"`
+	Feed          *Feed      `json:"feed,omitempty"`
+	Hash          string     `json:"hash"`
+	URL           string     `json:"url"`
+	CommentsURL   string     `json:"comments_url"`
+	Title         string     `json:"title"`
+	Status        string     `json:"status"`
+	Content       string     `json:"content"`
+	Author        string     `json:"author"`
+	ShareCode     string     `json:"share_code"`
+	Enclosures    Enclosures `json:"enclosures,omitempty"`
+	Tags          []string   `json:"tags"`
+	UserTags      []string   `json:"user_tags"`
+	Podcast       *Podcast   `json:"podcast,omitempty"`
+	ReadingTime   int        `json:"reading_time"`
+	UserID        int64      `json:"user_id"`
+	FeedID        int64      `json:"feed_id"`
+	Starred       bool       `json:"starred"`
+	Similarity    float64    `json:"similarity,omitempty"`
+	StorySiblings int        `json:"story_siblings,omitempty"`
 }
 
 // EntryModificationRequest represents a request to modify an entry.
@@ -236,19 +401,88 @@ type EntryModificationRequest struct {
 // Entries represents a list of entries.
 type Entries []*Entry
 
+// EntryRevision represents a previous version of an entry.
+type EntryRevision struct {
+	ID        int64     `json:"id"`
+	UserID    int64     `json:"user_id"`
+	EntryID   int64     `json:"entry_id"`
+	Title     string    `json:"title"`
+	Content   string    `json:"content"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// EntryRevisions represents a list of entry revisions.
+type EntryRevisions []*EntryRevision
+
+// EntryRevisionDiff represents the changes between a revision and the version that replaced it.
+type EntryRevisionDiff struct {
+	Revision *EntryRevision `json:"revision"`
+	Title    string         `json:"title"`
+	Content  string         `json:"content"`
+}
+
 // Enclosure represents an attachment.
 type Enclosure struct {
-	ID       int64  `json:"id"`
-	UserID   int64  `json:"user_id"`
-	EntryID  int64  `json:"entry_id"`
-	URL      string `json:"url"`
-	MimeType string `json:"mime_type"`
-	Size     int    `json:"size"`
+	ID          int64               `json:"id"`
+	UserID      int64               `json:"user_id"`
+	EntryID     int64               `json:"entry_id"`
+	URL         string              `json:"url"`
+	MimeType    string              `json:"mime_type"`
+	Size        int                 `json:"size"`
+	Alternate   bool                `json:"alternate"`
+	ChaptersURL string              `json:"chapters_url"`
+	Chapters    []PodcastChapter    `json:"chapters"`
+	Transcripts []PodcastTranscript `json:"transcripts"`
 }
 
 // Enclosures represents a list of attachments.
 type Enclosures []*Enclosure
 
+// Podcast represents the Podcasting 2.0 metadata of an entry.
+type Podcast struct {
+	Persons    []PodcastPerson    `json:"persons,omitempty"`
+	Funding    []PodcastFunding   `json:"funding,omitempty"`
+	Soundbites []PodcastSoundbite `json:"soundbites,omitempty"`
+}
+
+// PodcastPerson represents a person involved in an episode.
+type PodcastPerson struct {
+	Name     string `json:"name"`
+	Role     string `json:"role"`
+	Group    string `json:"group"`
+	ImageURL string `json:"image_url,omitempty"`
+	URL      string `json:"url,omitempty"`
+}
+
+// PodcastFunding represents a link to donate to a podcast.
+type PodcastFunding struct {
+	URL   string `json:"url"`
+	Title string `json:"title"`
+}
+
+// PodcastSoundbite represents a short segment of an episode.
+type PodcastSoundbite struct {
+	StartTime float64 `json:"start_time"`
+	Duration  float64 `json:"duration"`
+	Title     string  `json:"title"`
+}
+
+// PodcastChapter represents a section of an audio or video file.
+type PodcastChapter struct {
+	StartTime float64 `json:"start_time"`
+	Title     string  `json:"title"`
+	URL       string  `json:"url,omitempty"`
+	ImageURL  string  `json:"image_url,omitempty"`
+}
+
+// PodcastTranscript represents a link to the transcript of an audio or video file.
+type PodcastTranscript struct {
+	URL      string `json:"url"`
+	MimeType string `json:"mime_type"`
+	Language string `json:"language,omitempty"`
+	Rel      string `json:"rel,omitempty"`
+}
+
 const (
 	FilterNotStarred  = "0"
 	FilterOnlyStarred = "1"
@@ -271,9 +505,12 @@ type Filter struct {
 	BeforeEntryID   int64
 	AfterEntryID    int64
 	Search          string
+	SearchMode      string
 	CategoryID      int64
 	FeedID          int64
 	Statuses        []string
+	UserTags        []string
+	CollapseSimilar bool
 }
 
 // EntryResultSet represents the response when fetching entries.
@@ -49,15 +49,27 @@ func Serve(router *mux.Router, store *storage.Storage, pool *worker.Pool) {
 	sr.HandleFunc("/categories/{categoryID}/entries", handler.getCategoryEntries).Methods(http.MethodGet)
 	sr.HandleFunc("/categories/{categoryID}/entries/{entryID}", handler.getCategoryEntry).Methods(http.MethodGet)
 	sr.HandleFunc("/discover", handler.discoverSubscriptions).Methods(http.MethodPost)
+	sr.HandleFunc("/tags", handler.getUserTags).Methods(http.MethodGet)
+	sr.HandleFunc("/tags/{tagName}", handler.renameUserTag).Methods(http.MethodPut)
+	sr.HandleFunc("/tags/{tagName}", handler.removeUserTag).Methods(http.MethodDelete)
+	sr.HandleFunc("/rules", handler.createEntryRule).Methods(http.MethodPost)
+	sr.HandleFunc("/rules", handler.getEntryRules).Methods(http.MethodGet)
+	sr.HandleFunc("/rules/preview", handler.previewEntryRule).Methods(http.MethodPost)
+	sr.HandleFunc("/rules/{ruleID}", handler.getEntryRule).Methods(http.MethodGet)
+	sr.HandleFunc("/rules/{ruleID}", handler.updateEntryRule).Methods(http.MethodPut)
+	sr.HandleFunc("/rules/{ruleID}", handler.removeEntryRule).Methods(http.MethodDelete)
 	sr.HandleFunc("/feeds", handler.createFeed).Methods(http.MethodPost)
 	sr.HandleFunc("/feeds", handler.getFeeds).Methods(http.MethodGet)
 	sr.HandleFunc("/feeds/counters", handler.fetchCounters).Methods(http.MethodGet)
 	sr.HandleFunc("/feeds/refresh", handler.refreshAllFeeds).Methods(http.MethodPut)
+	sr.HandleFunc("/feeds/suggestions", handler.getFeedSuggestions).Methods(http.MethodGet)
 	sr.HandleFunc("/feeds/{feedID}/refresh", handler.refreshFeed).Methods(http.MethodPut)
 	sr.HandleFunc("/feeds/{feedID}", handler.getFeed).Methods(http.MethodGet)
 	sr.HandleFunc("/feeds/{feedID}", handler.updateFeed).Methods(http.MethodPut)
 	sr.HandleFunc("/feeds/{feedID}", handler.removeFeed).Methods(http.MethodDelete)
 	sr.HandleFunc("/feeds/{feedID}/icon", handler.getIconByFeedID).Methods(http.MethodGet)
+	sr.HandleFunc("/feeds/{feedID}/diagnostics", handler.getFeedDiagnostics).Methods(http.MethodGet)
+	sr.HandleFunc("/feeds/{feedID}/dismiss-suggestions", handler.dismissFeedSuggestions).Methods(http.MethodPut)
 	sr.HandleFunc("/feeds/{feedID}/mark-all-as-read", handler.markFeedAsRead).Methods(http.MethodPut)
 	sr.HandleFunc("/export", handler.exportFeeds).Methods(http.MethodGet)
 	sr.HandleFunc("/import", handler.importFeeds).Methods(http.MethodPost)
@@ -68,8 +80,18 @@ func Serve(router *mux.Router, store *storage.Storage, pool *worker.Pool) {
 	sr.HandleFunc("/entries/{entryID}", handler.getEntry).Methods(http.MethodGet)
 	sr.HandleFunc("/entries/{entryID}", handler.updateEntry).Methods(http.MethodPut)
 	sr.HandleFunc("/entries/{entryID}/bookmark", handler.toggleBookmark).Methods(http.MethodPut)
+	sr.HandleFunc("/entries/{entryID}/tags", handler.updateEntryUserTags).Methods(http.MethodPut)
+	sr.HandleFunc("/entries/{entryID}/annotations", handler.getEntryAnnotations).Methods(http.MethodGet)
+	sr.HandleFunc("/entries/{entryID}/annotations", handler.createEntryAnnotation).Methods(http.MethodPost)
+	sr.HandleFunc("/entries/{entryID}/annotations/save", handler.saveEntryAnnotations).Methods(http.MethodPost)
+	sr.HandleFunc("/entries/{entryID}/annotations/{annotationID}", handler.updateEntryAnnotation).Methods(http.MethodPut)
+	sr.HandleFunc("/entries/{entryID}/annotations/{annotationID}", handler.removeEntryAnnotation).Methods(http.MethodDelete)
 	sr.HandleFunc("/entries/{entryID}/save", handler.saveEntry).Methods(http.MethodPost)
 	sr.HandleFunc("/entries/{entryID}/fetch-content", handler.fetchContent).Methods(http.MethodGet)
+	sr.HandleFunc("/entries/{entryID}/similar", handler.getSimilarEntries).Methods(http.MethodGet)
+	sr.HandleFunc("/entries/{entryID}/similar/mark-all-as-read", handler.markSimilarEntriesAsRead).Methods(http.MethodPut)
+	sr.HandleFunc("/entries/{entryID}/revisions", handler.getEntryRevisions).Methods(http.MethodGet)
+	sr.HandleFunc("/entries/{entryID}/revisions/{revisionID}/diff", handler.getEntryRevisionDiff).Methods(http.MethodGet)
 	sr.HandleFunc("/flush-history", handler.flushHistory).Methods(http.MethodPut, http.MethodDelete)
 	sr.HandleFunc("/icons/{iconID}", handler.getIconByIconID).Methods(http.MethodGet)
 	sr.HandleFunc("/version", handler.versionHandler).Methods(http.MethodGet)
@@ -1521,6 +1521,166 @@ func TestGetFeedEndpoint(t *testing.T) {
 	}
 }
 
+func TestGetFeedDiagnostics(t *testing.T) {
+	testConfig := newIntegrationTestConfig()
+	if !testConfig.isConfigured() {
+		t.Skip(skipIntegrationTestsMessage)
+	}
+
+	adminClient := miniflux.NewClient(testConfig.testBaseURL, testConfig.testAdminUsername, testConfig.testAdminPassword)
+
+	regularTestUser, err := adminClient.CreateUser(testConfig.genRandomUsername(), testConfig.testRegularPassword, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer adminClient.DeleteUser(regularTestUser.ID)
+
+	regularUserClient := miniflux.NewClient(testConfig.testBaseURL, regularTestUser.Username, testConfig.testRegularPassword)
+
+	feedID, err := regularUserClient.CreateFeed(&miniflux.FeedCreationRequest{
+		FeedURL: testConfig.testFeedURL,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := regularUserClient.FeedDiagnostics(feedID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if result.Summary == nil {
+		t.Fatalf(`Invalid summary, got nil`)
+	}
+
+	if result.Summary.Checks != len(result.Diagnostics) {
+		t.Fatalf(`Invalid number of checks, got %d for %d diagnostics`, result.Summary.Checks, len(result.Diagnostics))
+	}
+
+	if _, err := regularUserClient.FeedDiagnostics(123456789); err != miniflux.ErrNotFound {
+		t.Fatalf(`Fetching diagnostics of an unknown feed should return a not found error, got %v`, err)
+	}
+}
+
+func TestGetFeedSuggestions(t *testing.T) {
+	testConfig := newIntegrationTestConfig()
+	if !testConfig.isConfigured() {
+		t.Skip(skipIntegrationTestsMessage)
+	}
+
+	adminClient := miniflux.NewClient(testConfig.testBaseURL, testConfig.testAdminUsername, testConfig.testAdminPassword)
+
+	regularTestUser, err := adminClient.CreateUser(testConfig.genRandomUsername(), testConfig.testRegularPassword, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer adminClient.DeleteUser(regularTestUser.ID)
+
+	regularUserClient := miniflux.NewClient(testConfig.testBaseURL, regularTestUser.Username, testConfig.testRegularPassword)
+
+	feedID, err := regularUserClient.CreateFeed(&miniflux.FeedCreationRequest{
+		FeedURL: testConfig.testFeedURL,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	suggestions, err := regularUserClient.FeedSuggestions()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(suggestions) != 0 {
+		t.Fatalf(`Invalid number of suggestions for a new feed, got %d instead of 0`, len(suggestions))
+	}
+
+	if err := regularUserClient.DismissFeedSuggestions(feedID); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := regularUserClient.DismissFeedSuggestions(123456789); err != miniflux.ErrNotFound {
+		t.Fatalf(`Dismissing suggestions of an unknown feed should return a not found error, got %v`, err)
+	}
+}
+
+func TestEntryRuleEndpoints(t *testing.T) {
+	testConfig := newIntegrationTestConfig()
+	if !testConfig.isConfigured() {
+		t.Skip(skipIntegrationTestsMessage)
+	}
+
+	adminClient := miniflux.NewClient(testConfig.testBaseURL, testConfig.testAdminUsername, testConfig.testAdminPassword)
+
+	regularTestUser, err := adminClient.CreateUser(testConfig.genRandomUsername(), testConfig.testRegularPassword, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer adminClient.DeleteUser(regularTestUser.ID)
+
+	regularUserClient := miniflux.NewClient(testConfig.testBaseURL, regularTestUser.Username, testConfig.testRegularPassword)
+
+	if _, err := regularUserClient.CreateFeed(&miniflux.FeedCreationRequest{FeedURL: testConfig.testFeedURL}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := regularUserClient.CreateEntryRule(&miniflux.EntryRuleRequest{Title: "Invalid"}); err == nil {
+		t.Fatal(`Creating a rule without conditions should fail`)
+	}
+
+	ruleRequest := &miniflux.EntryRuleRequest{
+		Title:      "Everything",
+		MatchAll:   true,
+		Conditions: []*miniflux.EntryRuleCondition{{Field: "title", Operator: "matches", Value: ".*"}},
+		Actions:    []*miniflux.EntryRuleAction{{Type: "add_tag", Value: "everything"}},
+	}
+
+	rule, err := regularUserClient.CreateEntryRule(ruleRequest)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if rule.ID == 0 || rule.Title != "Everything" || len(rule.Conditions) != 1 || len(rule.Actions) != 1 {
+		t.Fatalf(`Invalid rule returned by the API: %+v`, rule)
+	}
+
+	ruleRequest.Title = "Renamed"
+	ruleRequest.Disabled = true
+	updatedRule, err := regularUserClient.UpdateEntryRule(rule.ID, ruleRequest)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if updatedRule.Title != "Renamed" || !updatedRule.Disabled {
+		t.Fatalf(`The rule has not been updated: %+v`, updatedRule)
+	}
+
+	rules, err := regularUserClient.EntryRules()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(rules) != 1 {
+		t.Fatalf(`Invalid number of rules, got %d instead of 1`, len(rules))
+	}
+
+	result, err := regularUserClient.PreviewEntryRule(ruleRequest)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if result.Total != len(result.Entries) || result.Total == 0 {
+		t.Fatalf(`Invalid preview result, got %d entries for a total of %d`, len(result.Entries), result.Total)
+	}
+
+	if err := regularUserClient.DeleteEntryRule(rule.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := regularUserClient.EntryRule(rule.ID); err != miniflux.ErrNotFound {
+		t.Fatalf(`Fetching a removed rule should return a not found error, got %v`, err)
+	}
+}
+
 func TestGetFeedIcon(t *testing.T) {
 	testConfig := newIntegrationTestConfig()
 	if !testConfig.isConfigured() {
@@ -1973,6 +2133,15 @@ func TestGetAllEntriesEndpointWithFilter(t *testing.T) {
 		t.Fatalf(`Invalid total, got %d`, searchedEntries.Total)
 	}
 
+	semanticEntries, err := regularUserClient.Entries(&miniflux.Filter{Search: "Miniflux release", SearchMode: miniflux.SearchModeSemantic, Limit: 1})
+	if err != nil {
+		t.Fatalf(`Semantic search failed: %v`, err)
+	}
+
+	if len(semanticEntries.Entries) != 1 || semanticEntries.Total < 1 {
+		t.Fatalf(`Invalid semantic search result, got %d entries out of %d`, len(semanticEntries.Entries), semanticEntries.Total)
+	}
+
 	if _, err := regularUserClient.Entries(&miniflux.Filter{Status: "invalid"}); err == nil {
 		t.Fatal(`Using invalid status should raise an error`)
 	}
@@ -2050,6 +2219,234 @@ func TestGetEntryEndpoints(t *testing.T) {
 	}
 }
 
+func TestGetSimilarEntriesEndpoint(t *testing.T) {
+	testConfig := newIntegrationTestConfig()
+	if !testConfig.isConfigured() {
+		t.Skip(skipIntegrationTestsMessage)
+	}
+
+	adminClient := miniflux.NewClient(testConfig.testBaseURL, testConfig.testAdminUsername, testConfig.testAdminPassword)
+
+	regularTestUser, err := adminClient.CreateUser(testConfig.genRandomUsername(), testConfig.testRegularPassword, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer adminClient.DeleteUser(regularTestUser.ID)
+
+	regularUserClient := miniflux.NewClient(testConfig.testBaseURL, regularTestUser.Username, testConfig.testRegularPassword)
+
+	feedID, err := regularUserClient.CreateFeed(&miniflux.FeedCreationRequest{
+		FeedURL: testConfig.testFeedURL,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := regularUserClient.FeedEntries(feedID, &miniflux.Filter{Limit: 1})
+	if err != nil {
+		t.Fatalf(`Failed to get entries: %v`, err)
+	}
+
+	similar, err := regularUserClient.SimilarEntries(result.Entries[0].ID, &miniflux.Filter{Status: miniflux.EntryStatusUnread, Limit: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if similar.Total < len(similar.Entries) {
+		t.Fatalf(`Invalid total, got %d for %d entries`, similar.Total, len(similar.Entries))
+	}
+
+	for i := 1; i < len(similar.Entries); i++ {
+		if similar.Entries[i].Similarity > similar.Entries[i-1].Similarity {
+			t.Fatalf(`Similar entries are not ordered by similarity score`)
+		}
+	}
+
+	if _, err := regularUserClient.SimilarEntries(123456789, nil); err != miniflux.ErrNotFound {
+		t.Fatalf(`Fetching similar entries of an unknown entry should return a not found error, got %v`, err)
+	}
+}
+
+func TestGetEntryRevisionsEndpoint(t *testing.T) {
+	testConfig := newIntegrationTestConfig()
+	if !testConfig.isConfigured() {
+		t.Skip(skipIntegrationTestsMessage)
+	}
+
+	adminClient := miniflux.NewClient(testConfig.testBaseURL, testConfig.testAdminUsername, testConfig.testAdminPassword)
+
+	regularTestUser, err := adminClient.CreateUser(testConfig.genRandomUsername(), testConfig.testRegularPassword, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer adminClient.DeleteUser(regularTestUser.ID)
+
+	regularUserClient := miniflux.NewClient(testConfig.testBaseURL, regularTestUser.Username, testConfig.testRegularPassword)
+
+	feedID, err := regularUserClient.CreateFeed(&miniflux.FeedCreationRequest{
+		FeedURL: testConfig.testFeedURL,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := regularUserClient.FeedEntries(feedID, &miniflux.Filter{Limit: 1})
+	if err != nil {
+		t.Fatalf(`Failed to get entries: %v`, err)
+	}
+
+	revisions, err := regularUserClient.EntryRevisions(result.Entries[0].ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(revisions) != 0 {
+		t.Fatalf(`A new entry should not have any revision, got %d`, len(revisions))
+	}
+
+	if _, err := regularUserClient.EntryRevisionDiff(result.Entries[0].ID, 123456789); err != miniflux.ErrNotFound {
+		t.Fatalf(`Fetching an unknown revision should return a not found error, got %v`, err)
+	}
+
+	if _, err := regularUserClient.EntryRevisions(123456789); err != miniflux.ErrNotFound {
+		t.Fatalf(`Fetching revisions of an unknown entry should return a not found error, got %v`, err)
+	}
+}
+
+func TestGetEntriesWithCollapsedStories(t *testing.T) {
+	testConfig := newIntegrationTestConfig()
+	if !testConfig.isConfigured() {
+		t.Skip(skipIntegrationTestsMessage)
+	}
+
+	adminClient := miniflux.NewClient(testConfig.testBaseURL, testConfig.testAdminUsername, testConfig.testAdminPassword)
+
+	regularTestUser, err := adminClient.CreateUser(testConfig.genRandomUsername(), testConfig.testRegularPassword, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer adminClient.DeleteUser(regularTestUser.ID)
+
+	regularUserClient := miniflux.NewClient(testConfig.testBaseURL, regularTestUser.Username, testConfig.testRegularPassword)
+
+	if _, err := regularUserClient.CreateFeed(&miniflux.FeedCreationRequest{FeedURL: testConfig.testFeedURL}); err != nil {
+		t.Fatal(err)
+	}
+
+	allEntries, err := regularUserClient.Entries(&miniflux.Filter{Status: miniflux.EntryStatusUnread})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	collapsedEntries, err := regularUserClient.Entries(&miniflux.Filter{Status: miniflux.EntryStatusUnread, CollapseSimilar: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	siblings := 0
+	for _, entry := range collapsedEntries.Entries {
+		siblings += entry.StorySiblings
+	}
+
+	if collapsedEntries.Total+siblings != allEntries.Total {
+		t.Fatalf(`Collapsed entries and their siblings should add up to %d entries, got %d + %d`, allEntries.Total, collapsedEntries.Total, siblings)
+	}
+}
+
+func TestMarkSimilarEntriesAsReadEndpoint(t *testing.T) {
+	testConfig := newIntegrationTestConfig()
+	if !testConfig.isConfigured() {
+		t.Skip(skipIntegrationTestsMessage)
+	}
+
+	adminClient := miniflux.NewClient(testConfig.testBaseURL, testConfig.testAdminUsername, testConfig.testAdminPassword)
+
+	regularTestUser, err := adminClient.CreateUser(testConfig.genRandomUsername(), testConfig.testRegularPassword, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer adminClient.DeleteUser(regularTestUser.ID)
+
+	otherTestUser, err := adminClient.CreateUser(testConfig.genRandomUsername(), testConfig.testRegularPassword, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer adminClient.DeleteUser(otherTestUser.ID)
+
+	regularUserClient := miniflux.NewClient(testConfig.testBaseURL, regularTestUser.Username, testConfig.testRegularPassword)
+	otherUserClient := miniflux.NewClient(testConfig.testBaseURL, otherTestUser.Username, testConfig.testRegularPassword)
+
+	if _, err := regularUserClient.CreateFeed(&miniflux.FeedCreationRequest{FeedURL: testConfig.testFeedURL}); err != nil {
+		t.Fatal(err)
+	}
+
+	collapsedEntries, err := regularUserClient.Entries(&miniflux.Filter{Status: miniflux.EntryStatusUnread, CollapseSimilar: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(collapsedEntries.Entries) == 0 {
+		t.Fatal(`The feed should have unread entries`)
+	}
+
+	entryID := collapsedEntries.Entries[0].ID
+	for _, entry := range collapsedEntries.Entries {
+		if entry.StorySiblings > 0 {
+			entryID = entry.ID
+			break
+		}
+	}
+
+	unreadSimilarEntries, err := regularUserClient.SimilarEntries(entryID, &miniflux.Filter{Status: miniflux.EntryStatusUnread})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := otherUserClient.MarkSimilarEntriesAsRead(entryID); err != miniflux.ErrNotFound {
+		t.Fatalf(`Marking the similar entries of another user's entry should return a not found error, got %v`, err)
+	}
+
+	result, err := regularUserClient.SimilarEntries(entryID, &miniflux.Filter{Status: miniflux.EntryStatusUnread})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if result.Total != unreadSimilarEntries.Total {
+		t.Fatalf(`Another user should not change the similar entries status, got %d unread instead of %d`, result.Total, unreadSimilarEntries.Total)
+	}
+
+	if err := regularUserClient.MarkSimilarEntriesAsRead(entryID); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err = regularUserClient.SimilarEntries(entryID, &miniflux.Filter{Status: miniflux.EntryStatusUnread})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if result.Total != 0 {
+		t.Fatalf(`All the similar entries should be read, got %d unread`, result.Total)
+	}
+
+	readSimilarEntries, err := regularUserClient.SimilarEntries(entryID, &miniflux.Filter{Status: miniflux.EntryStatusRead})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if readSimilarEntries.Total < unreadSimilarEntries.Total {
+		t.Fatalf(`The %d unread similar entries should be read, got %d read`, unreadSimilarEntries.Total, readSimilarEntries.Total)
+	}
+
+	entry, err := regularUserClient.Entry(entryID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if entry.Status != miniflux.EntryStatusUnread {
+		t.Fatalf(`The entry itself should stay unread, got %q`, entry.Status)
+	}
+}
+
 func TestUpdateEntryStatusEndpoint(t *testing.T) {
 	testConfig := newIntegrationTestConfig()
 	if !testConfig.isConfigured() {
@@ -2194,6 +2591,226 @@ func TestToggleBookmarkEndpoint(t *testing.T) {
 	}
 }
 
+func TestUserTagEndpoints(t *testing.T) {
+	testConfig := newIntegrationTestConfig()
+	if !testConfig.isConfigured() {
+		t.Skip(skipIntegrationTestsMessage)
+	}
+
+	adminClient := miniflux.NewClient(testConfig.testBaseURL, testConfig.testAdminUsername, testConfig.testAdminPassword)
+
+	regularTestUser, err := adminClient.CreateUser(testConfig.genRandomUsername(), testConfig.testRegularPassword, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer adminClient.DeleteUser(regularTestUser.ID)
+
+	regularUserClient := miniflux.NewClient(testConfig.testBaseURL, regularTestUser.Username, testConfig.testRegularPassword)
+
+	feedID, err := regularUserClient.CreateFeed(&miniflux.FeedCreationRequest{
+		FeedURL: testConfig.testFeedURL,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := regularUserClient.FeedEntries(feedID, &miniflux.Filter{Limit: 2})
+	if err != nil {
+		t.Fatalf(`Failed to get entries: %v`, err)
+	}
+
+	if err := regularUserClient.UpdateEntryUserTags(result.Entries[0].ID, []string{"later", " later ", "work"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := regularUserClient.UpdateEntryUserTags(result.Entries[1].ID, []string{"to read"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := regularUserClient.UpdateEntryUserTags(result.Entries[1].ID, []string{strings.Repeat("a", 101)}); err == nil {
+		t.Fatal(`A label longer than 100 characters should be rejected`)
+	}
+
+	entry, err := regularUserClient.Entry(result.Entries[0].ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(entry.UserTags) != 2 || entry.UserTags[0] != "later" || entry.UserTags[1] != "work" {
+		t.Fatalf(`Invalid user tags, got %q`, entry.UserTags)
+	}
+
+	if err := regularUserClient.RenameUserTag("to read", "later"); err != nil {
+		t.Fatal(err)
+	}
+
+	tags, err := regularUserClient.UserTags()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(tags) != 2 || tags[0].Name != "later" || tags[0].EntryCount != 2 {
+		t.Fatalf(`The tags have not been merged, got %+v`, tags)
+	}
+
+	taggedEntries, err := regularUserClient.Entries(&miniflux.Filter{UserTags: []string{"later"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if taggedEntries.Total != 2 {
+		t.Fatalf(`Invalid number of tagged entries, got %d instead of 2`, taggedEntries.Total)
+	}
+
+	if err := regularUserClient.DeleteUserTag("later"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := regularUserClient.DeleteUserTag("later"); err != miniflux.ErrNotFound {
+		t.Fatalf(`Removing an unknown tag should return a not found error, got %v`, err)
+	}
+}
+
+func TestEntryAnnotationEndpoints(t *testing.T) {
+	testConfig := newIntegrationTestConfig()
+	if !testConfig.isConfigured() {
+		t.Skip(skipIntegrationTestsMessage)
+	}
+
+	adminClient := miniflux.NewClient(testConfig.testBaseURL, testConfig.testAdminUsername, testConfig.testAdminPassword)
+
+	regularTestUser, err := adminClient.CreateUser(testConfig.genRandomUsername(), testConfig.testRegularPassword, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer adminClient.DeleteUser(regularTestUser.ID)
+
+	regularUserClient := miniflux.NewClient(testConfig.testBaseURL, regularTestUser.Username, testConfig.testRegularPassword)
+
+	feedID, err := regularUserClient.CreateFeed(&miniflux.FeedCreationRequest{
+		FeedURL: testConfig.testFeedURL,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := regularUserClient.FeedEntries(feedID, &miniflux.Filter{Limit: 1})
+	if err != nil {
+		t.Fatalf(`Failed to get entries: %v`, err)
+	}
+	entryID := result.Entries[0].ID
+
+	if _, err := regularUserClient.CreateEntryAnnotation(entryID, &miniflux.EntryAnnotationRequest{Text: " "}); err == nil {
+		t.Fatal(`Creating an annotation without text should fail`)
+	}
+
+	annotation, err := regularUserClient.CreateEntryAnnotation(entryID, &miniflux.EntryAnnotationRequest{Text: "A quote", Note: "A note"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if annotation.ID == 0 || annotation.EntryID != entryID || annotation.Text != "A quote" || annotation.Note != "A note" {
+		t.Fatalf(`Invalid annotation returned by the API: %+v`, annotation)
+	}
+
+	note := "Another note"
+	updatedAnnotation, err := regularUserClient.UpdateEntryAnnotation(entryID, annotation.ID, &miniflux.EntryAnnotationModificationRequest{Note: &note})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if updatedAnnotation.Text != "A quote" || updatedAnnotation.Note != note {
+		t.Fatalf(`The annotation has not been updated: %+v`, updatedAnnotation)
+	}
+
+	annotations, err := regularUserClient.EntryAnnotations(entryID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(annotations) != 1 {
+		t.Fatalf(`Invalid number of annotations, got %d instead of 1`, len(annotations))
+	}
+
+	if err := regularUserClient.SaveEntryAnnotations(entryID); err == nil {
+		t.Fatal(`Saving annotations without integration should fail`)
+	}
+
+	if err := regularUserClient.DeleteEntryAnnotation(entryID, annotation.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := regularUserClient.DeleteEntryAnnotation(entryID, annotation.ID); err != miniflux.ErrNotFound {
+		t.Fatalf(`Removing an unknown annotation should return a not found error, got %v`, err)
+	}
+}
+
+func TestEntryAnnotationsOfStarredEntrySurviveArchiving(t *testing.T) {
+	testConfig := newIntegrationTestConfig()
+	if !testConfig.isConfigured() {
+		t.Skip(skipIntegrationTestsMessage)
+	}
+
+	adminClient := miniflux.NewClient(testConfig.testBaseURL, testConfig.testAdminUsername, testConfig.testAdminPassword)
+
+	regularTestUser, err := adminClient.CreateUser(testConfig.genRandomUsername(), testConfig.testRegularPassword, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer adminClient.DeleteUser(regularTestUser.ID)
+
+	regularUserClient := miniflux.NewClient(testConfig.testBaseURL, regularTestUser.Username, testConfig.testRegularPassword)
+
+	feedID, err := regularUserClient.CreateFeed(&miniflux.FeedCreationRequest{
+		FeedURL: testConfig.testFeedURL,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := regularUserClient.FeedEntries(feedID, &miniflux.Filter{Limit: 2})
+	if err != nil {
+		t.Fatalf(`Failed to get entries: %v`, err)
+	}
+
+	if len(result.Entries) != 2 {
+		t.Fatalf(`Invalid number of entries, got %d instead of 2`, len(result.Entries))
+	}
+	starredEntryID, otherEntryID := result.Entries[0].ID, result.Entries[1].ID
+
+	for _, entryID := range []int64{starredEntryID, otherEntryID} {
+		if _, err := regularUserClient.CreateEntryAnnotation(entryID, &miniflux.EntryAnnotationRequest{Text: "A quote"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := regularUserClient.ToggleBookmark(starredEntryID); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := regularUserClient.UpdateEntries([]int64{starredEntryID, otherEntryID}, miniflux.EntryStatusRead); err != nil {
+		t.Fatal(err)
+	}
+
+	// Archiving removes the read entries, except the starred ones.
+	if err := regularUserClient.FlushHistory(); err != nil {
+		t.Fatal(err)
+	}
+
+	annotations, err := regularUserClient.EntryAnnotations(starredEntryID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(annotations) != 1 || annotations[0].Text != "A quote" {
+		t.Fatalf(`The annotations of the starred entry should survive archiving, got %+v`, annotations)
+	}
+
+	if _, err := regularUserClient.EntryAnnotations(otherEntryID); err != miniflux.ErrNotFound {
+		t.Fatalf(`The annotations of an archived entry should not be returned, got %v`, err)
+	}
+}
+
 func TestSaveEntryEndpoint(t *testing.T) {
 	testConfig := newIntegrationTestConfig()
 	if !testConfig.isConfigured() {
@@ -17,8 +17,10 @@ import (
 	"miniflux.app/v2/internal/integration"
 	"miniflux.app/v2/internal/mediaproxy"
 	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/reader/fetcher"
 	"miniflux.app/v2/internal/reader/processor"
 	"miniflux.app/v2/internal/reader/readingtime"
+	"miniflux.app/v2/internal/similarity"
 	"miniflux.app/v2/internal/storage"
 	"miniflux.app/v2/internal/urllib"
 	"miniflux.app/v2/internal/validator"
@@ -138,16 +140,35 @@ func (h *handler) findEntries(w http.ResponseWriter, r *http.Request, feedID int
 		return
 	}
 
+	searchMode := request.QueryStringParam(r, "search_mode", model.SearchModeFullText)
+	if err := validator.ValidateSearchMode(searchMode); err != nil {
+		json.BadRequest(w, r, err)
+		return
+	}
+
 	tags := request.QueryStringParamList(r, "tags")
+	userTags := request.QueryStringParamList(r, "user_tags")
 
 	builder := h.store.NewEntryQueryBuilder(userID)
 	builder.WithFeedID(feedID)
 	builder.WithCategoryID(categoryID)
 	builder.WithStatuses(statuses)
+
+	// The semantic search ranks the entries by similarity before the requested order.
+	if searchQuery := request.QueryStringParam(r, "search", ""); searchQuery != "" && searchMode == model.SearchModeSemantic {
+		terms, weights, err := similarity.SemanticQuery(h.store, userID, searchQuery)
+		if err != nil {
+			json.ServerError(w, r, err)
+			return
+		}
+		builder.WithSemanticQuery(terms, weights)
+	}
+
 	builder.WithSorting(order, direction)
 	builder.WithOffset(offset)
 	builder.WithLimit(limit)
 	builder.WithTags(tags)
+	builder.WithUserTags(userTags)
 	builder.WithEnclosures()
 	configureFilters(builder, r)
 
@@ -170,6 +191,95 @@ func (h *handler) findEntries(w http.ResponseWriter, r *http.Request, feedID int
 	json.OK(w, r, &entriesResponse{Total: count, Entries: entries})
 }
 
+func (h *handler) getSimilarEntries(w http.ResponseWriter, r *http.Request) {
+	statuses := request.QueryStringParamList(r, "status")
+	for _, status := range statuses {
+		if err := validator.ValidateEntryStatus(status); err != nil {
+			json.BadRequest(w, r, err)
+			return
+		}
+	}
+
+	limit := request.QueryIntParam(r, "limit", 100)
+	offset := request.QueryIntParam(r, "offset", 0)
+	if err := validator.ValidateRange(offset, limit); err != nil {
+		json.BadRequest(w, r, err)
+		return
+	}
+
+	userID := request.UserID(r)
+	entryID := request.RouteInt64Param(r, "entryID")
+
+	entryBuilder := h.store.NewEntryQueryBuilder(userID)
+	entryBuilder.WithEntryID(entryID)
+	entryBuilder.WithoutStatus(model.EntryStatusRemoved)
+
+	entry, err := entryBuilder.GetEntry()
+	if err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	if entry == nil {
+		json.NotFound(w, r)
+		return
+	}
+
+	builder := h.store.NewEntryQueryBuilder(userID)
+	builder.WithSimilarTo(entryID)
+	builder.WithStatuses(statuses)
+	builder.WithoutStatus(model.EntryStatusRemoved)
+	builder.WithSorting("e.id", model.DefaultSortingDirection)
+	builder.WithOffset(offset)
+	builder.WithLimit(limit)
+	builder.WithEnclosures()
+
+	entries, err := builder.GetEntries()
+	if err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	count, err := builder.CountEntries()
+	if err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	for i := range entries {
+		entries[i].Content = mediaproxy.RewriteDocumentWithAbsoluteProxyURL(h.router, r.Host, entries[i].Content)
+	}
+
+	json.OK(w, r, &entriesResponse{Total: count, Entries: entries})
+}
+
+func (h *handler) markSimilarEntriesAsRead(w http.ResponseWriter, r *http.Request) {
+	userID := request.UserID(r)
+	entryID := request.RouteInt64Param(r, "entryID")
+
+	builder := h.store.NewEntryQueryBuilder(userID)
+	builder.WithEntryID(entryID)
+	builder.WithoutStatus(model.EntryStatusRemoved)
+
+	entry, err := builder.GetEntry()
+	if err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	if entry == nil {
+		json.NotFound(w, r)
+		return
+	}
+
+	if _, err := h.store.MarkSimilarEntriesAsRead(userID, entryID); err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	json.NoContent(w, r)
+}
+
 func (h *handler) setEntryStatus(w http.ResponseWriter, r *http.Request) {
 	var entriesStatusUpdateRequest model.EntriesStatusUpdateRequest
 	if err := json_parser.NewDecoder(r.Body).Decode(&entriesStatusUpdateRequest); err != nil {
@@ -228,7 +338,7 @@ func (h *handler) saveEntry(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	go integration.SendEntry(entry, settings)
+	go integration.SendEntry(h.store, entry, settings)
 
 	json.Accepted(w, r)
 }
@@ -331,6 +441,10 @@ func (h *handler) fetchContent(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err := processor.ProcessEntryWebPage(feed, entry, user); err != nil {
+		if errors.Is(err, fetcher.ErrDisallowedByRobotsTxt) {
+			json.BadRequest(w, r, err)
+			return
+		}
 		json.ServerError(w, r, err)
 		return
 	}
@@ -388,7 +502,14 @@ func configureFilters(builder *storage.EntryQueryBuilder, r *http.Request) {
 		}
 	}
 
-	if searchQuery := request.QueryStringParam(r, "search", ""); searchQuery != "" {
+	if searchQuery := request.QueryStringParam(r, "search", ""); searchQuery != "" && request.QueryStringParam(r, "search_mode", "") != model.SearchModeSemantic {
 		builder.WithSearchQuery(searchQuery)
 	}
+
+	if request.HasQueryParam(r, "collapse_similar") {
+		collapse, err := strconv.ParseBool(r.URL.Query().Get("collapse_similar"))
+		if err == nil && collapse {
+			builder.WithCollapsedStories()
+		}
+	}
 }
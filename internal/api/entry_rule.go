@@ -0,0 +1,159 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package api // import "miniflux.app/v2/internal/api"
+
+import (
+	json_parser "encoding/json"
+	"net/http"
+
+	"miniflux.app/v2/internal/http/request"
+	"miniflux.app/v2/internal/http/response/json"
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/validator"
+)
+
+func (h *handler) getEntryRules(w http.ResponseWriter, r *http.Request) {
+	rules, err := h.store.EntryRules(request.UserID(r))
+	if err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	json.OK(w, r, rules)
+}
+
+func (h *handler) getEntryRule(w http.ResponseWriter, r *http.Request) {
+	rule, err := h.store.EntryRuleByID(request.UserID(r), request.RouteInt64Param(r, "ruleID"))
+	if err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	if rule == nil {
+		json.NotFound(w, r)
+		return
+	}
+
+	json.OK(w, r, rule)
+}
+
+func (h *handler) createEntryRule(w http.ResponseWriter, r *http.Request) {
+	userID := request.UserID(r)
+
+	var ruleRequest model.EntryRuleRequest
+	if err := json_parser.NewDecoder(r.Body).Decode(&ruleRequest); err != nil {
+		json.BadRequest(w, r, err)
+		return
+	}
+
+	if validationErr := validator.ValidateEntryRule(h.store, userID, &ruleRequest); validationErr != nil {
+		json.BadRequest(w, r, validationErr.Error())
+		return
+	}
+
+	rule := &model.EntryRule{UserID: userID}
+	ruleRequest.Patch(rule)
+	if err := h.store.CreateEntryRule(rule); err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	json.Created(w, r, rule)
+}
+
+func (h *handler) updateEntryRule(w http.ResponseWriter, r *http.Request) {
+	userID := request.UserID(r)
+
+	rule, err := h.store.EntryRuleByID(userID, request.RouteInt64Param(r, "ruleID"))
+	if err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	if rule == nil {
+		json.NotFound(w, r)
+		return
+	}
+
+	var ruleRequest model.EntryRuleRequest
+	if err := json_parser.NewDecoder(r.Body).Decode(&ruleRequest); err != nil {
+		json.BadRequest(w, r, err)
+		return
+	}
+
+	if validationErr := validator.ValidateEntryRule(h.store, userID, &ruleRequest); validationErr != nil {
+		json.BadRequest(w, r, validationErr.Error())
+		return
+	}
+
+	ruleRequest.Patch(rule)
+	if err := h.store.UpdateEntryRule(rule); err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	json.Created(w, r, rule)
+}
+
+func (h *handler) removeEntryRule(w http.ResponseWriter, r *http.Request) {
+	userID := request.UserID(r)
+	ruleID := request.RouteInt64Param(r, "ruleID")
+
+	rule, err := h.store.EntryRuleByID(userID, ruleID)
+	if err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	if rule == nil {
+		json.NotFound(w, r)
+		return
+	}
+
+	if err := h.store.RemoveEntryRule(userID, ruleID); err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	json.NoContent(w, r)
+}
+
+// previewEntryRule returns the recent entries matching a rule, without applying its actions.
+func (h *handler) previewEntryRule(w http.ResponseWriter, r *http.Request) {
+	userID := request.UserID(r)
+
+	limit := request.QueryIntParam(r, "limit", 100)
+	if err := validator.ValidateRange(0, limit); err != nil {
+		json.BadRequest(w, r, err)
+		return
+	}
+
+	var ruleRequest model.EntryRuleRequest
+	if err := json_parser.NewDecoder(r.Body).Decode(&ruleRequest); err != nil {
+		json.BadRequest(w, r, err)
+		return
+	}
+
+	if validationErr := validator.ValidateEntryRule(h.store, userID, &ruleRequest); validationErr != nil {
+		json.BadRequest(w, r, validationErr.Error())
+		return
+	}
+
+	rule := &model.EntryRule{UserID: userID}
+	ruleRequest.Patch(rule)
+
+	builder := h.store.NewEntryQueryBuilder(userID)
+	builder.WithoutStatus(model.EntryStatusRemoved)
+	builder.WithSorting("e.created_at", "DESC")
+	builder.WithLimit(limit)
+
+	entries, err := builder.GetEntries()
+	if err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	matchedEntries := rule.Preview(entries)
+	json.OK(w, r, &entriesResponse{Total: len(matchedEntries), Entries: matchedEntries})
+}
@@ -218,6 +218,57 @@ func (h *handler) getFeed(w http.ResponseWriter, r *http.Request) {
 	json.OK(w, r, feed)
 }
 
+func (h *handler) getFeedDiagnostics(w http.ResponseWriter, r *http.Request) {
+	feedID := request.RouteInt64Param(r, "feedID")
+	userID := request.UserID(r)
+
+	limit := request.QueryIntParam(r, "limit", 100)
+	if err := validator.ValidateRange(0, limit); err != nil {
+		json.BadRequest(w, r, err)
+		return
+	}
+
+	if !h.store.FeedExists(userID, feedID) {
+		json.NotFound(w, r)
+		return
+	}
+
+	diagnostics, err := h.store.FeedDiagnostics(userID, feedID, limit)
+	if err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	json.OK(w, r, &feedDiagnosticsResponse{Summary: diagnostics.Summary(), Diagnostics: diagnostics})
+}
+
+func (h *handler) getFeedSuggestions(w http.ResponseWriter, r *http.Request) {
+	suggestions, err := h.store.FeedSuggestions(request.UserID(r))
+	if err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	json.OK(w, r, suggestions)
+}
+
+func (h *handler) dismissFeedSuggestions(w http.ResponseWriter, r *http.Request) {
+	feedID := request.RouteInt64Param(r, "feedID")
+	userID := request.UserID(r)
+
+	if !h.store.FeedExists(userID, feedID) {
+		json.NotFound(w, r)
+		return
+	}
+
+	if err := h.store.DismissFeedSuggestions(userID, feedID); err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	json.NoContent(w, r)
+}
+
 func (h *handler) removeFeed(w http.ResponseWriter, r *http.Request) {
 	feedID := request.RouteInt64Param(r, "feedID")
 	userID := request.UserID(r)
@@ -18,6 +18,11 @@ type entriesResponse struct {
 	Entries model.Entries `json:"entries"`
 }
 
+type feedDiagnosticsResponse struct {
+	Summary     *model.FeedDiagnosticsSummary `json:"summary"`
+	Diagnostics model.FeedDiagnostics         `json:"diagnostics"`
+}
+
 type feedCreationResponse struct {
 	FeedID int64 `json:"feed_id"`
 }
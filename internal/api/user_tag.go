@@ -0,0 +1,108 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package api // import "miniflux.app/v2/internal/api"
+
+import (
+	json_parser "encoding/json"
+	"net/http"
+
+	"miniflux.app/v2/internal/http/request"
+	"miniflux.app/v2/internal/http/response/json"
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/validator"
+)
+
+func (h *handler) getUserTags(w http.ResponseWriter, r *http.Request) {
+	tags, err := h.store.UserTags(request.UserID(r))
+	if err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	json.OK(w, r, tags)
+}
+
+func (h *handler) updateEntryUserTags(w http.ResponseWriter, r *http.Request) {
+	userID := request.UserID(r)
+	entryID := request.RouteInt64Param(r, "entryID")
+
+	var tagsRequest model.EntryUserTagsRequest
+	if err := json_parser.NewDecoder(r.Body).Decode(&tagsRequest); err != nil {
+		json.BadRequest(w, r, err)
+		return
+	}
+
+	tags := model.NormalizeUserTags(tagsRequest.Tags)
+	if validationErr := validator.ValidateEntryUserTags(tags); validationErr != nil {
+		json.BadRequest(w, r, validationErr.Error())
+		return
+	}
+
+	builder := h.store.NewEntryQueryBuilder(userID)
+	builder.WithEntryID(entryID)
+	builder.WithoutStatus(model.EntryStatusRemoved)
+
+	entry, err := builder.GetEntry()
+	if err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	if entry == nil {
+		json.NotFound(w, r)
+		return
+	}
+
+	if err := h.store.SetEntryUserTags(userID, entry.ID, tags); err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	json.NoContent(w, r)
+}
+
+func (h *handler) renameUserTag(w http.ResponseWriter, r *http.Request) {
+	userID := request.UserID(r)
+	tagName := request.RouteStringParam(r, "tagName")
+
+	if !h.store.UserTagExists(userID, tagName) {
+		json.NotFound(w, r)
+		return
+	}
+
+	var tagRequest model.UserTagModificationRequest
+	if err := json_parser.NewDecoder(r.Body).Decode(&tagRequest); err != nil {
+		json.BadRequest(w, r, err)
+		return
+	}
+
+	if validationErr := validator.ValidateUserTagModification(&tagRequest); validationErr != nil {
+		json.BadRequest(w, r, validationErr.Error())
+		return
+	}
+
+	if err := h.store.RenameUserTag(userID, tagName, tagRequest.Name); err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	json.NoContent(w, r)
+}
+
+func (h *handler) removeUserTag(w http.ResponseWriter, r *http.Request) {
+	userID := request.UserID(r)
+	tagName := request.RouteStringParam(r, "tagName")
+
+	if !h.store.UserTagExists(userID, tagName) {
+		json.NotFound(w, r)
+		return
+	}
+
+	if err := h.store.RemoveUserTag(userID, tagName); err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	json.NoContent(w, r)
+}
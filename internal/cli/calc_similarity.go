@@ -1,61 +1,84 @@
 package cli
 
 import (
+	"context"
+	"fmt"
 	"log/slog"
 	"miniflux.app/v2/internal/model"
 	"miniflux.app/v2/internal/similarity"
+	"miniflux.app/v2/internal/similarity/story"
 	"miniflux.app/v2/internal/storage"
+	"os"
+	"sync"
 )
 
-func calcSimilarity(store *storage.Storage, similarityThreshold float64) error {
+func calcSimilarity(ctx context.Context, store *storage.Storage, similarityAlgorithm string, similarityThreshold float64, workers int) error {
 	slog.Debug("Calculating similarity",
+		slog.String("algorithm", similarityAlgorithm),
 		slog.Float64("threshold", similarityThreshold),
+		slog.Int("workers", workers),
 		slog.String("action", "calc_similarity.go:calcSimilarity()"))
 	// Get all users
 	users, err := store.Users()
 	if err != nil {
-		printErrorAndExit(err)
+		return err
 	}
 	for _, user := range users {
 		slog.Debug("Processing user", slog.Int64("userID", user.ID))
+		// Build the document frequency corpus and the candidates index
+		index := similarity.NewStoredIndex(store, user.ID, 0, 0)
+		corpusBuilder := store.NewEntryQueryBuilder(user.ID)
+		corpusBuilder.WithoutStatus(model.EntryStatusRemoved)
+		err := corpusBuilder.EntryProcessor(func(entry *model.Entry) error {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			if err := similarity.AddDocument(store, user.ID, entry); err != nil {
+				return err
+			}
+			return index.Add(story.FromEntry(entry))
+		})
+		if err != nil {
+			return err
+		}
+		corpus, err := similarity.NewStoredCorpus(store, user.ID)
+		if err != nil {
+			return err
+		}
+
 		// Calculate Similar
 		builder := store.NewEntryQueryBuilder(user.ID)
-		stories, err := similarity.NewSimilarity(similarityThreshold).Calculate(builder)
+		stories, err := similarity.NewSimilarity(similarityAlgorithm, similarityThreshold, corpus, index).Calculate(ctx, builder, workers, similarityProgress(user.Username))
+		fmt.Fprintln(os.Stderr)
 		if err != nil {
-			printErrorAndExit(err)
+			return err
 		}
 
 		// Create similar entries
-		for _, story := range stories {
-			for _, similar := range story.Similar {
-				sims, err := store.FindSimilarEntries(story.ID)
-				if err != nil {
-					return err
-				}
-				found := false
-				for _, sim := range sims {
-					if sim.EntryID == story.ID {
-						found = true
-						slog.Debug("Found existing similar story", slog.Int64("storyID", story.ID))
-					}
-				}
-				if !found {
-					slog.Debug("Found new similar",
-						slog.Int64("entryID", story.ID),
-						slog.Int64("similarEntryID", similar.Source.ID),
-						slog.Float64("similarity", similar.Similarity),
-					)
-					err := store.CreateSimilarEntry(&model.EntrySimilar{
-						EntryID:        story.ID,
-						SimilarEntryID: similar.Source.ID,
-						Similarity:     similar.Similarity,
-					})
-					if err != nil {
-						return err
-					}
-				}
-			}
+		if err := similarity.SaveStories(store, user.ID, stories); err != nil {
+			return err
+		}
+
+		// Group similar entries into story clusters
+		if err := similarity.RebuildClusters(store, user.ID); err != nil {
+			return err
 		}
 	}
 	return nil
 }
+
+// similarityProgress prints the progress of a user's similarity calculation each time the percentage changes.
+func similarityProgress(username string) similarity.ProgressFunc {
+	var mu sync.Mutex
+	lastPercent := -1
+	return func(completed, total int) {
+		mu.Lock()
+		defer mu.Unlock()
+		percent := completed * 100 / total
+		if percent == lastPercent {
+			return
+		}
+		lastPercent = percent
+		fmt.Fprintf(os.Stderr, "\r%s: %d/%d entries compared (%d%%)", username, completed, total, percent)
+	}
+}
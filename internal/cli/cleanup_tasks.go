@@ -13,6 +13,12 @@ import (
 	"miniflux.app/v2/internal/storage"
 )
 
+// integrationDeliveriesRetentionDays is the number of days the completed integration deliveries are kept in the delivery log.
+const integrationDeliveriesRetentionDays = 30
+
+// failedJobsRetentionDays is the number of days the failed feed refresh jobs are kept in the job queue.
+const failedJobsRetentionDays = 7
+
 func runCleanupTasks(store *storage.Storage) {
 	nbSessions := store.CleanOldSessions(config.Opts.CleanupRemoveSessionsDays())
 	nbUserSessions := store.CleanOldUserSessions(config.Opts.CleanupRemoveSessionsDays())
@@ -46,4 +52,20 @@ func runCleanupTasks(store *storage.Storage) {
 			metric.ArchiveEntriesDuration.WithLabelValues(model.EntryStatusUnread).Observe(time.Since(startTime).Seconds())
 		}
 	}
+
+	if rowsAffected, err := store.CleanOldIntegrationDeliveries(integrationDeliveriesRetentionDays); err != nil {
+		slog.Error("Unable to remove old integration deliveries", slog.Any("error", err))
+	} else {
+		slog.Info("Integration deliveries cleanup completed",
+			slog.Int64("integration_deliveries_removed", rowsAffected),
+		)
+	}
+
+	if rowsAffected, err := store.CleanOldFailedJobs(failedJobsRetentionDays); err != nil {
+		slog.Error("Unable to remove old failed jobs", slog.Any("error", err))
+	} else {
+		slog.Info("Failed jobs cleanup completed",
+			slog.Int64("failed_jobs_removed", rowsAffected),
+		)
+	}
 }
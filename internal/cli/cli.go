@@ -4,15 +4,21 @@
 package cli // import "miniflux.app/v2/internal/cli"
 
 import (
+	"context"
+	"errors"
 	"flag"
 	"fmt"
 	"io"
 	"log/slog"
 	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"miniflux.app/v2/internal/config"
 	"miniflux.app/v2/internal/database"
 	"miniflux.app/v2/internal/locale"
+	"miniflux.app/v2/internal/reader/fetcher"
 	"miniflux.app/v2/internal/storage"
 	"miniflux.app/v2/internal/ui/static"
 	"miniflux.app/v2/internal/version"
@@ -32,7 +38,9 @@ const (
 	flagHealthCheckHelp     = `Perform a health check on the given endpoint (the value "auto" try to guess the health check endpoint).`
 	flagRefreshFeedsHelp    = "Refresh a batch of feeds and exit"
 	flagRunCleanupTasksHelp = "Run cleanup tasks (delete old sessions and archives old entries)"
+	flagSendDigestsHelp     = "Send the digest emails due now and exit"
 	flagRunSimilarityHelp   = "Calculate similarity between articles"
+	flagSimilarityBenchHelp = `Compare the similarity algorithms on a labelled fixtures file (the value "builtin" uses the bundled fixtures).`
 	flagExportUserFeedsHelp = "Export user feeds (provide the username as argument)"
 )
 
@@ -53,7 +61,9 @@ func Parse() {
 		flagHealthCheck     string
 		flagRefreshFeeds    bool
 		flagRunCleanupTasks bool
+		flagSendDigests     bool
 		flagRunSimilarity   bool
+		flagSimilarityBench string
 		flagExportUserFeeds string
 	)
 
@@ -73,7 +83,9 @@ func Parse() {
 	flag.StringVar(&flagHealthCheck, "healthcheck", "", flagHealthCheckHelp)
 	flag.BoolVar(&flagRefreshFeeds, "refresh-feeds", false, flagRefreshFeedsHelp)
 	flag.BoolVar(&flagRunCleanupTasks, "run-cleanup-tasks", false, flagRunCleanupTasksHelp)
+	flag.BoolVar(&flagSendDigests, "send-digests", false, flagSendDigestsHelp)
 	flag.BoolVar(&flagRunSimilarity, "calc-similarity", false, flagRunSimilarityHelp)
+	flag.StringVar(&flagSimilarityBench, "similarity-benchmark", "", flagSimilarityBenchHelp)
 	flag.StringVar(&flagExportUserFeeds, "export-user-feeds", "", flagExportUserFeedsHelp)
 	flag.Parse()
 
@@ -124,6 +136,11 @@ func Parse() {
 		return
 	}
 
+	if flagSimilarityBench != "" {
+		similarityBenchmark(flagSimilarityBench, config.Opts.SimilarityThreshold())
+		return
+	}
+
 	if flagInfo {
 		info()
 		return
@@ -134,6 +151,9 @@ func Parse() {
 		return
 	}
 
+	fetcher.SetHostRateLimit(float64(config.Opts.HTTPClientHostRateLimit()), config.Opts.HTTPClientHostBurst())
+	fetcher.SetRobotsTxtPolicy(config.Opts.ScraperRobotsTxt(), time.Duration(config.Opts.ScraperRobotsTxtCacheHours())*time.Hour)
+
 	if config.Opts.IsDefaultDatabaseURL() {
 		slog.Info("The default value for DATABASE_URL is used")
 	}
@@ -230,8 +250,23 @@ func Parse() {
 		return
 	}
 
+	if flagSendDigests {
+		if !config.Opts.HasDigestEmails() {
+			printErrorAndExit(errors.New("SMTP_HOST must be configured to send the digest emails"))
+		}
+		sendDigests(store, newDigestMailer())
+		return
+	}
+
 	if flagRunSimilarity {
-		calcSimilarity(store, config.Opts.SimilarityThreshold())
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+		if err := calcSimilarity(ctx, store, config.Opts.SimilarityAlgorithm(), config.Opts.SimilarityThreshold(), config.Opts.SimilarityWorkers()); err != nil {
+			if errors.Is(err, context.Canceled) {
+				printErrorAndExit(errors.New("similarity calculation interrupted"))
+			}
+			printErrorAndExit(err)
+		}
 		return
 	}
 
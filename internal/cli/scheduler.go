@@ -4,10 +4,15 @@
 package cli // import "miniflux.app/v2/internal/cli"
 
 import (
+	"context"
 	"log/slog"
 	"time"
 
 	"miniflux.app/v2/internal/config"
+	"miniflux.app/v2/internal/digest"
+	"miniflux.app/v2/internal/integration"
+	"miniflux.app/v2/internal/reader/handler"
+	"miniflux.app/v2/internal/similarity"
 	"miniflux.app/v2/internal/storage"
 	"miniflux.app/v2/internal/worker"
 )
@@ -27,6 +32,24 @@ func runScheduler(store *storage.Storage, pool *worker.Pool) {
 		store,
 		config.Opts.CleanupFrequencyHours(),
 	)
+
+	go integrationDeliveryScheduler(store, config.Opts.BatchSize())
+
+	if config.Opts.WebSub() {
+		go webSubScheduler(store)
+	}
+
+	if config.Opts.StaleFeedDays() > 0 {
+		go staleFeedScheduler(store, config.Opts.StaleFeedDays())
+	}
+
+	if config.Opts.HasDigestEmails() {
+		go digestScheduler(store, newDigestMailer())
+	}
+
+	if config.Opts.SimilarityWindowHours() > 0 {
+		go similarityIndexBackfill(store)
+	}
 }
 
 func feedScheduler(store *storage.Storage, pool *worker.Pool, frequency, batchSize, errorLimit int) {
@@ -37,6 +60,9 @@ func feedScheduler(store *storage.Storage, pool *worker.Pool, frequency, batchSi
 		batchBuilder.WithErrorLimit(errorLimit)
 		batchBuilder.WithoutDisabledFeeds()
 		batchBuilder.WithNextCheckExpired()
+		if config.Opts.WebSub() {
+			batchBuilder.WithoutWebSubFeeds()
+		}
 
 		if jobs, err := batchBuilder.FetchJobs(); err != nil {
 			slog.Error("Unable to fetch jobs from database", slog.Any("error", err))
@@ -54,3 +80,91 @@ func cleanupScheduler(store *storage.Storage, frequency int) {
 		runCleanupTasks(store)
 	}
 }
+
+// integrationDeliveryScheduler retries every minute the integration deliveries that failed previously.
+func integrationDeliveryScheduler(store *storage.Storage, batchSize int) {
+	for range time.Tick(time.Minute) {
+		deliveries, err := store.ClaimIntegrationDeliveries(batchSize)
+		if err != nil {
+			slog.Error("Unable to fetch the integration deliveries to retry", slog.Any("error", err))
+			continue
+		}
+
+		for _, delivery := range deliveries {
+			integration.Deliver(store, delivery)
+		}
+	}
+}
+
+// webSubScheduler renews the WebSub subscriptions expiring within a day, and retries the ones never verified by their hub.
+func webSubScheduler(store *storage.Storage) {
+	for range time.Tick(time.Hour) {
+		subscriptions, err := store.WebSubSubscriptionsToRenew(time.Now().Add(24*time.Hour), time.Now().Add(-24*time.Hour))
+		if err != nil {
+			slog.Error("Unable to fetch the WebSub subscriptions to renew", slog.Any("error", err))
+			continue
+		}
+
+		for _, subscription := range subscriptions {
+			if err := handler.RenewWebSubSubscription(store, subscription); err != nil {
+				slog.Warn("Unable to renew the WebSub subscription",
+					slog.Int64("feed_id", subscription.FeedID),
+					slog.String("hub_url", subscription.HubURL),
+					slog.Any("error", err),
+				)
+			}
+		}
+	}
+}
+
+// staleFeedScheduler flags at startup, then once a day, the feeds without new entries for the given number of days.
+func staleFeedScheduler(store *storage.Storage, days int) {
+	flagStaleFeeds(store, days)
+	for range time.Tick(24 * time.Hour) {
+		flagStaleFeeds(store, days)
+	}
+}
+
+func flagStaleFeeds(store *storage.Storage, days int) {
+	if count, err := store.FlagStaleFeeds(days); err != nil {
+		slog.Error("Unable to flag stale feeds", slog.Any("error", err))
+	} else {
+		slog.Info("Stale feeds analysis completed",
+			slog.Int64("stale_feeds_flagged", count),
+		)
+	}
+}
+
+// digestScheduler sends every hour the daily and weekly digest emails due.
+func digestScheduler(store *storage.Storage, mailer *digest.Mailer) {
+	for range time.Tick(time.Hour) {
+		sendDigests(store, mailer)
+	}
+}
+
+// similarityIndexBackfill indexes once at startup the entries missing from the similarity index,
+// so that entries created before the index was maintained can be found as candidates of new ones.
+func similarityIndexBackfill(store *storage.Storage) {
+	users, err := store.Users()
+	if err != nil {
+		slog.Error("Unable to fetch the users to backfill the similarity index", slog.Any("error", err))
+		return
+	}
+
+	for _, user := range users {
+		count, err := similarity.BackfillIndex(context.Background(), store, user.ID)
+		if err != nil {
+			slog.Error("Unable to backfill the similarity index",
+				slog.Int64("user_id", user.ID),
+				slog.Any("error", err),
+			)
+			continue
+		}
+		if count > 0 {
+			slog.Info("Similarity index backfilled",
+				slog.Int64("user_id", user.ID),
+				slog.Int("entries", count),
+			)
+		}
+	}
+}
@@ -0,0 +1,46 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package cli // import "miniflux.app/v2/internal/cli"
+
+import (
+	"log/slog"
+
+	"miniflux.app/v2/internal/config"
+	"miniflux.app/v2/internal/digest"
+	"miniflux.app/v2/internal/storage"
+)
+
+func newDigestMailer() *digest.Mailer {
+	return digest.NewMailer(
+		config.Opts.SMTPHost(),
+		config.Opts.SMTPPort(),
+		config.Opts.SMTPUsername(),
+		config.Opts.SMTPPassword(),
+		config.Opts.SMTPFrom(),
+	)
+}
+
+// sendDigests sends the digest emails due now.
+func sendDigests(store *storage.Storage, mailer *digest.Mailer) {
+	schedules, err := store.DigestsDue()
+	if err != nil {
+		slog.Error("Unable to fetch the digests due", slog.Any("error", err))
+		return
+	}
+
+	for _, schedule := range schedules {
+		if err := digest.Send(store, mailer, schedule); err != nil {
+			slog.Error("Unable to send the digest email",
+				slog.Int64("user_id", schedule.UserID),
+				slog.Any("error", err),
+			)
+			continue
+		}
+
+		slog.Info("Digest email processed",
+			slog.Int64("user_id", schedule.UserID),
+			slog.Time("since", schedule.Since),
+		)
+	}
+}
@@ -0,0 +1,52 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"miniflux.app/v2/internal/similarity"
+	"miniflux.app/v2/internal/similarity/benchmark"
+)
+
+func similarityBenchmark(fixturesFile string, similarityThreshold float64) {
+	var pairs []*benchmark.Pair
+	var err error
+	if fixturesFile == "builtin" {
+		pairs, err = benchmark.BuiltinPairs()
+	} else {
+		var file *os.File
+		file, err = os.Open(fixturesFile)
+		if err != nil {
+			printErrorAndExit(fmt.Errorf("unable to open fixtures file: %v", err))
+		}
+		defer file.Close()
+		pairs, err = benchmark.ParsePairs(file)
+	}
+	if err != nil {
+		printErrorAndExit(err)
+	}
+
+	results, err := benchmark.Run(similarity.Algorithms(), pairs, similarityThreshold)
+	if err != nil {
+		printErrorAndExit(err)
+	}
+
+	fmt.Printf("%d labelled pairs, threshold %.2f\n\n", len(pairs), similarityThreshold)
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, "ALGORITHM\tPRECISION\tRECALL\tF1\tMEAN SIMILAR\tMEAN DISSIMILAR\tBEST THRESHOLD\tBEST F1\tDURATION")
+	for _, result := range results {
+		fmt.Fprintf(writer, "%s\t%.2f\t%.2f\t%.2f\t%.3f\t%.3f\t%.3f\t%.2f\t%s\n",
+			result.Algorithm,
+			result.Precision,
+			result.Recall,
+			result.F1,
+			result.MeanSimilar,
+			result.MeanDissimilar,
+			result.BestThreshold,
+			result.BestF1,
+			result.Duration,
+		)
+	}
+	writer.Flush()
+}
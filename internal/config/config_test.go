@@ -1834,6 +1834,76 @@ func TestDefaultHTTPClientTimeoutValue(t *testing.T) {
 	}
 }
 
+func TestHTTPClientHostRateLimit(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("HTTP_CLIENT_HOST_RATE_LIMIT", "30")
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	expected := 30
+	result := opts.HTTPClientHostRateLimit()
+
+	if result != expected {
+		t.Fatalf(`Unexpected HTTP_CLIENT_HOST_RATE_LIMIT value, got %d instead of %d`, result, expected)
+	}
+}
+
+func TestDefaultHTTPClientHostRateLimitValue(t *testing.T) {
+	os.Clearenv()
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	expected := defaultHTTPClientHostRateLimit
+	result := opts.HTTPClientHostRateLimit()
+
+	if result != expected {
+		t.Fatalf(`Unexpected HTTP_CLIENT_HOST_RATE_LIMIT value, got %d instead of %d`, result, expected)
+	}
+}
+
+func TestHTTPClientHostBurst(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("HTTP_CLIENT_HOST_BURST", "5")
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	expected := 5
+	result := opts.HTTPClientHostBurst()
+
+	if result != expected {
+		t.Fatalf(`Unexpected HTTP_CLIENT_HOST_BURST value, got %d instead of %d`, result, expected)
+	}
+}
+
+func TestDefaultHTTPClientHostBurstValue(t *testing.T) {
+	os.Clearenv()
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	expected := defaultHTTPClientHostBurst
+	result := opts.HTTPClientHostBurst()
+
+	if result != expected {
+		t.Fatalf(`Unexpected HTTP_CLIENT_HOST_BURST value, got %d instead of %d`, result, expected)
+	}
+}
+
 func TestHTTPClientMaxBodySize(t *testing.T) {
 	os.Clearenv()
 	os.Setenv("HTTP_CLIENT_MAX_BODY_SIZE", "42")
@@ -2093,6 +2163,147 @@ func TestYouTubeEmbedUrlOverride(t *testing.T) {
 	}
 }
 
+func TestDefaultSimilarityThresholdValue(t *testing.T) {
+	os.Clearenv()
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	expected := defaultSimilarityThreshold
+	result := opts.SimilarityThreshold()
+
+	if result != expected {
+		t.Fatalf(`Unexpected SIMILARITY_THRESHOLD value, got %v instead of %v`, result, expected)
+	}
+}
+
+func TestSimilarityThreshold(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("SIMILARITY_THRESHOLD", "0.65")
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	expected := 0.65
+	result := opts.SimilarityThreshold()
+
+	if result != expected {
+		t.Fatalf(`Unexpected SIMILARITY_THRESHOLD value, got %v instead of %v`, result, expected)
+	}
+}
+
+func TestDefaultSimilarityWorkersValue(t *testing.T) {
+	os.Clearenv()
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	if result := opts.SimilarityWorkers(); result != defaultSimilarityWorkers {
+		t.Fatalf(`Unexpected SIMILARITY_WORKERS value, got %v instead of %v`, result, defaultSimilarityWorkers)
+	}
+}
+
+func TestSimilarityWorkers(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("SIMILARITY_WORKERS", "12")
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	if result := opts.SimilarityWorkers(); result != 12 {
+		t.Fatalf(`Unexpected SIMILARITY_WORKERS value, got %v instead of %v`, result, 12)
+	}
+}
+
+func TestDefaultSimilarityAlgorithmValue(t *testing.T) {
+	os.Clearenv()
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	if result := opts.SimilarityAlgorithm(); result != defaultSimilarityAlgorithm {
+		t.Fatalf(`Unexpected SIMILARITY_ALGORITHM value, got %q instead of %q`, result, defaultSimilarityAlgorithm)
+	}
+}
+
+func TestSimilarityAlgorithm(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("SIMILARITY_ALGORITHM", "minhash")
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	if result := opts.SimilarityAlgorithm(); result != "minhash" {
+		t.Fatalf(`Unexpected SIMILARITY_ALGORITHM value, got %q instead of %q`, result, "minhash")
+	}
+}
+
+func TestSimilarityAlgorithmWithInvalidValue(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("SIMILARITY_ALGORITHM", "invalid")
+
+	parser := NewParser()
+	if _, err := parser.ParseEnvironmentVariables(); err == nil {
+		t.Fatal(`Expected an error for an invalid SIMILARITY_ALGORITHM value`)
+	}
+}
+
+func TestDefaultSimilarityWindowValues(t *testing.T) {
+	os.Clearenv()
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	if result := opts.SimilarityWindowHours(); result != defaultSimilarityWindowHours {
+		t.Fatalf(`Unexpected SIMILARITY_WINDOW_HOURS value, got %v instead of %v`, result, defaultSimilarityWindowHours)
+	}
+
+	if result := opts.SimilarityWindowSize(); result != defaultSimilarityWindowSize {
+		t.Fatalf(`Unexpected SIMILARITY_WINDOW_SIZE value, got %v instead of %v`, result, defaultSimilarityWindowSize)
+	}
+}
+
+func TestSimilarityWindow(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("SIMILARITY_WINDOW_HOURS", "24")
+	os.Setenv("SIMILARITY_WINDOW_SIZE", "250")
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	if result := opts.SimilarityWindowHours(); result != 24 {
+		t.Fatalf(`Unexpected SIMILARITY_WINDOW_HOURS value, got %v instead of %v`, result, 24)
+	}
+
+	if result := opts.SimilarityWindowSize(); result != 250 {
+		t.Fatalf(`Unexpected SIMILARITY_WINDOW_SIZE value, got %v instead of %v`, result, 250)
+	}
+}
+
 func TestParseConfigDumpOutput(t *testing.T) {
 	os.Clearenv()
 
@@ -2127,3 +2338,277 @@ func TestParseConfigDumpOutput(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestWebSubDisabledByDefault(t *testing.T) {
+	os.Clearenv()
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	if opts.WebSub() {
+		t.Fatalf(`Unexpected WEBSUB value, got true instead of false`)
+	}
+}
+
+func TestWebSub(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("WEBSUB", "1")
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	if !opts.WebSub() {
+		t.Fatalf(`Unexpected WEBSUB value, got false instead of true`)
+	}
+}
+
+func TestDefaultWebSubLeaseSecondsValue(t *testing.T) {
+	os.Clearenv()
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	if result := opts.WebSubLeaseSeconds(); result != defaultWebSubLeaseSeconds {
+		t.Fatalf(`Unexpected WEBSUB_LEASE_SECONDS value, got %v instead of %v`, result, defaultWebSubLeaseSeconds)
+	}
+}
+
+func TestWebSubLeaseSeconds(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("WEBSUB_LEASE_SECONDS", "3600")
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	if result := opts.WebSubLeaseSeconds(); result != 3600 {
+		t.Fatalf(`Unexpected WEBSUB_LEASE_SECONDS value, got %v instead of %v`, result, 3600)
+	}
+}
+
+func TestDefaultJobQueueValues(t *testing.T) {
+	os.Clearenv()
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	if result := opts.JobMaxAttempts(); result != defaultJobMaxAttempts {
+		t.Fatalf(`Unexpected JOB_MAX_ATTEMPTS value, got %v instead of %v`, result, defaultJobMaxAttempts)
+	}
+
+	if result := opts.JobHostConcurrency(); result != defaultJobHostConcurrency {
+		t.Fatalf(`Unexpected JOB_HOST_CONCURRENCY value, got %v instead of %v`, result, defaultJobHostConcurrency)
+	}
+
+	if result := opts.JobRetryDelay(); result != defaultJobRetryDelay {
+		t.Fatalf(`Unexpected JOB_RETRY_DELAY value, got %v instead of %v`, result, defaultJobRetryDelay)
+	}
+}
+
+func TestJobQueueValues(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("JOB_MAX_ATTEMPTS", "5")
+	os.Setenv("JOB_HOST_CONCURRENCY", "1")
+	os.Setenv("JOB_RETRY_DELAY", "30")
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	if result := opts.JobMaxAttempts(); result != 5 {
+		t.Fatalf(`Unexpected JOB_MAX_ATTEMPTS value, got %v instead of %v`, result, 5)
+	}
+
+	if result := opts.JobHostConcurrency(); result != 1 {
+		t.Fatalf(`Unexpected JOB_HOST_CONCURRENCY value, got %v instead of %v`, result, 1)
+	}
+
+	if result := opts.JobRetryDelay(); result != 30 {
+		t.Fatalf(`Unexpected JOB_RETRY_DELAY value, got %v instead of %v`, result, 30)
+	}
+}
+
+func TestDefaultScraperRobotsTxtValue(t *testing.T) {
+	os.Clearenv()
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	if !opts.ScraperRobotsTxt() {
+		t.Fatalf(`Unexpected SCRAPER_ROBOTS_TXT value, got false instead of true`)
+	}
+}
+
+func TestScraperRobotsTxt(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("SCRAPER_ROBOTS_TXT", "0")
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	if opts.ScraperRobotsTxt() {
+		t.Fatalf(`Unexpected SCRAPER_ROBOTS_TXT value, got true instead of false`)
+	}
+}
+
+func TestDefaultScraperRobotsTxtCacheHoursValue(t *testing.T) {
+	os.Clearenv()
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	if result := opts.ScraperRobotsTxtCacheHours(); result != defaultScraperRobotsTxtCacheHours {
+		t.Fatalf(`Unexpected SCRAPER_ROBOTS_TXT_CACHE_HOURS value, got %v instead of %v`, result, defaultScraperRobotsTxtCacheHours)
+	}
+}
+
+func TestScraperRobotsTxtCacheHours(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("SCRAPER_ROBOTS_TXT_CACHE_HOURS", "6")
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	if result := opts.ScraperRobotsTxtCacheHours(); result != 6 {
+		t.Fatalf(`Unexpected SCRAPER_ROBOTS_TXT_CACHE_HOURS value, got %v instead of 6`, result)
+	}
+}
+
+func TestDefaultStaleFeedDaysValue(t *testing.T) {
+	os.Clearenv()
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	if result := opts.StaleFeedDays(); result != defaultStaleFeedDays {
+		t.Fatalf(`Unexpected STALE_FEED_DAYS value, got %v instead of %v`, result, defaultStaleFeedDays)
+	}
+}
+
+func TestStaleFeedDays(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("STALE_FEED_DAYS", "30")
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	if result := opts.StaleFeedDays(); result != 30 {
+		t.Fatalf(`Unexpected STALE_FEED_DAYS value, got %v instead of 30`, result)
+	}
+}
+
+func TestUpdateFeedURLOnPermanentRedirect(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("UPDATE_FEED_URL_ON_PERMANENT_REDIRECT", "1")
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	if !opts.UpdateFeedURLOnPermanentRedirect() {
+		t.Fatalf(`Unexpected UPDATE_FEED_URL_ON_PERMANENT_REDIRECT value, got false instead of true`)
+	}
+}
+
+func TestDigestEmailsDisabledByDefault(t *testing.T) {
+	os.Clearenv()
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	if opts.HasDigestEmails() {
+		t.Fatalf(`Digest emails should be disabled without SMTP_HOST`)
+	}
+
+	if result := opts.SMTPPort(); result != defaultSMTPPort {
+		t.Fatalf(`Unexpected SMTP_PORT value, got %v instead of %v`, result, defaultSMTPPort)
+	}
+
+	if result := opts.SMTPFrom(); result != defaultSMTPFrom {
+		t.Fatalf(`Unexpected SMTP_FROM value, got %q instead of %q`, result, defaultSMTPFrom)
+	}
+}
+
+func TestSMTPOptions(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("SMTP_HOST", "localhost")
+	os.Setenv("SMTP_PORT", "1025")
+	os.Setenv("SMTP_USERNAME", "miniflux")
+	os.Setenv("SMTP_PASSWORD", "secret")
+	os.Setenv("SMTP_FROM", "digest@example.org")
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	if !opts.HasDigestEmails() {
+		t.Fatalf(`Digest emails should be enabled when SMTP_HOST is set`)
+	}
+
+	if result := opts.SMTPHost(); result != "localhost" {
+		t.Fatalf(`Unexpected SMTP_HOST value, got %q instead of "localhost"`, result)
+	}
+
+	if result := opts.SMTPPort(); result != 1025 {
+		t.Fatalf(`Unexpected SMTP_PORT value, got %v instead of 1025`, result)
+	}
+
+	if result := opts.SMTPUsername(); result != "miniflux" {
+		t.Fatalf(`Unexpected SMTP_USERNAME value, got %q instead of "miniflux"`, result)
+	}
+
+	if result := opts.SMTPPassword(); result != "secret" {
+		t.Fatalf(`Unexpected SMTP_PASSWORD value, got %q instead of "secret"`, result)
+	}
+
+	if result := opts.SMTPFrom(); result != "digest@example.org" {
+		t.Fatalf(`Unexpected SMTP_FROM value, got %q instead of "digest@example.org"`, result)
+	}
+
+	for _, option := range opts.SortedOptions(true) {
+		if option.Key == "SMTP_PASSWORD" && option.Value != "<secret>" {
+			t.Fatalf(`SMTP_PASSWORD should be redacted, got %v`, option.Value)
+		}
+	}
+}
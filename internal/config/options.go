@@ -86,7 +86,27 @@ const (
 	defaultWatchdog                           = true
 	defaultInvidiousInstance                  = "yewtu.be"
 	defaultWebAuthn                           = false
+	defaultSimilarityAlgorithm                = "v2"
 	defaultSimilarityThreshold                = 0.4
+	defaultSimilarityWindowHours              = 72
+	defaultSimilarityWindowSize               = 1000
+	defaultSimilarityWorkers                  = 5
+	defaultWebSub                             = false
+	defaultWebSubLeaseSeconds                 = 864000
+	defaultJobMaxAttempts                     = 3
+	defaultJobHostConcurrency                 = 2
+	defaultJobRetryDelay                      = 60
+	defaultHTTPClientHostRateLimit            = 0
+	defaultHTTPClientHostBurst                = 10
+	defaultScraperRobotsTxt                   = true
+	defaultScraperRobotsTxtCacheHours         = 24
+	defaultStaleFeedDays                      = 90
+	defaultUpdateFeedURLOnPermanentRedirect   = false
+	defaultSMTPHost                           = ""
+	defaultSMTPPort                           = 587
+	defaultSMTPUsername                       = ""
+	defaultSMTPPassword                       = ""
+	defaultSMTPFrom                           = "miniflux@localhost"
 )
 
 var defaultHTTPClientUserAgent = "Mozilla/5.0 (compatible; Miniflux/" + version.Version + "; +https://miniflux.app)"
@@ -172,7 +192,27 @@ type Options struct {
 	invidiousInstance                  string
 	mediaProxyPrivateKey               []byte
 	webAuthn                           bool
+	similarityAlgorithm                string
 	similarityThreshold                float64
+	similarityWindowHours              int
+	similarityWindowSize               int
+	similarityWorkers                  int
+	webSub                             bool
+	webSubLeaseSeconds                 int
+	jobMaxAttempts                     int
+	jobHostConcurrency                 int
+	jobRetryDelay                      int
+	httpClientHostRateLimit            int
+	httpClientHostBurst                int
+	scraperRobotsTxt                   bool
+	scraperRobotsTxtCacheHours         int
+	staleFeedDays                      int
+	updateFeedURLOnPermanentRedirect   bool
+	smtpHost                           string
+	smtpPort                           int
+	smtpUsername                       string
+	smtpPassword                       string
+	smtpFrom                           string
 }
 
 // NewOptions returns Options with default values.
@@ -249,7 +289,27 @@ func NewOptions() *Options {
 		invidiousInstance:                  defaultInvidiousInstance,
 		mediaProxyPrivateKey:               crypto.GenerateRandomBytes(16),
 		webAuthn:                           defaultWebAuthn,
+		similarityAlgorithm:                defaultSimilarityAlgorithm,
 		similarityThreshold:                defaultSimilarityThreshold,
+		similarityWindowHours:              defaultSimilarityWindowHours,
+		similarityWindowSize:               defaultSimilarityWindowSize,
+		similarityWorkers:                  defaultSimilarityWorkers,
+		webSub:                             defaultWebSub,
+		webSubLeaseSeconds:                 defaultWebSubLeaseSeconds,
+		jobMaxAttempts:                     defaultJobMaxAttempts,
+		jobHostConcurrency:                 defaultJobHostConcurrency,
+		jobRetryDelay:                      defaultJobRetryDelay,
+		httpClientHostRateLimit:            defaultHTTPClientHostRateLimit,
+		httpClientHostBurst:                defaultHTTPClientHostBurst,
+		scraperRobotsTxt:                   defaultScraperRobotsTxt,
+		scraperRobotsTxtCacheHours:         defaultScraperRobotsTxtCacheHours,
+		staleFeedDays:                      defaultStaleFeedDays,
+		updateFeedURLOnPermanentRedirect:   defaultUpdateFeedURLOnPermanentRedirect,
+		smtpHost:                           defaultSMTPHost,
+		smtpPort:                           defaultSMTPPort,
+		smtpUsername:                       defaultSMTPUsername,
+		smtpPassword:                       defaultSMTPPassword,
+		smtpFrom:                           defaultSMTPFrom,
 	}
 }
 
@@ -627,16 +687,121 @@ func (o *Options) WebAuthn() bool {
 	return o.webAuthn
 }
 
+// SimilarityAlgorithm returns the name of the algorithm used to compare entries.
+func (o *Options) SimilarityAlgorithm() string {
+	return o.similarityAlgorithm
+}
+
 // SimilarityThreshold returns the value of the Similarity Threshold
 func (o *Options) SimilarityThreshold() float64 {
 	return o.similarityThreshold
 }
 
+// SimilarityWindowHours returns the age in hours of the entries compared against newly inserted entries.
+func (o *Options) SimilarityWindowHours() int {
+	return o.similarityWindowHours
+}
+
+// SimilarityWindowSize returns the maximum number of recent entries compared against newly inserted entries.
+func (o *Options) SimilarityWindowSize() int {
+	return o.similarityWindowSize
+}
+
+// SimilarityWorkers returns the number of workers comparing entries with the -calc-similarity command.
+func (o *Options) SimilarityWorkers() int {
+	return o.similarityWorkers
+}
+
 // FilterEntryMaxAgeDays returns the number of days after which entries should be retained.
 func (o *Options) FilterEntryMaxAgeDays() int {
 	return o.filterEntryMaxAgeDays
 }
 
+// WebSub returns true if feeds advertising a hub are subscribed through WebSub instead of being polled.
+func (o *Options) WebSub() bool {
+	return o.webSub
+}
+
+// WebSubLeaseSeconds returns the lease duration in seconds requested to WebSub hubs.
+func (o *Options) WebSubLeaseSeconds() int {
+	return o.webSubLeaseSeconds
+}
+
+// JobMaxAttempts returns the number of attempts of a feed refresh job before it is marked as failed.
+func (o *Options) JobMaxAttempts() int {
+	return o.jobMaxAttempts
+}
+
+// JobHostConcurrency returns the maximum number of feeds refreshed at the same time from a single host.
+func (o *Options) JobHostConcurrency() int {
+	return o.jobHostConcurrency
+}
+
+// JobRetryDelay returns the delay in seconds before the first retry of a failed job, doubled after each attempt.
+func (o *Options) JobRetryDelay() int {
+	return o.jobRetryDelay
+}
+
+// HTTPClientHostRateLimit returns the maximum number of requests per minute sent to the same host, zero disables the limit.
+func (o *Options) HTTPClientHostRateLimit() int {
+	return o.httpClientHostRateLimit
+}
+
+// HTTPClientHostBurst returns the number of requests that can be sent at once to the same host.
+func (o *Options) HTTPClientHostBurst() int {
+	return o.httpClientHostBurst
+}
+
+// ScraperRobotsTxt returns true if the scraper must respect the robots.txt file of websites.
+func (o *Options) ScraperRobotsTxt() bool {
+	return o.scraperRobotsTxt
+}
+
+// ScraperRobotsTxtCacheHours returns the number of hours a robots.txt file is kept in cache.
+func (o *Options) ScraperRobotsTxtCacheHours() int {
+	return o.scraperRobotsTxtCacheHours
+}
+
+// StaleFeedDays returns the number of days without new entries after which a feed is considered stale, 0 disables the analysis.
+func (o *Options) StaleFeedDays() int {
+	return o.staleFeedDays
+}
+
+// UpdateFeedURLOnPermanentRedirect returns true if the feed URL must be updated when the feed is permanently redirected.
+func (o *Options) UpdateFeedURLOnPermanentRedirect() bool {
+	return o.updateFeedURLOnPermanentRedirect
+}
+
+// SMTPHost returns the SMTP server used to send the digest emails.
+func (o *Options) SMTPHost() string {
+	return o.smtpHost
+}
+
+// SMTPPort returns the port of the SMTP server.
+func (o *Options) SMTPPort() int {
+	return o.smtpPort
+}
+
+// SMTPUsername returns the username used to authenticate with the SMTP server.
+func (o *Options) SMTPUsername() string {
+	return o.smtpUsername
+}
+
+// SMTPPassword returns the password used to authenticate with the SMTP server.
+func (o *Options) SMTPPassword() string {
+	return o.smtpPassword
+}
+
+// SMTPFrom returns the sender address of the digest emails.
+func (o *Options) SMTPFrom() string {
+	return o.smtpFrom
+}
+
+// HasDigestEmails returns true if an SMTP server is configured to send the digest emails.
+func (o *Options) HasDigestEmails() bool {
+	return o.smtpHost != ""
+}
+
 // SortedOptions returns options as a list of key value pairs, sorted by keys.
 func (o *Options) SortedOptions(redactSecret bool) []*Option {
 	var keyValues = map[string]interface{}{
@@ -715,7 +880,27 @@ func (o *Options) SortedOptions(redactSecret bool) []*Option {
 		"WORKER_POOL_SIZE":                       o.workerPoolSize,
 		"YOUTUBE_EMBED_URL_OVERRIDE":             o.youTubeEmbedUrlOverride,
 		"WEBAUTHN":                               o.webAuthn,
+		"SIMILARITY_ALGORITHM":                   o.similarityAlgorithm,
 		"SIMILARITY_THRESHOLD":                   o.similarityThreshold,
+		"SIMILARITY_WINDOW_HOURS":                o.similarityWindowHours,
+		"SIMILARITY_WINDOW_SIZE":                 o.similarityWindowSize,
+		"SIMILARITY_WORKERS":                     o.similarityWorkers,
+		"WEBSUB":                                 o.webSub,
+		"WEBSUB_LEASE_SECONDS":                   o.webSubLeaseSeconds,
+		"JOB_MAX_ATTEMPTS":                       o.jobMaxAttempts,
+		"JOB_HOST_CONCURRENCY":                   o.jobHostConcurrency,
+		"JOB_RETRY_DELAY":                        o.jobRetryDelay,
+		"HTTP_CLIENT_HOST_RATE_LIMIT":            o.httpClientHostRateLimit,
+		"HTTP_CLIENT_HOST_BURST":                 o.httpClientHostBurst,
+		"SCRAPER_ROBOTS_TXT":                     o.scraperRobotsTxt,
+		"SCRAPER_ROBOTS_TXT_CACHE_HOURS":         o.scraperRobotsTxtCacheHours,
+		"STALE_FEED_DAYS":                        o.staleFeedDays,
+		"UPDATE_FEED_URL_ON_PERMANENT_REDIRECT":  o.updateFeedURLOnPermanentRedirect,
+		"SMTP_HOST":                              o.smtpHost,
+		"SMTP_PORT":                              o.smtpPort,
+		"SMTP_USERNAME":                          o.smtpUsername,
+		"SMTP_PASSWORD":                          redactSecretValue(o.smtpPassword, redactSecret),
+		"SMTP_FROM":                              o.smtpFrom,
 	}
 
 	keys := make([]string, 0, len(keyValues))
@@ -15,6 +15,8 @@ import (
 	"os"
 	"strconv"
 	"strings"
+
+	"miniflux.app/v2/internal/similarity/algorithm"
 )
 
 // Parser handles configuration parsing.
@@ -273,6 +275,55 @@ func (p *Parser) parseLines(lines []string) (err error) {
 			p.opts.invidiousInstance = parseString(value, defaultInvidiousInstance)
 		case "WEBAUTHN":
 			p.opts.webAuthn = parseBool(value, defaultWebAuthn)
+		case "SIMILARITY_ALGORITHM":
+			parsedValue := parseString(value, defaultSimilarityAlgorithm)
+			if !algorithm.IsValid(parsedValue) {
+				return fmt.Errorf("config: invalid SIMILARITY_ALGORITHM %q, expected one of %v", parsedValue, algorithm.Names())
+			}
+			p.opts.similarityAlgorithm = parsedValue
+		case "SIMILARITY_THRESHOLD":
+			p.opts.similarityThreshold = parseFloat(value, defaultSimilarityThreshold)
+		case "SIMILARITY_WINDOW_HOURS":
+			p.opts.similarityWindowHours = parseInt(value, defaultSimilarityWindowHours)
+		case "SIMILARITY_WINDOW_SIZE":
+			p.opts.similarityWindowSize = parseInt(value, defaultSimilarityWindowSize)
+		case "SIMILARITY_WORKERS":
+			p.opts.similarityWorkers = parseInt(value, defaultSimilarityWorkers)
+		case "WEBSUB":
+			p.opts.webSub = parseBool(value, defaultWebSub)
+		case "WEBSUB_LEASE_SECONDS":
+			p.opts.webSubLeaseSeconds = parseInt(value, defaultWebSubLeaseSeconds)
+			if p.opts.webSubLeaseSeconds <= 0 {
+				p.opts.webSubLeaseSeconds = defaultWebSubLeaseSeconds
+			}
+		case "JOB_MAX_ATTEMPTS":
+			p.opts.jobMaxAttempts = parseInt(value, defaultJobMaxAttempts)
+		case "JOB_HOST_CONCURRENCY":
+			p.opts.jobHostConcurrency = parseInt(value, defaultJobHostConcurrency)
+		case "JOB_RETRY_DELAY":
+			p.opts.jobRetryDelay = parseInt(value, defaultJobRetryDelay)
+		case "HTTP_CLIENT_HOST_RATE_LIMIT":
+			p.opts.httpClientHostRateLimit = parseInt(value, defaultHTTPClientHostRateLimit)
+		case "HTTP_CLIENT_HOST_BURST":
+			p.opts.httpClientHostBurst = parseInt(value, defaultHTTPClientHostBurst)
+		case "SCRAPER_ROBOTS_TXT":
+			p.opts.scraperRobotsTxt = parseBool(value, defaultScraperRobotsTxt)
+		case "SCRAPER_ROBOTS_TXT_CACHE_HOURS":
+			p.opts.scraperRobotsTxtCacheHours = parseInt(value, defaultScraperRobotsTxtCacheHours)
+		case "STALE_FEED_DAYS":
+			p.opts.staleFeedDays = parseInt(value, defaultStaleFeedDays)
+		case "UPDATE_FEED_URL_ON_PERMANENT_REDIRECT":
+			p.opts.updateFeedURLOnPermanentRedirect = parseBool(value, defaultUpdateFeedURLOnPermanentRedirect)
+		case "SMTP_HOST":
+			p.opts.smtpHost = parseString(value, defaultSMTPHost)
+		case "SMTP_PORT":
+			p.opts.smtpPort = parseInt(value, defaultSMTPPort)
+		case "SMTP_USERNAME":
+			p.opts.smtpUsername = parseString(value, defaultSMTPUsername)
+		case "SMTP_PASSWORD":
+			p.opts.smtpPassword = parseString(value, defaultSMTPPassword)
+		case "SMTP_FROM":
+			p.opts.smtpFrom = parseString(value, defaultSMTPFrom)
 		}
 	}
 
@@ -332,6 +383,19 @@ func parseInt(value string, fallback int) int {
 	return v
 }
 
+func parseFloat(value string, fallback float64) float64 {
+	if value == "" {
+		return fallback
+	}
+
+	v, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return fallback
+	}
+
+	return v
+}
+
 func parseString(value string, fallback string) string {
 	if value == "" {
 		return fallback
@@ -58,3 +58,21 @@ func TestParseIntValue(t *testing.T) {
 		t.Errorf(`Defined variables should returns the specified value`)
 	}
 }
+
+func TestParseFloatValueWithUnsetVariable(t *testing.T) {
+	if parseFloat("", 0.4) != 0.4 {
+		t.Errorf(`Unset variables should returns the default value`)
+	}
+}
+
+func TestParseFloatValueWithInvalidInput(t *testing.T) {
+	if parseFloat("invalid float", 0.4) != 0.4 {
+		t.Errorf(`Invalid float should returns the default value`)
+	}
+}
+
+func TestParseFloatValue(t *testing.T) {
+	if parseFloat("0.75", 0.4) != 0.75 {
+		t.Errorf(`Defined variables should returns the specified value`)
+	}
+}
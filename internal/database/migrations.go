@@ -916,4 +916,281 @@ var migrations = []func(tx *sql.Tx) error{
 		_, err = tx.Exec(sql)
 		return err
 	},
+	func(tx *sql.Tx) (err error) {
+		sql := `
+			CREATE TABLE entry_clusters (
+				user_id int not null,
+				entry_id bigint not null,
+				cluster_id bigint not null,
+				primary key (entry_id),
+				foreign key (user_id) references users(id) on delete cascade,
+				foreign key (entry_id) references entries(id) on delete cascade
+			);
+			CREATE INDEX entry_clusters_user_id_cluster_id_idx ON entry_clusters(user_id, cluster_id);
+			ALTER TABLE users ADD COLUMN collapse_similar_entries bool default 'f';
+		`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `
+			CREATE TABLE similarity_documents (
+				entry_id bigint not null,
+				user_id int not null,
+				terms text[] not null default '{}',
+				primary key (entry_id),
+				foreign key (entry_id) references entries(id) on delete cascade,
+				foreign key (user_id) references users(id) on delete cascade
+			);
+			CREATE INDEX similarity_documents_user_id_idx ON similarity_documents(user_id);
+			CREATE TABLE similarity_terms (
+				user_id int not null,
+				term text not null,
+				document_count int not null default 0,
+				primary key (user_id, term),
+				foreign key (user_id) references users(id) on delete cascade
+			);
+			CREATE FUNCTION similarity_documents_remove_terms() RETURNS trigger AS $$
+			BEGIN
+				UPDATE similarity_terms SET document_count=document_count - 1
+					WHERE user_id=OLD.user_id AND term=ANY(OLD.terms);
+				DELETE FROM similarity_terms
+					WHERE user_id=OLD.user_id AND term=ANY(OLD.terms) AND document_count <= 0;
+				RETURN OLD;
+			END;
+			$$ LANGUAGE plpgsql;
+
+			CREATE TRIGGER similarity_documents_remove_terms_trigger
+				AFTER DELETE ON similarity_documents
+				FOR EACH ROW EXECUTE PROCEDURE similarity_documents_remove_terms();
+		`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `
+			CREATE TABLE entry_signatures (
+				entry_id bigint not null,
+				user_id int not null,
+				buckets bigint[] not null default '{}',
+				primary key (entry_id),
+				foreign key (entry_id) references entries(id) on delete cascade,
+				foreign key (user_id) references users(id) on delete cascade
+			);
+			CREATE INDEX entry_signatures_user_id_idx ON entry_signatures(user_id);
+			CREATE INDEX entry_signatures_buckets_idx ON entry_signatures USING gin(buckets);
+		`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `
+			ALTER TABLE users ADD COLUMN duplicate_entries_action text not null default 'none';
+			ALTER TABLE users ADD COLUMN duplicate_entries_threshold float not null default 0.8;
+			ALTER TABLE users ADD COLUMN duplicate_entries_category_ids bigint[] not null default '{}';
+		`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `
+			CREATE TABLE websub_subscriptions (
+				feed_id bigint not null,
+				user_id int not null,
+				hub_url text not null,
+				topic_url text not null,
+				secret text not null,
+				state text not null default 'pending' CHECK (state IN ('pending', 'active', 'denied', 'unsubscribing')),
+				pending_mode text not null default '' CHECK (pending_mode IN ('', 'subscribe', 'unsubscribe')),
+				lease_expires_at timestamp with time zone,
+				created_at timestamp with time zone not null default now(),
+				primary key (feed_id),
+				foreign key (feed_id) references feeds(id) on delete cascade,
+				foreign key (user_id) references users(id) on delete cascade
+			);
+		`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `
+			CREATE TYPE job_state AS enum('queued', 'running', 'failed');
+			CREATE TABLE jobs (
+				id bigserial not null,
+				user_id int not null,
+				feed_id bigint not null,
+				host text not null default '',
+				state job_state not null default 'queued',
+				attempts int not null default 0,
+				run_at timestamp with time zone not null default now(),
+				locked_at timestamp with time zone,
+				last_error text not null default '',
+				created_at timestamp with time zone not null default now(),
+				primary key (id),
+				foreign key (user_id) references users(id) on delete cascade,
+				foreign key (feed_id) references feeds(id) on delete cascade
+			);
+			CREATE UNIQUE INDEX jobs_pending_feed_id_idx ON jobs(feed_id) WHERE state IN ('queued', 'running');
+			CREATE INDEX jobs_state_run_at_idx ON jobs(state, run_at);
+			CREATE INDEX jobs_host_state_idx ON jobs(host, state);
+		`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `ALTER TABLE feeds ADD COLUMN page_watcher_rules jsonb not null default '{}'`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `ALTER TABLE feeds ADD COLUMN json_mapping jsonb not null default '{}'`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `
+			CREATE TABLE entry_revisions (
+				id bigserial not null,
+				user_id int not null,
+				entry_id bigint not null,
+				title text not null default '',
+				content text not null default '',
+				created_at timestamp with time zone not null default now(),
+				primary key (id),
+				foreign key (user_id) references users(id) on delete cascade,
+				foreign key (entry_id) references entries(id) on delete cascade
+			);
+			CREATE INDEX entry_revisions_entry_id_idx ON entry_revisions(entry_id);
+			ALTER TABLE feeds ADD COLUMN mark_unread_on_change bool not null default 'f';
+		`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `
+			ALTER TABLE entries ADD COLUMN podcast jsonb not null default '{}';
+			ALTER TABLE enclosures ADD COLUMN alternate bool not null default 'f';
+			ALTER TABLE enclosures ADD COLUMN chapters_url text not null default '';
+			ALTER TABLE enclosures ADD COLUMN chapters jsonb not null default '[]';
+			ALTER TABLE enclosures ADD COLUMN chapters_checked_at timestamp with time zone;
+			ALTER TABLE enclosures ADD COLUMN transcripts jsonb not null default '[]';
+		`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `
+			CREATE TABLE feed_diagnostics (
+				id bigserial not null,
+				user_id int not null,
+				feed_id bigint not null,
+				checked_at timestamp with time zone not null default now(),
+				status_code int not null default 0,
+				duration bigint not null default 0,
+				response_size bigint not null default 0,
+				not_modified bool not null default 'f',
+				error_msg text not null default '',
+				new_entries int not null default 0,
+				primary key (id),
+				foreign key (user_id) references users(id) on delete cascade,
+				foreign key (feed_id) references feeds(id) on delete cascade
+			);
+			CREATE INDEX feed_diagnostics_feed_id_idx ON feed_diagnostics(feed_id, id);
+		`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `
+			ALTER TABLE feeds ADD COLUMN stale_since timestamp with time zone null;
+			ALTER TABLE feeds ADD COLUMN redirected_url text not null default '';
+			ALTER TABLE feeds ADD COLUMN redirected_at timestamp with time zone null;
+			ALTER TABLE feeds ADD COLUMN suggestions_dismissed_at timestamp with time zone null;
+		`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `
+			CREATE TABLE integration_deliveries (
+				id bigserial not null,
+				user_id int not null,
+				integration text not null,
+				event_type text not null,
+				feed_id bigint not null default 0,
+				entry_ids bigint[] not null default '{}',
+				status text not null default 'pending',
+				attempts int not null default 0,
+				last_error text not null default '',
+				created_at timestamp with time zone not null default now(),
+				next_attempt_at timestamp with time zone not null default now(),
+				delivered_at timestamp with time zone null,
+				primary key (id),
+				foreign key (user_id) references users(id) on delete cascade
+			);
+			CREATE INDEX integration_deliveries_user_id_idx ON integration_deliveries(user_id, id);
+			CREATE INDEX integration_deliveries_pending_idx ON integration_deliveries(next_attempt_at) WHERE status = 'pending';
+		`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `
+			CREATE TABLE entry_rules (
+				id bigserial not null,
+				user_id int not null,
+				title text not null,
+				disabled bool not null default 'f',
+				match_all bool not null default 't',
+				conditions jsonb not null default '[]',
+				actions jsonb not null default '[]',
+				created_at timestamp with time zone not null default now(),
+				primary key (id),
+				foreign key (user_id) references users(id) on delete cascade
+			);
+			CREATE INDEX entry_rules_user_id_idx ON entry_rules(user_id);
+		`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `
+			ALTER TABLE entries ADD COLUMN user_tags text[] not null default '{}';
+			CREATE INDEX entries_user_tags_idx ON entries USING gin(user_tags);
+		`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `
+			CREATE TABLE entry_annotations (
+				id bigserial not null,
+				user_id int not null,
+				entry_id bigint not null,
+				text text not null,
+				note text not null default '',
+				created_at timestamp with time zone not null default now(),
+				updated_at timestamp with time zone not null default now(),
+				primary key (id),
+				foreign key (user_id) references users(id) on delete cascade,
+				foreign key (entry_id) references entries(id) on delete cascade
+			);
+			CREATE INDEX entry_annotations_user_id_idx ON entry_annotations(user_id);
+			CREATE INDEX entry_annotations_entry_id_idx ON entry_annotations(entry_id);
+		`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `
+			ALTER TABLE users ADD COLUMN digest_frequency text not null default 'none';
+			ALTER TABLE users ADD COLUMN digest_entries text not null default 'unread';
+			ALTER TABLE users ADD COLUMN digest_email text not null default '';
+			ALTER TABLE users ADD COLUMN digest_sent_at timestamp with time zone;
+			ALTER TABLE entries ADD COLUMN starred_at timestamp with time zone;
+			UPDATE entries SET starred_at=changed_at WHERE starred is true;
+		`
+		_, err = tx.Exec(sql)
+		return err
+	},
 }
@@ -0,0 +1,152 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package digest // import "miniflux.app/v2/internal/digest"
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"miniflux.app/v2/internal/config"
+	"miniflux.app/v2/internal/locale"
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/storage"
+	"miniflux.app/v2/internal/template"
+)
+
+// maxEntries is the maximum number of entries listed in a digest.
+const maxEntries = 200
+
+// Digest represents the entries sent to a user, grouped by category.
+type Digest struct {
+	User       *model.User
+	Since      time.Time
+	Categories []*Category
+	Count      int
+}
+
+// Category represents the entries of a category in a digest.
+type Category struct {
+	Title   string
+	Entries model.Entries
+}
+
+// New groups the entries by category, keeping their order.
+func New(user *model.User, since time.Time, entries model.Entries) *Digest {
+	digest := &Digest{User: user, Since: since, Count: len(entries)}
+	categories := make(map[int64]*Category)
+
+	for _, entry := range entries {
+		categoryID := entry.Feed.Category.ID
+		category, found := categories[categoryID]
+		if !found {
+			category = &Category{Title: entry.Feed.Category.Title}
+			categories[categoryID] = category
+			digest.Categories = append(digest.Categories, category)
+		}
+		category.Entries = append(category.Entries, entry)
+	}
+
+	return digest
+}
+
+// Build collects the entries of the digest covering the period between the given dates.
+// The starred digests list the entries starred during the period, the others the unread entries created during the period.
+func Build(store *storage.Storage, user *model.User, since, until time.Time) (*Digest, error) {
+	builder := store.NewEntryQueryBuilder(user.ID)
+	if user.DigestEntries == model.DigestEntriesStarred {
+		builder.WithStarred(true)
+		builder.AfterStarredDate(since)
+		builder.BeforeStarredDate(until)
+	} else {
+		builder.WithStatus(model.EntryStatusUnread)
+		builder.WithGloballyVisible()
+		builder.AfterCreatedDate(since)
+		builder.BeforeCreatedDate(until)
+	}
+	builder.WithSorting("c.title", "ASC")
+	builder.WithSorting("e.published_at", "DESC")
+	builder.WithLimit(maxEntries)
+
+	entries, err := builder.GetEntries()
+	if err != nil {
+		return nil, err
+	}
+
+	return New(user, since, entries), nil
+}
+
+// Subject returns the subject of the digest email in the language of the user.
+func (d *Digest) Subject() string {
+	printer := locale.NewPrinter(d.User.Language)
+	if d.User.DigestFrequency == model.DigestFrequencyWeekly {
+		return printer.Print("email.digest.subject.weekly")
+	}
+	return printer.Print("email.digest.subject.daily")
+}
+
+// Render returns the HTML and plain text versions of the digest email.
+func (d *Digest) Render() (htmlBody, textBody []byte, err error) {
+	pageURL := config.Opts.BaseURL() + "/unread"
+	if d.User.DigestEntries == model.DigestEntriesStarred {
+		pageURL = config.Opts.BaseURL() + "/starred"
+	}
+
+	return template.RenderEmail("digest", map[string]interface{}{
+		"language": d.User.Language,
+		"subject":  d.Subject(),
+		"digest":   d,
+		"pageURL":  pageURL,
+	})
+}
+
+// Send builds and sends the digest due for a user, nothing is sent when there are no entries.
+// The digest is recorded before being sent, so that a database failure afterward does not send it again.
+func Send(store *storage.Storage, mailer *Mailer, schedule *model.DigestSchedule) error {
+	user, err := store.UserByID(schedule.UserID)
+	if err != nil {
+		return err
+	}
+
+	if user == nil {
+		return errors.New("digest: user not found")
+	}
+
+	sentAt := time.Now().Truncate(time.Hour)
+	claimed, err := store.ClaimDigest(schedule, sentAt)
+	if err != nil {
+		return err
+	}
+
+	if !claimed {
+		return nil
+	}
+
+	if err := send(store, mailer, user, schedule.Since, sentAt); err != nil {
+		if releaseErr := store.ReleaseDigest(schedule, sentAt); releaseErr != nil {
+			return errors.Join(err, releaseErr)
+		}
+		return err
+	}
+
+	return nil
+}
+
+func send(store *storage.Storage, mailer *Mailer, user *model.User, since, until time.Time) error {
+	digest, err := Build(store, user, since, until)
+	if err != nil {
+		return err
+	}
+
+	if digest.Count == 0 {
+		return nil
+	}
+
+	htmlBody, textBody, err := digest.Render()
+	if err != nil {
+		return fmt.Errorf("digest: unable to render the email: %w", err)
+	}
+
+	return mailer.Send(user.DigestEmail, digest.Subject(), htmlBody, textBody)
+}
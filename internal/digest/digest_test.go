@@ -0,0 +1,218 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package digest // import "miniflux.app/v2/internal/digest"
+
+import (
+	"bufio"
+	"net"
+	"net/mail"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"miniflux.app/v2/internal/config"
+	"miniflux.app/v2/internal/locale"
+	"miniflux.app/v2/internal/model"
+)
+
+func newEntry(title, url string, categoryID int64, categoryTitle string) *model.Entry {
+	return &model.Entry{
+		Title: title,
+		URL:   url,
+		Feed: &model.Feed{
+			Title:    "Feed of " + categoryTitle,
+			Category: &model.Category{ID: categoryID, Title: categoryTitle},
+		},
+	}
+}
+
+func TestNewGroupsEntriesByCategory(t *testing.T) {
+	entries := model.Entries{
+		newEntry("First", "https://example.org/1", 1, "News"),
+		newEntry("Second", "https://example.org/2", 2, "Science"),
+		newEntry("Third", "https://example.org/3", 1, "News"),
+	}
+
+	digest := New(&model.User{}, time.Now(), entries)
+
+	if digest.Count != 3 {
+		t.Fatalf(`Unexpected number of entries, got %d instead of 3`, digest.Count)
+	}
+
+	if len(digest.Categories) != 2 {
+		t.Fatalf(`Unexpected number of categories, got %d instead of 2`, len(digest.Categories))
+	}
+
+	if digest.Categories[0].Title != "News" || len(digest.Categories[0].Entries) != 2 {
+		t.Errorf(`Unexpected first category: %q with %d entries`, digest.Categories[0].Title, len(digest.Categories[0].Entries))
+	}
+
+	if digest.Categories[0].Entries[1].Title != "Third" {
+		t.Errorf(`The order of the entries should be kept, got %q`, digest.Categories[0].Entries[1].Title)
+	}
+
+	if digest.Categories[1].Title != "Science" || len(digest.Categories[1].Entries) != 1 {
+		t.Errorf(`Unexpected second category: %q with %d entries`, digest.Categories[1].Title, len(digest.Categories[1].Entries))
+	}
+}
+
+func TestRender(t *testing.T) {
+	var err error
+	parser := config.NewParser()
+	config.Opts, err = parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	if err := locale.LoadCatalogMessages(); err != nil {
+		t.Fatalf(`Unable to load the translations: %v`, err)
+	}
+
+	user := &model.User{Language: "en_US", DigestFrequency: model.DigestFrequencyWeekly, DigestEntries: model.DigestEntriesStarred}
+	entries := model.Entries{newEntry("Cats & Dogs", "https://example.org/1", 1, "News")}
+
+	digest := New(user, time.Now(), entries)
+	htmlBody, textBody, err := digest.Render()
+	if err != nil {
+		t.Fatalf(`Unable to render the digest: %v`, err)
+	}
+
+	if digest.Subject() != "Your weekly Miniflux digest" {
+		t.Errorf(`Unexpected subject: %q`, digest.Subject())
+	}
+
+	if !strings.Contains(string(htmlBody), `<a href="https://example.org/1" style="color: #3366cc;">Cats &amp; Dogs</a>`) {
+		t.Errorf(`The HTML version should contain an escaped link to the entry, got %s`, htmlBody)
+	}
+
+	if !strings.Contains(string(textBody), "- Cats & Dogs (Feed of News)\n  https://example.org/1\n") {
+		t.Errorf(`The text version should list the entry, got %s`, textBody)
+	}
+
+	if !strings.Contains(string(textBody), "## News") {
+		t.Errorf(`The text version should contain the category, got %s`, textBody)
+	}
+
+	if !strings.Contains(string(textBody), "/starred") {
+		t.Errorf(`The digest of starred entries should link to the starred page, got %s`, textBody)
+	}
+}
+
+func TestBuildMessage(t *testing.T) {
+	sender := &mail.Address{Name: "Miniflux", Address: "miniflux@example.org"}
+	recipient := &mail.Address{Address: "user@example.org"}
+
+	message, err := buildMessage(sender, recipient, "Résumé", []byte("<p>HTML</p>"), []byte("Text"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	parsedMessage, err := mail.ReadMessage(strings.NewReader(string(message)))
+	if err != nil {
+		t.Fatalf(`The message should be parsable: %v`, err)
+	}
+
+	if from, err := mail.ParseAddress(parsedMessage.Header.Get("From")); err != nil || from.Address != "miniflux@example.org" {
+		t.Errorf(`Unexpected From header: %q`, parsedMessage.Header.Get("From"))
+	}
+
+	if parsedMessage.Header.Get("Subject") != "=?utf-8?q?R=C3=A9sum=C3=A9?=" {
+		t.Errorf(`The subject should be encoded, got %q`, parsedMessage.Header.Get("Subject"))
+	}
+
+	if !strings.HasPrefix(parsedMessage.Header.Get("Content-Type"), "multipart/alternative; boundary=") {
+		t.Errorf(`Unexpected Content-Type header: %q`, parsedMessage.Header.Get("Content-Type"))
+	}
+
+	if !strings.Contains(string(message), "text/plain; charset=utf-8") || !strings.Contains(string(message), "text/html; charset=utf-8") {
+		t.Errorf(`The message should contain both HTML and text parts`)
+	}
+}
+
+// startSMTPSink starts a minimal SMTP server accepting a single message.
+func startSMTPSink(t *testing.T) (string, int, <-chan string) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	messages := make(chan string, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		reader := bufio.NewReader(conn)
+		reply := func(line string) { conn.Write([]byte(line + "\r\n")) }
+		reply("220 localhost ESMTP")
+
+		var data strings.Builder
+		for {
+			line, err := reader.ReadString('\n')
+			if err != nil {
+				return
+			}
+
+			command := strings.ToUpper(strings.TrimSpace(line))
+			switch {
+			case strings.HasPrefix(command, "EHLO"), strings.HasPrefix(command, "HELO"):
+				reply("250 localhost")
+			case strings.HasPrefix(command, "DATA"):
+				reply("354 End data with <CR><LF>.<CR><LF>")
+				for {
+					dataLine, err := reader.ReadString('\n')
+					if err != nil {
+						return
+					}
+					if dataLine == ".\r\n" {
+						break
+					}
+					data.WriteString(dataLine)
+				}
+				messages <- data.String()
+				reply("250 OK")
+			case strings.HasPrefix(command, "QUIT"):
+				reply("221 Bye")
+				return
+			default:
+				reply("250 OK")
+			}
+		}
+	}()
+
+	address := listener.Addr().(*net.TCPAddr)
+	return address.IP.String(), address.Port, messages
+}
+
+func TestMailerSend(t *testing.T) {
+	host, port, messages := startSMTPSink(t)
+
+	mailer := NewMailer(host, port, "", "", "Miniflux <miniflux@example.org>")
+	if err := mailer.Send("user@example.org", "Daily digest", []byte("<p>HTML</p>"), []byte("Text")); err != nil {
+		t.Fatalf(`Unable to send the email: %v`, err)
+	}
+
+	select {
+	case message := <-messages:
+		if !strings.Contains(message, "To: <user@example.org>\r\n") {
+			t.Errorf(`Unexpected recipient in message: %s`, message)
+		}
+		if !strings.Contains(message, "Subject: Daily digest\r\n") {
+			t.Errorf(`Unexpected subject in message: %s`, message)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal(`The SMTP sink did not receive the message on port ` + strconv.Itoa(port))
+	}
+}
+
+func TestMailerSendWithInvalidRecipient(t *testing.T) {
+	mailer := NewMailer("localhost", 25, "", "", "miniflux@example.org")
+	if err := mailer.Send("not an address", "Subject", nil, nil); err == nil {
+		t.Fatal(`An invalid recipient should be rejected`)
+	}
+}
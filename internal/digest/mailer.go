@@ -0,0 +1,107 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package digest // import "miniflux.app/v2/internal/digest"
+
+import (
+	"bytes"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"strconv"
+	"time"
+)
+
+// Mailer sends emails through an SMTP server.
+type Mailer struct {
+	host     string
+	port     int
+	username string
+	password string
+	from     string
+}
+
+// NewMailer returns a new Mailer, no authentication is done when the username is empty.
+func NewMailer(host string, port int, username, password, from string) *Mailer {
+	return &Mailer{host: host, port: port, username: username, password: password, from: from}
+}
+
+// Send sends an email with both HTML and plain text versions.
+func (m *Mailer) Send(to, subject string, htmlBody, textBody []byte) error {
+	sender, err := mail.ParseAddress(m.from)
+	if err != nil {
+		return fmt.Errorf("digest: invalid sender address %q: %w", m.from, err)
+	}
+
+	recipient, err := mail.ParseAddress(to)
+	if err != nil {
+		return fmt.Errorf("digest: invalid recipient address %q: %w", to, err)
+	}
+
+	message, err := buildMessage(sender, recipient, subject, htmlBody, textBody)
+	if err != nil {
+		return err
+	}
+
+	var auth smtp.Auth
+	if m.username != "" {
+		auth = smtp.PlainAuth("", m.username, m.password, m.host)
+	}
+
+	if err := smtp.SendMail(net.JoinHostPort(m.host, strconv.Itoa(m.port)), auth, sender.Address, []string{recipient.Address}, message); err != nil {
+		return fmt.Errorf("digest: unable to send the email to %q: %w", recipient.Address, err)
+	}
+
+	return nil
+}
+
+func buildMessage(sender, recipient *mail.Address, subject string, htmlBody, textBody []byte) ([]byte, error) {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+
+	for _, part := range []struct {
+		contentType string
+		content     []byte
+	}{
+		{"text/plain; charset=utf-8", textBody},
+		{"text/html; charset=utf-8", htmlBody},
+	} {
+		partWriter, err := writer.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		encoder := quotedprintable.NewWriter(partWriter)
+		if _, err := encoder.Write(part.content); err != nil {
+			return nil, err
+		}
+
+		if err := encoder.Close(); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+
+	var message bytes.Buffer
+	fmt.Fprintf(&message, "From: %s\r\n", sender.String())
+	fmt.Fprintf(&message, "To: %s\r\n", recipient.String())
+	fmt.Fprintf(&message, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", subject))
+	fmt.Fprintf(&message, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	fmt.Fprintf(&message, "MIME-Version: 1.0\r\n")
+	fmt.Fprintf(&message, "Content-Type: multipart/alternative; boundary=%s\r\n", writer.Boundary())
+	fmt.Fprintf(&message, "\r\n")
+	message.Write(body.Bytes())
+
+	return message.Bytes(), nil
+}
@@ -330,6 +330,8 @@ func checkAndSimplifyTags(addTags []Stream, removeTags []Stream) (map[StreamType
 			tags[StarredStream] = true
 		case BroadcastStream, LikeStream:
 			slog.Debug("Broadcast & Like tags are not implemented!")
+		case LabelStream:
+			// Labels are handled by labelNames.
 		default:
 			return nil, fmt.Errorf("googlereader: unsupported tag type: %s", s.Type)
 		}
@@ -353,6 +355,8 @@ func checkAndSimplifyTags(addTags []Stream, removeTags []Stream) (map[StreamType
 			tags[StarredStream] = false
 		case BroadcastStream, LikeStream:
 			slog.Debug("Broadcast & Like tags are not implemented!")
+		case LabelStream:
+			// Labels are handled by labelNames.
 		default:
 			return nil, fmt.Errorf("googlereader: unsupported tag type: %s", s.Type)
 		}
@@ -361,6 +365,17 @@ func checkAndSimplifyTags(addTags []Stream, removeTags []Stream) (map[StreamType
 	return tags, nil
 }
 
+// labelNames returns the names of the label streams, which are the user tags of the entries.
+func labelNames(streams []Stream) []string {
+	names := make([]string, 0)
+	for _, s := range streams {
+		if s.Type == LabelStream {
+			names = append(names, s.ID)
+		}
+	}
+	return names
+}
+
 func getItemIDs(r *http.Request) ([]int64, error) {
 	items := r.Form[ParamItemIDs]
 	if len(items) == 0 {
@@ -584,6 +599,32 @@ func (h *handler) editTagHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if addLabels, removeLabels := labelNames(addTags), labelNames(removeTags); len(entries) > 0 && (len(addLabels) > 0 || len(removeLabels) > 0) {
+		entryIDs := make([]int64, 0, len(entries))
+		for _, entry := range entries {
+			entryIDs = append(entryIDs, entry.ID)
+		}
+
+		if len(addLabels) > 0 {
+			if validationErr := validator.ValidateEntryUserTags(addLabels); validationErr != nil {
+				json.BadRequest(w, r, validationErr.Error())
+				return
+			}
+
+			if err := h.store.AddEntriesUserTags(userID, entryIDs, addLabels); err != nil {
+				json.ServerError(w, r, err)
+				return
+			}
+		}
+
+		if len(removeLabels) > 0 {
+			if err := h.store.RemoveEntriesUserTags(userID, entryIDs, removeLabels); err != nil {
+				json.ServerError(w, r, err)
+				return
+			}
+		}
+	}
+
 	n := 0
 	readEntryIDs := make([]int64, 0)
 	unreadEntryIDs := make([]int64, 0)
@@ -651,7 +692,7 @@ func (h *handler) editTagHandler(w http.ResponseWriter, r *http.Request) {
 		for _, entry := range entries {
 			e := entry
 			go func() {
-				integration.SendEntry(e, settings)
+				integration.SendEntry(h.store, e, settings)
 			}()
 		}
 	}
@@ -995,6 +1036,9 @@ func (h *handler) streamItemContentsHandler(w http.ResponseWriter, r *http.Reque
 		if entry.Feed.Category.Title != "" {
 			categories = append(categories, fmt.Sprintf(UserLabelPrefix, userID)+entry.Feed.Category.Title)
 		}
+		for _, tag := range entry.UserTags {
+			categories = append(categories, fmt.Sprintf(UserLabelPrefix, userID)+tag)
+		}
 		if entry.Status == model.EntryStatusRead {
 			categories = append(categories, userRead)
 		}
@@ -1080,19 +1124,29 @@ func (h *handler) disableTagHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	titles := make([]string, len(streams))
-	for i, stream := range streams {
+	// A label is either a category, a user tag of the entries, or both.
+	var categoryTitles []string
+	for _, stream := range streams {
 		if stream.Type != LabelStream {
 			json.BadRequest(w, r, errors.New("googlereader: only labels are supported"))
 			return
 		}
-		titles[i] = stream.ID
+
+		if h.store.CategoryTitleExists(userID, stream.ID) {
+			categoryTitles = append(categoryTitles, stream.ID)
+		}
+
+		if err := h.store.RemoveUserTag(userID, stream.ID); err != nil {
+			json.ServerError(w, r, err)
+			return
+		}
 	}
 
-	err = h.store.RemoveAndReplaceCategoriesByName(userID, titles)
-	if err != nil {
-		json.ServerError(w, r, err)
-		return
+	if len(categoryTitles) > 0 {
+		if err := h.store.RemoveAndReplaceCategoriesByName(userID, categoryTitles); err != nil {
+			json.ServerError(w, r, err)
+			return
+		}
 	}
 
 	OK(w, r)
@@ -1136,28 +1190,48 @@ func (h *handler) renameTagHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// A label is either a category, a user tag of the entries, or both.
 	category, err := h.store.CategoryByTitle(userID, source.ID)
 	if err != nil {
 		json.ServerError(w, r, err)
 		return
 	}
-	if category == nil {
+	hasUserTag := h.store.UserTagExists(userID, source.ID)
+	if category == nil && !hasUserTag {
 		json.NotFound(w, r)
 		return
 	}
-	categoryRequest := model.CategoryRequest{
-		Title: destination.ID,
+
+	tagRequest := model.UserTagModificationRequest{
+		Name: destination.ID,
 	}
-	verr := validator.ValidateCategoryModification(h.store, userID, category.ID, &categoryRequest)
-	if verr != nil {
+	if verr := validator.ValidateUserTagModification(&tagRequest); verr != nil {
 		json.BadRequest(w, r, verr.Error())
 		return
 	}
-	categoryRequest.Patch(category)
-	err = h.store.UpdateCategory(category)
-	if err != nil {
-		json.ServerError(w, r, err)
-		return
+
+	if category != nil {
+		categoryRequest := model.CategoryRequest{
+			Title: destination.ID,
+		}
+		verr := validator.ValidateCategoryModification(h.store, userID, category.ID, &categoryRequest)
+		if verr != nil {
+			json.BadRequest(w, r, verr.Error())
+			return
+		}
+		categoryRequest.Patch(category)
+		err = h.store.UpdateCategory(category)
+		if err != nil {
+			json.ServerError(w, r, err)
+			return
+		}
+	}
+
+	if hasUserTag {
+		if err := h.store.RenameUserTag(userID, source.ID, tagRequest.Name); err != nil {
+			json.ServerError(w, r, err)
+			return
+		}
 	}
 	OK(w, r)
 }
@@ -1187,13 +1261,32 @@ func (h *handler) tagListHandler(w http.ResponseWriter, r *http.Request) {
 	result.Tags = append(result.Tags, subscriptionCategory{
 		ID: fmt.Sprintf(UserStreamPrefix, userID) + Starred,
 	})
+	categoryTitles := make(map[string]bool, len(categories))
 	for _, category := range categories {
+		categoryTitles[category.Title] = true
 		result.Tags = append(result.Tags, subscriptionCategory{
 			ID:    fmt.Sprintf(UserLabelPrefix, userID) + category.Title,
 			Label: category.Title,
 			Type:  "folder",
 		})
 	}
+
+	// A label named after a category shares the folder stream, which includes the labeled entries.
+	userTags, err := h.store.UserTags(userID)
+	if err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+	for _, tag := range userTags {
+		if categoryTitles[tag.Name] {
+			continue
+		}
+		result.Tags = append(result.Tags, subscriptionCategory{
+			ID:    fmt.Sprintf(UserLabelPrefix, userID) + tag.Name,
+			Label: tag.Name,
+			Type:  "tag",
+		})
+	}
 	json.OK(w, r, result)
 }
 
@@ -1309,6 +1402,8 @@ func (h *handler) streamItemIDsHandler(w http.ResponseWriter, r *http.Request) {
 		h.handleReadStreamHandler(w, r, rm)
 	case FeedStream:
 		h.handleFeedStreamHandler(w, r, rm)
+	case LabelStream:
+		h.handleLabelStreamHandler(w, r, rm)
 	default:
 		slog.Warn("[GoogleReader] Unknown Stream",
 			slog.String("handler", "streamItemIDsHandler"),
@@ -1417,6 +1512,50 @@ func (h *handler) handleStarredStreamHandler(w http.ResponseWriter, r *http.Requ
 	json.OK(w, r, streamIDResponse{itemRefs, continuation})
 }
 
+// handleLabelStreamHandler returns the entries of the folder with the label name and the entries tagged by the user with the label.
+func (h *handler) handleLabelStreamHandler(w http.ResponseWriter, r *http.Request, rm RequestModifiers) {
+	builder := h.store.NewEntryQueryBuilder(rm.UserID)
+	for _, s := range rm.ExcludeTargets {
+		if s.Type == ReadStream {
+			builder.WithStatus(model.EntryStatusUnread)
+		}
+	}
+	builder.WithoutStatus(model.EntryStatusRemoved)
+	builder.WithLabel(rm.Streams[0].ID)
+	builder.WithLimit(rm.Count)
+	builder.WithOffset(rm.Offset)
+	builder.WithSorting(model.DefaultSortingOrder, rm.SortDirection)
+	if rm.StartTime > 0 {
+		builder.AfterPublishedDate(time.Unix(rm.StartTime, 0))
+	}
+	if rm.StopTime > 0 {
+		builder.BeforePublishedDate(time.Unix(rm.StopTime, 0))
+	}
+
+	rawEntryIDs, err := builder.GetEntryIDs()
+	if err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+	var itemRefs = make([]itemRef, 0)
+	for _, entryID := range rawEntryIDs {
+		formattedID := strconv.FormatInt(entryID, 10)
+		itemRefs = append(itemRefs, itemRef{ID: formattedID})
+	}
+
+	totalEntries, err := builder.CountEntries()
+	if err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+	continuation := 0
+	if len(itemRefs)+rm.Offset < totalEntries {
+		continuation = len(itemRefs) + rm.Offset
+	}
+
+	json.OK(w, r, streamIDResponse{itemRefs, continuation})
+}
+
 func (h *handler) handleReadStreamHandler(w http.ResponseWriter, r *http.Request, rm RequestModifiers) {
 	builder := h.store.NewEntryQueryBuilder(rm.UserID)
 	builder.WithoutStatus(model.EntryStatusRemoved)
@@ -0,0 +1,208 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package htmldiff compares two versions of a HTML document word by word.
+package htmldiff // import "miniflux.app/v2/internal/htmldiff"
+
+import (
+	"html"
+	"io"
+	"strings"
+
+	nethtml "golang.org/x/net/html"
+)
+
+// maxMatrixSize limits the memory used to compare long documents,
+// the changed part is shown as a single replacement above this size.
+const maxMatrixSize = 4_000_000
+
+const lineBreak = "\n"
+
+var blockElements = map[string]bool{
+	"article": true, "blockquote": true, "br": true, "dd": true, "div": true, "dl": true, "dt": true,
+	"figcaption": true, "figure": true, "h1": true, "h2": true, "h3": true, "h4": true, "h5": true,
+	"h6": true, "hr": true, "li": true, "ol": true, "p": true, "pre": true, "section": true,
+	"table": true, "td": true, "th": true, "tr": true, "ul": true,
+}
+
+type operation int
+
+const (
+	equal operation = iota
+	deleted
+	inserted
+)
+
+type change struct {
+	operation operation
+	tokens    []string
+}
+
+// HTML returns the text of both documents with the removed words wrapped in <del> and the added words in <ins>.
+// Markup is not compared, block elements are rendered as line breaks.
+func HTML(oldDocument, newDocument string) string {
+	return render(compare(tokenizeHTML(oldDocument), tokenizeHTML(newDocument)))
+}
+
+// Text compares two plain text values, such as titles.
+func Text(oldText, newText string) string {
+	return render(compare(strings.Fields(oldText), strings.Fields(newText)))
+}
+
+// ChangeRatio returns the proportion of words added or removed between two HTML documents, from 0 to 1.
+func ChangeRatio(oldDocument, newDocument string) float64 {
+	oldTokens := tokenizeHTML(oldDocument)
+	newTokens := tokenizeHTML(newDocument)
+
+	total := len(oldTokens) + len(newTokens)
+	if total == 0 {
+		return 0
+	}
+
+	changed := 0
+	for _, c := range compare(oldTokens, newTokens) {
+		if c.operation != equal {
+			changed += len(c.tokens)
+		}
+	}
+
+	return float64(changed) / float64(total)
+}
+
+func tokenizeHTML(document string) []string {
+	var tokens []string
+	appendLineBreak := func() {
+		if len(tokens) > 0 && tokens[len(tokens)-1] != lineBreak {
+			tokens = append(tokens, lineBreak)
+		}
+	}
+
+	tokenizer := nethtml.NewTokenizer(strings.NewReader(document))
+	for {
+		switch tokenizer.Next() {
+		case nethtml.ErrorToken:
+			if tokenizer.Err() != io.EOF {
+				return tokens
+			}
+			if len(tokens) > 0 && tokens[len(tokens)-1] == lineBreak {
+				tokens = tokens[:len(tokens)-1]
+			}
+			return tokens
+		case nethtml.TextToken:
+			tokens = append(tokens, strings.Fields(string(tokenizer.Text()))...)
+		case nethtml.StartTagToken, nethtml.EndTagToken, nethtml.SelfClosingTagToken:
+			name, _ := tokenizer.TagName()
+			if blockElements[string(name)] {
+				appendLineBreak()
+			}
+		}
+	}
+}
+
+// compare returns the list of changes between two lists of tokens using the longest common subsequence.
+func compare(a, b []string) []change {
+	var changes []change
+	add := func(op operation, tokens []string) {
+		if len(tokens) == 0 {
+			return
+		}
+		if len(changes) > 0 && changes[len(changes)-1].operation == op {
+			changes[len(changes)-1].tokens = append(changes[len(changes)-1].tokens, tokens...)
+			return
+		}
+		changes = append(changes, change{operation: op, tokens: tokens})
+	}
+
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	add(equal, a[:prefix])
+	middleA := a[prefix : len(a)-suffix]
+	middleB := b[prefix : len(b)-suffix]
+
+	if (len(middleA)+1)*(len(middleB)+1) > maxMatrixSize {
+		add(deleted, middleA)
+		add(inserted, middleB)
+	} else {
+		n, m := len(middleA), len(middleB)
+		lengths := make([][]int32, n+1)
+		for i := range lengths {
+			lengths[i] = make([]int32, m+1)
+		}
+
+		for i := n - 1; i >= 0; i-- {
+			for j := m - 1; j >= 0; j-- {
+				if middleA[i] == middleB[j] {
+					lengths[i][j] = lengths[i+1][j+1] + 1
+				} else {
+					lengths[i][j] = max(lengths[i+1][j], lengths[i][j+1])
+				}
+			}
+		}
+
+		i, j := 0, 0
+		for i < n && j < m {
+			switch {
+			case middleA[i] == middleB[j]:
+				add(equal, middleA[i:i+1])
+				i++
+				j++
+			case lengths[i+1][j] >= lengths[i][j+1]:
+				add(deleted, middleA[i:i+1])
+				i++
+			default:
+				add(inserted, middleB[j:j+1])
+				j++
+			}
+		}
+		add(deleted, middleA[i:])
+		add(inserted, middleB[j:])
+	}
+
+	add(equal, a[len(a)-suffix:])
+	return changes
+}
+
+func render(changes []change) string {
+	var builder strings.Builder
+	for index, c := range changes {
+		if index > 0 {
+			builder.WriteString(" ")
+		}
+
+		switch c.operation {
+		case deleted:
+			builder.WriteString("<del>")
+		case inserted:
+			builder.WriteString("<ins>")
+		}
+
+		for tokenIndex, token := range c.tokens {
+			switch {
+			case token == lineBreak:
+				builder.WriteString("<br>")
+			default:
+				if tokenIndex > 0 && c.tokens[tokenIndex-1] != lineBreak {
+					builder.WriteString(" ")
+				}
+				builder.WriteString(html.EscapeString(token))
+			}
+		}
+
+		switch c.operation {
+		case deleted:
+			builder.WriteString("</del>")
+		case inserted:
+			builder.WriteString("</ins>")
+		}
+	}
+
+	return builder.String()
+}
@@ -0,0 +1,60 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package htmldiff // import "miniflux.app/v2/internal/htmldiff"
+
+import "testing"
+
+func TestText(t *testing.T) {
+	scenarios := []struct {
+		oldText  string
+		newText  string
+		expected string
+	}{
+		{"same title", "same title", "same title"},
+		{"Privacy policy", "Updated privacy policy", "<del>Privacy</del> <ins>Updated privacy</ins> policy"},
+		{"the quick brown fox", "the slow brown fox", "the <del>quick</del> <ins>slow</ins> brown fox"},
+		{"a b c", "", "<del>a b c</del>"},
+		{"", "a & b", "<ins>a &amp; b</ins>"},
+	}
+
+	for _, scenario := range scenarios {
+		if result := Text(scenario.oldText, scenario.newText); result != scenario.expected {
+			t.Errorf(`Unexpected diff between %q and %q, got %q instead of %q`, scenario.oldText, scenario.newText, result, scenario.expected)
+		}
+	}
+}
+
+func TestHTML(t *testing.T) {
+	oldDocument := `<p>We collect your <b>email</b> address.</p><p>We never share it.</p>`
+	newDocument := `<p>We collect your <b>email</b> address.</p><p>We share it with partners.</p>`
+
+	expected := `We collect your email address.<br>We <del>never</del> share <del>it.</del> <ins>it with partners.</ins>`
+	if result := HTML(oldDocument, newDocument); result != expected {
+		t.Errorf(`Unexpected HTML diff, got %q instead of %q`, result, expected)
+	}
+}
+
+func TestHTMLEscapesText(t *testing.T) {
+	if result := HTML(`<p>&lt;script&gt;</p>`, `<p>&lt;script&gt; tag</p>`); result != `&lt;script&gt; <ins>tag</ins>` {
+		t.Errorf(`Unexpected HTML diff, got %q`, result)
+	}
+}
+
+func TestChangeRatio(t *testing.T) {
+	if ratio := ChangeRatio("<p>a b c d</p>", "<p>a b c d</p>"); ratio != 0 {
+		t.Errorf(`Identical documents should have a ratio of 0, got %v`, ratio)
+	}
+
+	if ratio := ChangeRatio("<p>a b c d</p>", "<p>e f g h</p>"); ratio != 1 {
+		t.Errorf(`Different documents should have a ratio of 1, got %v`, ratio)
+	}
+
+	if ratio := ChangeRatio("<p>a b c d</p>", "<p>a b c e</p>"); ratio != 0.25 {
+		t.Errorf(`Unexpected ratio, got %v`, ratio)
+	}
+
+	if ratio := ChangeRatio("", ""); ratio != 0 {
+		t.Errorf(`Empty documents should have a ratio of 0, got %v`, ratio)
+	}
+}
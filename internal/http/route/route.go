@@ -21,6 +21,8 @@ func Path(router *mux.Router, name string, args ...any) string {
 		switch param := arg.(type) {
 		case string:
 			pairs = append(pairs, param)
+		case int:
+			pairs = append(pairs, strconv.Itoa(param))
 		case int64:
 			pairs = append(pairs, strconv.FormatInt(param, 10))
 		}
@@ -203,6 +203,10 @@ func setupHandler(store *storage.Storage, pool *worker.Pool) *mux.Router {
 		w.Write([]byte("OK"))
 	}).Name("healthcheck")
 
+	if config.Opts.WebSub() {
+		router.HandleFunc("/websub/{feedID}", webSubCallback(store)).Name("websubCallback").Methods(http.MethodGet, http.MethodPost)
+	}
+
 	router.HandleFunc("/version", func(w http.ResponseWriter, r *http.Request) {
 		w.Write([]byte(version.Version))
 	}).Name("version")
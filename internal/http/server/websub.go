@@ -0,0 +1,178 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package httpd // import "miniflux.app/v2/internal/http/server"
+
+import (
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"miniflux.app/v2/internal/config"
+	"miniflux.app/v2/internal/http/request"
+	"miniflux.app/v2/internal/model"
+	feedHandler "miniflux.app/v2/internal/reader/handler"
+	"miniflux.app/v2/internal/reader/websub"
+	"miniflux.app/v2/internal/storage"
+)
+
+// webSubCallback handles the intent verifications and the content distribution requests sent by WebSub hubs.
+func webSubCallback(store *storage.Storage) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		feedID := request.RouteInt64Param(r, "feedID")
+		subscription, err := store.WebSubSubscription(feedID)
+		if err != nil {
+			slog.Error("Unable to fetch the WebSub subscription", slog.Int64("feed_id", feedID), slog.Any("error", err))
+			http.Error(w, "Database Connection Error", http.StatusInternalServerError)
+			return
+		}
+
+		if subscription == nil {
+			http.NotFound(w, r)
+			return
+		}
+
+		if r.Method == http.MethodGet {
+			verifyWebSubIntent(store, subscription, w, r)
+			return
+		}
+
+		if subscription.State == model.WebSubStateUnsubscribing {
+			w.WriteHeader(http.StatusAccepted)
+			return
+		}
+
+		body, err := io.ReadAll(io.LimitReader(r.Body, config.Opts.HTTPClientMaxBodySize()))
+		if err != nil {
+			http.Error(w, "Unable to read the request body", http.StatusBadRequest)
+			return
+		}
+
+		// Content with a missing or invalid signature must be acknowledged but ignored.
+		if !websub.VerifySignature(subscription.Secret, r.Header.Get("X-Hub-Signature"), body) {
+			slog.Warn("Ignored WebSub content with an invalid signature",
+				slog.Int64("feed_id", feedID),
+				slog.String("client_ip", request.ClientIP(r)),
+			)
+			w.WriteHeader(http.StatusAccepted)
+			return
+		}
+
+		go func() {
+			if err := feedHandler.ProcessWebSubContent(store, subscription, body); err != nil {
+				slog.Warn("Unable to process WebSub content",
+					slog.Int64("feed_id", feedID),
+					slog.Any("error", err),
+				)
+			}
+		}()
+
+		w.WriteHeader(http.StatusAccepted)
+	}
+}
+
+// verifyWebSubIntent confirms the subscription or the unsubscription request pending for the subscription,
+// intents matching no pending request are refused so that nobody else can extend or end a subscription.
+func verifyWebSubIntent(store *storage.Storage, subscription *model.WebSubSubscription, w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	if query.Get("hub.topic") != subscription.TopicURL {
+		http.NotFound(w, r)
+		return
+	}
+
+	switch query.Get("hub.mode") {
+	case websub.ModeSubscribe:
+		if subscription.PendingMode != websub.ModeSubscribe {
+			http.NotFound(w, r)
+			return
+		}
+
+		leaseSeconds := webSubLeaseSeconds(query.Get("hub.lease_seconds"))
+		activated, err := store.ActivateWebSubSubscription(subscription.FeedID, time.Duration(leaseSeconds)*time.Second)
+		if err != nil {
+			slog.Error("Unable to activate the WebSub subscription", slog.Int64("feed_id", subscription.FeedID), slog.Any("error", err))
+			http.Error(w, "Database Connection Error", http.StatusInternalServerError)
+			return
+		}
+
+		if !activated {
+			http.NotFound(w, r)
+			return
+		}
+
+		slog.Info("WebSub subscription verified",
+			slog.Int64("feed_id", subscription.FeedID),
+			slog.String("hub_url", subscription.HubURL),
+			slog.Int("lease_seconds", leaseSeconds),
+		)
+		writeWebSubChallenge(w, query.Get("hub.challenge"))
+	case websub.ModeUnsubscribe:
+		if subscription.PendingMode != websub.ModeUnsubscribe {
+			http.NotFound(w, r)
+			return
+		}
+
+		removed, err := store.RemoveWebSubSubscription(subscription.FeedID)
+		if err != nil {
+			slog.Error("Unable to remove the WebSub subscription", slog.Int64("feed_id", subscription.FeedID), slog.Any("error", err))
+			http.Error(w, "Database Connection Error", http.StatusInternalServerError)
+			return
+		}
+
+		if !removed {
+			http.NotFound(w, r)
+			return
+		}
+
+		slog.Info("WebSub unsubscription verified",
+			slog.Int64("feed_id", subscription.FeedID),
+			slog.String("hub_url", subscription.HubURL),
+		)
+		writeWebSubChallenge(w, query.Get("hub.challenge"))
+	case websub.ModeDenied:
+		if subscription.PendingMode != websub.ModeSubscribe {
+			http.NotFound(w, r)
+			return
+		}
+
+		denied, err := store.DenyWebSubSubscription(subscription.FeedID)
+		if err != nil {
+			slog.Error("Unable to deny the WebSub subscription", slog.Int64("feed_id", subscription.FeedID), slog.Any("error", err))
+			http.Error(w, "Database Connection Error", http.StatusInternalServerError)
+			return
+		}
+
+		if !denied {
+			http.NotFound(w, r)
+			return
+		}
+
+		slog.Warn("WebSub subscription denied by the hub",
+			slog.Int64("feed_id", subscription.FeedID),
+			slog.String("hub_url", subscription.HubURL),
+			slog.String("reason", query.Get("hub.reason")),
+		)
+		w.WriteHeader(http.StatusOK)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// webSubLeaseSeconds returns the lease granted by the hub, bounded by the lease requested to the hub.
+func webSubLeaseSeconds(value string) int {
+	maxLeaseSeconds := config.Opts.WebSubLeaseSeconds()
+	leaseSeconds, err := strconv.Atoi(value)
+	if err != nil {
+		return maxLeaseSeconds
+	}
+	return min(max(leaseSeconds, 1), maxLeaseSeconds)
+}
+
+// writeWebSubChallenge echoes the challenge of a verified intent as plain text, so that browsers never render it.
+func writeWebSubChallenge(w http.ResponseWriter, challenge string) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Write([]byte(challenge))
+}
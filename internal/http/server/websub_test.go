@@ -0,0 +1,57 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package httpd // import "miniflux.app/v2/internal/http/server"
+
+import (
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"miniflux.app/v2/internal/config"
+)
+
+func TestWriteWebSubChallengeIsPlainText(t *testing.T) {
+	w := httptest.NewRecorder()
+	writeWebSubChallenge(w, "<script>alert(1)</script>")
+
+	if contentType := w.Header().Get("Content-Type"); contentType != "text/plain; charset=utf-8" {
+		t.Errorf(`Unexpected Content-Type header, got %q`, contentType)
+	}
+
+	if nosniff := w.Header().Get("X-Content-Type-Options"); nosniff != "nosniff" {
+		t.Errorf(`Unexpected X-Content-Type-Options header, got %q`, nosniff)
+	}
+
+	if body := w.Body.String(); body != "<script>alert(1)</script>" {
+		t.Errorf(`Unexpected body, got %q`, body)
+	}
+}
+
+func TestWebSubLeaseSecondsIsBounded(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("WEBSUB_LEASE_SECONDS", "3600")
+
+	var err error
+	config.Opts, err = config.NewParser().ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	scenarios := map[string]int{
+		"":                     3600,
+		"invalid":              3600,
+		"600":                  600,
+		"0":                    1,
+		"-5":                   1,
+		"7200":                 3600,
+		"9223372036854775807":  3600,
+		"99999999999999999999": 3600,
+	}
+
+	for value, expected := range scenarios {
+		if leaseSeconds := webSubLeaseSeconds(value); leaseSeconds != expected {
+			t.Errorf(`Unexpected lease for %q, got %d instead of %d`, value, leaseSeconds, expected)
+		}
+	}
+}
@@ -4,7 +4,10 @@
 package integration // import "miniflux.app/v2/internal/integration"
 
 import (
+	"errors"
+	"fmt"
 	"log/slog"
+	"slices"
 
 	"miniflux.app/v2/internal/config"
 	"miniflux.app/v2/internal/integration/apprise"
@@ -28,169 +31,391 @@ import (
 	"miniflux.app/v2/internal/integration/wallabag"
 	"miniflux.app/v2/internal/integration/webhook"
 	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/storage"
 )
 
-// SendEntry sends the entry to third-party providers when the user click on "Save".
-func SendEntry(entry *model.Entry, userIntegrations *model.Integration) {
-	if userIntegrations.PinboardEnabled {
-		slog.Debug("Sending entry to Pinboard",
-			slog.Int64("user_id", userIntegrations.UserID),
-			slog.Int64("entry_id", entry.ID),
-			slog.String("entry_url", entry.URL),
-		)
+var (
+	// ErrAnnotationsExportDisabled is returned when no provider supporting highlights is enabled.
+	ErrAnnotationsExportDisabled = errors.New("integration: no third-party integration supporting highlights enabled")
 
-		client := pinboard.NewClient(userIntegrations.PinboardToken)
-		err := client.CreateBookmark(
-			entry.URL,
-			entry.Title,
-			userIntegrations.PinboardTags,
-			userIntegrations.PinboardMarkAsUnread,
-		)
+	// ErrNoAnnotation is returned when the entry has no annotation to export.
+	ErrNoAnnotation = errors.New("integration: the entry has no annotation")
+)
 
-		if err != nil {
-			slog.Error("Unable to send entry to Pinboard",
-				slog.Int64("user_id", userIntegrations.UserID),
-				slog.Int64("entry_id", entry.ID),
-				slog.String("entry_url", entry.URL),
-				slog.Any("error", err),
-			)
+// errUndeliverable is returned when retrying a delivery cannot succeed anymore.
+var errUndeliverable = errors.New("integration: undeliverable event")
+
+// SendEntry sends the entry to third-party providers when the user click on "Save".
+// Each delivery is recorded, the failed ones are retried in the background.
+func SendEntry(store *storage.Storage, entry *model.Entry, userIntegrations *model.Integration) {
+	for _, name := range enabledIntegrations(userIntegrations, model.IntegrationEventSaveEntry) {
+		delivery := &model.IntegrationDelivery{
+			UserID:      userIntegrations.UserID,
+			Integration: name,
+			EventType:   model.IntegrationEventSaveEntry,
+			FeedID:      entry.FeedID,
+			EntryIDs:    []int64{entry.ID},
 		}
+
+		attemptDelivery(store, delivery, func() error {
+			return sendEntry(name, entry, userIntegrations)
+		})
 	}
+}
 
-	if userIntegrations.InstapaperEnabled {
-		slog.Debug("Sending entry to Instapaper",
+// SendEntryTo sends the entry to a single third-party provider, when the user enabled it.
+func SendEntryTo(store *storage.Storage, entry *model.Entry, userIntegrations *model.Integration, name string) {
+	if !slices.Contains(enabledIntegrations(userIntegrations, model.IntegrationEventSaveEntry), name) {
+		slog.Warn("Unable to send entry to a disabled integration",
 			slog.Int64("user_id", userIntegrations.UserID),
 			slog.Int64("entry_id", entry.ID),
-			slog.String("entry_url", entry.URL),
+			slog.String("integration", name),
 		)
+		return
+	}
 
-		client := instapaper.NewClient(userIntegrations.InstapaperUsername, userIntegrations.InstapaperPassword)
-		if err := client.AddURL(entry.URL, entry.Title); err != nil {
-			slog.Error("Unable to send entry to Instapaper",
-				slog.Int64("user_id", userIntegrations.UserID),
-				slog.Int64("entry_id", entry.ID),
-				slog.String("entry_url", entry.URL),
-				slog.Any("error", err),
-			)
-		}
+	delivery := &model.IntegrationDelivery{
+		UserID:      userIntegrations.UserID,
+		Integration: name,
+		EventType:   model.IntegrationEventSaveEntry,
+		FeedID:      entry.FeedID,
+		EntryIDs:    []int64{entry.ID},
 	}
 
-	if userIntegrations.WallabagEnabled {
-		slog.Debug("Sending entry to Wallabag",
-			slog.Int64("user_id", userIntegrations.UserID),
-			slog.Int64("entry_id", entry.ID),
-			slog.String("entry_url", entry.URL),
-		)
+	attemptDelivery(store, delivery, func() error {
+		return sendEntry(name, entry, userIntegrations)
+	})
+}
 
-		client := wallabag.NewClient(
-			userIntegrations.WallabagURL,
-			userIntegrations.WallabagClientID,
-			userIntegrations.WallabagClientSecret,
-			userIntegrations.WallabagUsername,
-			userIntegrations.WallabagPassword,
-			userIntegrations.WallabagOnlyURL,
-		)
+// PushEntries pushes a list of entries to activated third-party providers during feed refreshes.
+// The webhook receives all the new entries, the notification providers only the notified entries.
+// Each delivery is recorded, the failed ones are retried in the background.
+func PushEntries(store *storage.Storage, feed *model.Feed, entries, notifiedEntries model.Entries, userIntegrations *model.Integration) {
+	for _, name := range enabledIntegrations(userIntegrations, model.IntegrationEventNewEntries) {
+		pushedEntries := notifiedEntries
+		if name == "webhook" {
+			pushedEntries = entries
+		}
 
-		if err := client.CreateEntry(entry.URL, entry.Title, entry.Content); err != nil {
-			slog.Error("Unable to send entry to Wallabag",
-				slog.Int64("user_id", userIntegrations.UserID),
-				slog.Int64("entry_id", entry.ID),
-				slog.String("entry_url", entry.URL),
-				slog.Any("error", err),
-			)
+		if len(pushedEntries) == 0 {
+			continue
+		}
+
+		// Integrations that only support sending individual entries get a delivery per entry,
+		// so that a retry does not send again the entries already delivered.
+		batches := []model.Entries{pushedEntries}
+		if !supportsBatches(name) {
+			batches = batches[:0]
+			for _, entry := range pushedEntries {
+				batches = append(batches, model.Entries{entry})
+			}
+		}
+
+		for _, batch := range batches {
+			entryIDs := make([]int64, 0, len(batch))
+			for _, entry := range batch {
+				entryIDs = append(entryIDs, entry.ID)
+			}
+
+			delivery := &model.IntegrationDelivery{
+				UserID:      userIntegrations.UserID,
+				Integration: name,
+				EventType:   model.IntegrationEventNewEntries,
+				FeedID:      feed.ID,
+				EntryIDs:    entryIDs,
+			}
+
+			attemptDelivery(store, delivery, func() error {
+				return pushEntries(name, feed, batch, userIntegrations)
+			})
 		}
 	}
+}
 
-	if userIntegrations.NotionEnabled {
-		slog.Debug("Sending entry to Notion",
-			slog.Int64("user_id", userIntegrations.UserID),
-			slog.Int64("entry_id", entry.ID),
-			slog.String("entry_url", entry.URL),
-		)
+// SendAnnotations exports the annotations of an entry to the third-party providers supporting highlights.
+// Each delivery is recorded, the failed ones are retried in the background.
+func SendAnnotations(store *storage.Storage, entry *model.Entry, annotations model.EntryAnnotations, userIntegrations *model.Integration) error {
+	var errs []error
+	for _, name := range enabledIntegrations(userIntegrations, model.IntegrationEventAnnotations) {
+		delivery := &model.IntegrationDelivery{
+			UserID:      userIntegrations.UserID,
+			Integration: name,
+			EventType:   model.IntegrationEventAnnotations,
+			FeedID:      entry.FeedID,
+			EntryIDs:    []int64{entry.ID},
+		}
 
-		client := notion.NewClient(
-			userIntegrations.NotionToken,
-			userIntegrations.NotionPageID,
-		)
-		if err := client.UpdateDocument(entry.URL, entry.Title); err != nil {
-			slog.Error("Unable to send entry to Notion",
-				slog.Int64("user_id", userIntegrations.UserID),
+		errs = append(errs, attemptDelivery(store, delivery, func() error {
+			return sendAnnotations(name, entry, annotations, userIntegrations)
+		}))
+	}
+	return errors.Join(errs...)
+}
+
+// ExportAnnotations sends in the background the annotations of an entry to the third-party providers supporting highlights.
+// It returns ErrAnnotationsExportDisabled or ErrNoAnnotation when there is nothing to send.
+func ExportAnnotations(store *storage.Storage, entry *model.Entry) error {
+	userIntegrations, err := store.Integration(entry.UserID)
+	if err != nil {
+		return err
+	}
+
+	if !HasAnnotationsExport(userIntegrations) {
+		return ErrAnnotationsExportDisabled
+	}
+
+	annotations, err := store.EntryAnnotations(entry.UserID, entry.ID)
+	if err != nil {
+		return err
+	}
+
+	if len(annotations) == 0 {
+		return ErrNoAnnotation
+	}
+
+	go func() {
+		if err := SendAnnotations(store, entry, annotations, userIntegrations); err != nil {
+			slog.Warn("Unable to export the entry annotations, the failed deliveries will be retried",
+				slog.Int64("user_id", entry.UserID),
 				slog.Int64("entry_id", entry.ID),
-				slog.String("entry_url", entry.URL),
 				slog.Any("error", err),
 			)
 		}
-	}
+	}()
 
-	if userIntegrations.NunuxKeeperEnabled {
-		slog.Debug("Sending entry to NunuxKeeper",
-			slog.Int64("user_id", userIntegrations.UserID),
-			slog.Int64("entry_id", entry.ID),
-			slog.String("entry_url", entry.URL),
-		)
+	return nil
+}
 
-		client := nunuxkeeper.NewClient(
-			userIntegrations.NunuxKeeperURL,
-			userIntegrations.NunuxKeeperAPIKey,
-		)
+// HasAnnotationsExport returns true if the user enabled a provider supporting highlights.
+func HasAnnotationsExport(userIntegrations *model.Integration) bool {
+	return len(enabledIntegrations(userIntegrations, model.IntegrationEventAnnotations)) > 0
+}
 
-		if err := client.AddEntry(entry.URL, entry.Title, entry.Content); err != nil {
-			slog.Error("Unable to send entry to NunuxKeeper",
-				slog.Int64("user_id", userIntegrations.UserID),
-				slog.Int64("entry_id", entry.ID),
-				slog.String("entry_url", entry.URL),
+// Deliver attempts again a recorded delivery with the current settings of the user.
+func Deliver(store *storage.Storage, delivery *model.IntegrationDelivery) {
+	attemptDelivery(store, delivery, func() error {
+		userIntegrations, err := store.Integration(delivery.UserID)
+		if err != nil {
+			return err
+		}
+
+		if !slices.Contains(enabledIntegrations(userIntegrations, delivery.EventType), delivery.Integration) {
+			return fmt.Errorf("%w: the integration is disabled", errUndeliverable)
+		}
+
+		builder := store.NewEntryQueryBuilder(delivery.UserID)
+		builder.WithEntryIDs(delivery.EntryIDs)
+		builder.WithoutStatus(model.EntryStatusRemoved)
+		builder.WithSorting("e.id", "ASC")
+		entries, err := builder.GetEntries()
+		if err != nil {
+			return err
+		}
+
+		if len(entries) == 0 {
+			return fmt.Errorf("%w: the entries have been removed", errUndeliverable)
+		}
+
+		if delivery.EventType == model.IntegrationEventSaveEntry {
+			return sendEntry(delivery.Integration, entries[0], userIntegrations)
+		}
+
+		if delivery.EventType == model.IntegrationEventAnnotations {
+			annotations, err := store.EntryAnnotations(delivery.UserID, entries[0].ID)
+			if err != nil {
+				return err
+			}
+			return sendAnnotations(delivery.Integration, entries[0], annotations, userIntegrations)
+		}
+
+		feed, err := store.FeedByID(delivery.UserID, delivery.FeedID)
+		if err != nil {
+			return err
+		}
+
+		if feed == nil {
+			return fmt.Errorf("%w: the feed has been removed", errUndeliverable)
+		}
+
+		return pushEntries(delivery.Integration, feed, entries, userIntegrations)
+	})
+}
+
+// attemptDelivery records the delivery when new, sends it, saves the outcome and returns the sending error.
+func attemptDelivery(store *storage.Storage, delivery *model.IntegrationDelivery, send func() error) error {
+	if delivery.ID == 0 {
+		if err := store.CreateIntegrationDelivery(delivery); err != nil {
+			slog.Error("Unable to record the integration delivery, it will not be retried",
+				slog.Int64("user_id", delivery.UserID),
+				slog.String("integration", delivery.Integration),
 				slog.Any("error", err),
 			)
 		}
 	}
 
-	if userIntegrations.EspialEnabled {
-		slog.Debug("Sending entry to Espial",
-			slog.Int64("user_id", userIntegrations.UserID),
-			slog.Int64("entry_id", entry.ID),
-			slog.String("entry_url", entry.URL),
+	slog.Debug("Sending entries to integration",
+		slog.Int64("user_id", delivery.UserID),
+		slog.String("integration", delivery.Integration),
+		slog.String("event_type", delivery.EventType),
+		slog.Int64("delivery_id", delivery.ID),
+		slog.Any("entry_ids", delivery.EntryIDs),
+	)
+
+	err := send()
+	switch {
+	case err == nil:
+		delivery.MarkAsDelivered()
+	case errors.Is(err, errUndeliverable):
+		delivery.MarkAsAbandoned(err.Error())
+	default:
+		delivery.MarkAsFailed(err.Error())
+	}
+
+	if err != nil {
+		slog.Error("Unable to send entries to integration",
+			slog.Int64("user_id", delivery.UserID),
+			slog.String("integration", delivery.Integration),
+			slog.String("event_type", delivery.EventType),
+			slog.Int64("delivery_id", delivery.ID),
+			slog.Any("entry_ids", delivery.EntryIDs),
+			slog.Int("attempts", delivery.Attempts),
+			slog.String("status", delivery.Status),
+			slog.Any("error", err),
 		)
+	}
 
-		client := espial.NewClient(
-			userIntegrations.EspialURL,
-			userIntegrations.EspialAPIKey,
+	if delivery.ID == 0 {
+		return err
+	}
+
+	if updateErr := store.UpdateIntegrationDelivery(delivery); updateErr != nil {
+		slog.Error("Unable to update the integration delivery",
+			slog.Int64("user_id", delivery.UserID),
+			slog.Int64("delivery_id", delivery.ID),
+			slog.Any("error", updateErr),
 		)
+	}
 
-		if err := client.CreateLink(entry.URL, entry.Title, userIntegrations.EspialTags); err != nil {
-			slog.Error("Unable to send entry to Espial",
-				slog.Int64("user_id", userIntegrations.UserID),
-				slog.Int64("entry_id", entry.ID),
-				slog.String("entry_url", entry.URL),
-				slog.Any("error", err),
-			)
+	return err
+}
+
+// enabledIntegrations returns the integrations of the user receiving the given event.
+func enabledIntegrations(userIntegrations *model.Integration, eventType string) []string {
+	var names []string
+	add := func(enabled bool, name string) {
+		if enabled {
+			names = append(names, name)
 		}
 	}
 
-	if userIntegrations.PocketEnabled {
-		slog.Debug("Sending entry to Pocket",
-			slog.Int64("user_id", userIntegrations.UserID),
-			slog.Int64("entry_id", entry.ID),
-			slog.String("entry_url", entry.URL),
-		)
+	switch eventType {
+	case model.IntegrationEventSaveEntry:
+		add(userIntegrations.PinboardEnabled, "pinboard")
+		add(userIntegrations.InstapaperEnabled, "instapaper")
+		add(userIntegrations.WallabagEnabled, "wallabag")
+		add(userIntegrations.NotionEnabled, "notion")
+		add(userIntegrations.NunuxKeeperEnabled, "nunux_keeper")
+		add(userIntegrations.EspialEnabled, "espial")
+		add(userIntegrations.PocketEnabled, "pocket")
+		add(userIntegrations.LinkAceEnabled, "linkace")
+		add(userIntegrations.LinkdingEnabled, "linkding")
+		add(userIntegrations.LinkwardenEnabled, "linkwarden")
+		add(userIntegrations.ReadeckEnabled, "readeck")
+		add(userIntegrations.ReadwiseEnabled, "readwise")
+		add(userIntegrations.ShioriEnabled, "shiori")
+		add(userIntegrations.ShaarliEnabled, "shaarli")
+		add(userIntegrations.WebhookEnabled, "webhook")
+		add(userIntegrations.OmnivoreEnabled, "omnivore")
+		add(userIntegrations.RaindropEnabled, "raindrop")
+	case model.IntegrationEventNewEntries:
+		add(userIntegrations.MatrixBotEnabled, "matrix_bot")
+		add(userIntegrations.WebhookEnabled, "webhook")
+		add(userIntegrations.TelegramBotEnabled, "telegram_bot")
+		add(userIntegrations.AppriseEnabled, "apprise")
+	case model.IntegrationEventAnnotations:
+		add(userIntegrations.ReadwiseEnabled, "readwise")
+	}
 
-		client := pocket.NewClient(config.Opts.PocketConsumerKey(userIntegrations.PocketConsumerKey), userIntegrations.PocketAccessToken)
-		if err := client.AddURL(entry.URL, entry.Title); err != nil {
-			slog.Error("Unable to send entry to Pocket",
-				slog.Int64("user_id", userIntegrations.UserID),
-				slog.Int64("entry_id", entry.ID),
-				slog.String("entry_url", entry.URL),
-				slog.Any("error", err),
-			)
+	return names
+}
+
+func supportsBatches(name string) bool {
+	return name == "matrix_bot" || name == "webhook"
+}
+
+// sendAnnotations sends the annotations of an entry to a single integration.
+func sendAnnotations(name string, entry *model.Entry, annotations model.EntryAnnotations, userIntegrations *model.Integration) error {
+	if len(annotations) == 0 {
+		return fmt.Errorf("%w: the entry has no annotation", errUndeliverable)
+	}
+
+	switch name {
+	case "readwise":
+		highlights := make([]readwise.Highlight, 0, len(annotations))
+		for _, annotation := range annotations {
+			highlights = append(highlights, readwise.Highlight{
+				Text:          annotation.Text,
+				Title:         entry.Title,
+				Author:        entry.Author,
+				SourceURL:     entry.URL,
+				Note:          annotation.Note,
+				HighlightedAt: annotation.CreatedAt,
+			})
 		}
+
+		client := readwise.NewClient(userIntegrations.ReadwiseAPIKey)
+		return client.CreateHighlights(highlights)
 	}
 
-	if userIntegrations.LinkAceEnabled {
-		slog.Debug("Sending entry to LinkAce",
-			slog.Int64("user_id", userIntegrations.UserID),
-			slog.Int64("entry_id", entry.ID),
-			slog.String("entry_url", entry.URL),
-		)
+	return fmt.Errorf("%w: unknown integration %q", errUndeliverable, name)
+}
 
+// sendEntry sends a saved entry to a single integration.
+func sendEntry(name string, entry *model.Entry, userIntegrations *model.Integration) error {
+	switch name {
+	case "pinboard":
+		client := pinboard.NewClient(userIntegrations.PinboardToken)
+		return client.CreateBookmark(
+			entry.URL,
+			entry.Title,
+			userIntegrations.PinboardTags,
+			userIntegrations.PinboardMarkAsUnread,
+		)
+	case "instapaper":
+		client := instapaper.NewClient(userIntegrations.InstapaperUsername, userIntegrations.InstapaperPassword)
+		return client.AddURL(entry.URL, entry.Title)
+	case "wallabag":
+		client := wallabag.NewClient(
+			userIntegrations.WallabagURL,
+			userIntegrations.WallabagClientID,
+			userIntegrations.WallabagClientSecret,
+			userIntegrations.WallabagUsername,
+			userIntegrations.WallabagPassword,
+			userIntegrations.WallabagOnlyURL,
+		)
+		return client.CreateEntry(entry.URL, entry.Title, entry.Content)
+	case "notion":
+		client := notion.NewClient(
+			userIntegrations.NotionToken,
+			userIntegrations.NotionPageID,
+		)
+		return client.UpdateDocument(entry.URL, entry.Title)
+	case "nunux_keeper":
+		client := nunuxkeeper.NewClient(
+			userIntegrations.NunuxKeeperURL,
+			userIntegrations.NunuxKeeperAPIKey,
+		)
+		return client.AddEntry(entry.URL, entry.Title, entry.Content)
+	case "espial":
+		client := espial.NewClient(
+			userIntegrations.EspialURL,
+			userIntegrations.EspialAPIKey,
+		)
+		return client.CreateLink(entry.URL, entry.Title, userIntegrations.EspialTags)
+	case "pocket":
+		client := pocket.NewClient(config.Opts.PocketConsumerKey(userIntegrations.PocketConsumerKey), userIntegrations.PocketAccessToken)
+		return client.AddURL(entry.URL, entry.Title)
+	case "linkace":
 		client := linkace.NewClient(
 			userIntegrations.LinkAceURL,
 			userIntegrations.LinkAceAPIKey,
@@ -198,216 +423,66 @@ func SendEntry(entry *model.Entry, userIntegrations *model.Integration) {
 			userIntegrations.LinkAcePrivate,
 			userIntegrations.LinkAceCheckDisabled,
 		)
-		if err := client.AddURL(entry.URL, entry.Title); err != nil {
-			slog.Error("Unable to send entry to LinkAce",
-				slog.Int64("user_id", userIntegrations.UserID),
-				slog.Int64("entry_id", entry.ID),
-				slog.String("entry_url", entry.URL),
-				slog.Any("error", err),
-			)
-		}
-	}
-
-	if userIntegrations.LinkdingEnabled {
-		slog.Debug("Sending entry to Linkding",
-			slog.Int64("user_id", userIntegrations.UserID),
-			slog.Int64("entry_id", entry.ID),
-			slog.String("entry_url", entry.URL),
-		)
-
+		return client.AddURL(entry.URL, entry.Title)
+	case "linkding":
 		client := linkding.NewClient(
 			userIntegrations.LinkdingURL,
 			userIntegrations.LinkdingAPIKey,
 			userIntegrations.LinkdingTags,
 			userIntegrations.LinkdingMarkAsUnread,
 		)
-		if err := client.CreateBookmark(entry.URL, entry.Title); err != nil {
-			slog.Error("Unable to send entry to Linkding",
-				slog.Int64("user_id", userIntegrations.UserID),
-				slog.Int64("entry_id", entry.ID),
-				slog.String("entry_url", entry.URL),
-				slog.Any("error", err),
-			)
-		}
-	}
-
-	if userIntegrations.LinkwardenEnabled {
-		slog.Debug("Sending entry to linkwarden",
-			slog.Int64("user_id", userIntegrations.UserID),
-			slog.Int64("entry_id", entry.ID),
-			slog.String("entry_url", entry.URL),
-		)
-
+		return client.CreateBookmark(entry.URL, entry.Title)
+	case "linkwarden":
 		client := linkwarden.NewClient(
 			userIntegrations.LinkwardenURL,
 			userIntegrations.LinkwardenAPIKey,
 		)
-		if err := client.CreateBookmark(entry.URL, entry.Title); err != nil {
-			slog.Error("Unable to send entry to Linkwarden",
-				slog.Int64("user_id", userIntegrations.UserID),
-				slog.Int64("entry_id", entry.ID),
-				slog.String("entry_url", entry.URL),
-				slog.Any("error", err),
-			)
-		}
-	}
-
-	if userIntegrations.ReadeckEnabled {
-		slog.Debug("Sending entry to Readeck",
-			slog.Int64("user_id", userIntegrations.UserID),
-			slog.Int64("entry_id", entry.ID),
-			slog.String("entry_url", entry.URL),
-		)
-
+		return client.CreateBookmark(entry.URL, entry.Title)
+	case "readeck":
 		client := readeck.NewClient(
 			userIntegrations.ReadeckURL,
 			userIntegrations.ReadeckAPIKey,
 			userIntegrations.ReadeckLabels,
 			userIntegrations.ReadeckOnlyURL,
 		)
-		if err := client.CreateBookmark(entry.URL, entry.Title, entry.Content); err != nil {
-			slog.Error("Unable to send entry to Readeck",
-				slog.Int64("user_id", userIntegrations.UserID),
-				slog.Int64("entry_id", entry.ID),
-				slog.String("entry_url", entry.URL),
-				slog.Any("error", err),
-			)
-		}
-	}
-
-	if userIntegrations.ReadwiseEnabled {
-		slog.Debug("Sending entry to Readwise",
-			slog.Int64("user_id", userIntegrations.UserID),
-			slog.Int64("entry_id", entry.ID),
-			slog.String("entry_url", entry.URL),
-		)
-
+		return client.CreateBookmark(entry.URL, entry.Title, entry.Content)
+	case "readwise":
 		client := readwise.NewClient(
 			userIntegrations.ReadwiseAPIKey,
 		)
-
-		if err := client.CreateDocument(entry.URL); err != nil {
-			slog.Error("Unable to send entry to Readwise",
-				slog.Int64("user_id", userIntegrations.UserID),
-				slog.Int64("entry_id", entry.ID),
-				slog.String("entry_url", entry.URL),
-				slog.Any("error", err),
-			)
-		}
-	}
-
-	if userIntegrations.ShioriEnabled {
-		slog.Debug("Sending entry to Shiori",
-			slog.Int64("user_id", userIntegrations.UserID),
-			slog.Int64("entry_id", entry.ID),
-			slog.String("entry_url", entry.URL),
-		)
-
+		return client.CreateDocument(entry.URL)
+	case "shiori":
 		client := shiori.NewClient(
 			userIntegrations.ShioriURL,
 			userIntegrations.ShioriUsername,
 			userIntegrations.ShioriPassword,
 		)
-
-		if err := client.CreateBookmark(entry.URL, entry.Title); err != nil {
-			slog.Error("Unable to send entry to Shiori",
-				slog.Int64("user_id", userIntegrations.UserID),
-				slog.Int64("entry_id", entry.ID),
-				slog.String("entry_url", entry.URL),
-				slog.Any("error", err),
-			)
-		}
-	}
-
-	if userIntegrations.ShaarliEnabled {
-		slog.Debug("Sending entry to Shaarli",
-			slog.Int64("user_id", userIntegrations.UserID),
-			slog.Int64("entry_id", entry.ID),
-			slog.String("entry_url", entry.URL),
-		)
-
+		return client.CreateBookmark(entry.URL, entry.Title)
+	case "shaarli":
 		client := shaarli.NewClient(
 			userIntegrations.ShaarliURL,
 			userIntegrations.ShaarliAPISecret,
 		)
-
-		if err := client.CreateLink(entry.URL, entry.Title); err != nil {
-			slog.Error("Unable to send entry to Shaarli",
-				slog.Int64("user_id", userIntegrations.UserID),
-				slog.Int64("entry_id", entry.ID),
-				slog.String("entry_url", entry.URL),
-				slog.Any("error", err),
-			)
-		}
-	}
-
-	if userIntegrations.WebhookEnabled {
-		slog.Debug("Sending entry to Webhook",
-			slog.Int64("user_id", userIntegrations.UserID),
-			slog.Int64("entry_id", entry.ID),
-			slog.String("entry_url", entry.URL),
-			slog.String("webhook_url", userIntegrations.WebhookURL),
-		)
-
+		return client.CreateLink(entry.URL, entry.Title)
+	case "webhook":
 		webhookClient := webhook.NewClient(userIntegrations.WebhookURL, userIntegrations.WebhookSecret)
-		if err := webhookClient.SendSaveEntryWebhookEvent(entry); err != nil {
-			slog.Error("Unable to send entry to Webhook",
-				slog.Int64("user_id", userIntegrations.UserID),
-				slog.Int64("entry_id", entry.ID),
-				slog.String("entry_url", entry.URL),
-				slog.String("webhook_url", userIntegrations.WebhookURL),
-				slog.Any("error", err),
-			)
-		}
-	}
-
-	if userIntegrations.OmnivoreEnabled {
-		slog.Debug("Sending entry to Omnivore",
-			slog.Int64("user_id", userIntegrations.UserID),
-			slog.Int64("entry_id", entry.ID),
-			slog.String("entry_url", entry.URL),
-		)
-
+		return webhookClient.SendSaveEntryWebhookEvent(entry)
+	case "omnivore":
 		client := omnivore.NewClient(userIntegrations.OmnivoreAPIKey, userIntegrations.OmnivoreURL)
-		if err := client.SaveUrl(entry.URL); err != nil {
-			slog.Error("Unable to send entry to Omnivore",
-				slog.Int64("user_id", userIntegrations.UserID),
-				slog.Int64("entry_id", entry.ID),
-				slog.String("entry_url", entry.URL),
-				slog.Any("error", err),
-			)
-		}
-	}
-
-	if userIntegrations.RaindropEnabled {
-		slog.Debug("Sending entry to Raindrop",
-			slog.Int64("user_id", userIntegrations.UserID),
-			slog.Int64("entry_id", entry.ID),
-			slog.String("entry_url", entry.URL),
-		)
-
+		return client.SaveUrl(entry.URL)
+	case "raindrop":
 		client := raindrop.NewClient(userIntegrations.RaindropToken, userIntegrations.RaindropCollectionID, userIntegrations.RaindropTags)
-		if err := client.CreateRaindrop(entry.URL, entry.Title); err != nil {
-			slog.Error("Unable to send entry to Raindrop",
-				slog.Int64("user_id", userIntegrations.UserID),
-				slog.Int64("entry_id", entry.ID),
-				slog.String("entry_url", entry.URL),
-				slog.Any("error", err),
-			)
-		}
+		return client.CreateRaindrop(entry.URL, entry.Title)
 	}
-}
 
-// PushEntries pushes a list of entries to activated third-party providers during feed refreshes.
-func PushEntries(feed *model.Feed, entries model.Entries, userIntegrations *model.Integration) {
-	if userIntegrations.MatrixBotEnabled {
-		slog.Debug("Sending new entries to Matrix",
-			slog.Int64("user_id", userIntegrations.UserID),
-			slog.Int("nb_entries", len(entries)),
-			slog.Int64("feed_id", feed.ID),
-		)
+	return fmt.Errorf("%w: unknown integration %q", errUndeliverable, name)
+}
 
-		err := matrixbot.PushEntries(
+// pushEntries sends new entries to a single integration.
+func pushEntries(name string, feed *model.Feed, entries model.Entries, userIntegrations *model.Integration) error {
+	switch name {
+	case "matrix_bot":
+		return matrixbot.PushEntries(
 			feed,
 			entries,
 			userIntegrations.MatrixBotURL,
@@ -415,93 +490,42 @@ func PushEntries(feed *model.Feed, entries model.Entries, userIntegrations *mode
 			userIntegrations.MatrixBotPassword,
 			userIntegrations.MatrixBotChatID,
 		)
-		if err != nil {
-			slog.Error("Unable to send new entries to Matrix",
-				slog.Int64("user_id", userIntegrations.UserID),
-				slog.Int("nb_entries", len(entries)),
-				slog.Int64("feed_id", feed.ID),
-				slog.Any("error", err),
-			)
-		}
-	}
-
-	if userIntegrations.WebhookEnabled {
-		slog.Debug("Sending new entries to Webhook",
-			slog.Int64("user_id", userIntegrations.UserID),
-			slog.Int("nb_entries", len(entries)),
-			slog.Int64("feed_id", feed.ID),
-			slog.String("webhook_url", userIntegrations.WebhookURL),
-		)
-
+	case "webhook":
 		webhookClient := webhook.NewClient(userIntegrations.WebhookURL, userIntegrations.WebhookSecret)
-		if err := webhookClient.SendNewEntriesWebhookEvent(feed, entries); err != nil {
-			slog.Debug("Unable to send new entries to Webhook",
-				slog.Int64("user_id", userIntegrations.UserID),
-				slog.Int("nb_entries", len(entries)),
-				slog.Int64("feed_id", feed.ID),
-				slog.String("webhook_url", userIntegrations.WebhookURL),
-				slog.Any("error", err),
-			)
-		}
-	}
-
-	// Integrations that only support sending individual entries
-	if userIntegrations.TelegramBotEnabled || userIntegrations.AppriseEnabled {
+		return webhookClient.SendNewEntriesWebhookEvent(feed, entries)
+	case "telegram_bot":
 		for _, entry := range entries {
-			if userIntegrations.TelegramBotEnabled {
-				slog.Debug("Sending a new entry to Telegram",
-					slog.Int64("user_id", userIntegrations.UserID),
-					slog.Int64("entry_id", entry.ID),
-					slog.String("entry_url", entry.URL),
-				)
-
-				if err := telegrambot.PushEntry(
-					feed,
-					entry,
-					userIntegrations.TelegramBotToken,
-					userIntegrations.TelegramBotChatID,
-					userIntegrations.TelegramBotTopicID,
-					userIntegrations.TelegramBotDisableWebPagePreview,
-					userIntegrations.TelegramBotDisableNotification,
-					userIntegrations.TelegramBotDisableButtons,
-				); err != nil {
-					slog.Error("Unable to send entry to Telegram",
-						slog.Int64("user_id", userIntegrations.UserID),
-						slog.Int64("entry_id", entry.ID),
-						slog.String("entry_url", entry.URL),
-						slog.Any("error", err),
-					)
-				}
+			if err := telegrambot.PushEntry(
+				feed,
+				entry,
+				userIntegrations.TelegramBotToken,
+				userIntegrations.TelegramBotChatID,
+				userIntegrations.TelegramBotTopicID,
+				userIntegrations.TelegramBotDisableWebPagePreview,
+				userIntegrations.TelegramBotDisableNotification,
+				userIntegrations.TelegramBotDisableButtons,
+			); err != nil {
+				return err
 			}
+		}
+		return nil
+	case "apprise":
+		appriseServiceURLs := userIntegrations.AppriseServicesURL
+		if feed.AppriseServiceURLs != "" {
+			appriseServiceURLs = feed.AppriseServiceURLs
+		}
 
-			if userIntegrations.AppriseEnabled {
-				slog.Debug("Sending a new entry to Apprise",
-					slog.Int64("user_id", userIntegrations.UserID),
-					slog.Int64("entry_id", entry.ID),
-					slog.String("entry_url", entry.URL),
-					slog.String("apprise_url", userIntegrations.AppriseURL),
-				)
-
-				appriseServiceURLs := userIntegrations.AppriseServicesURL
-				if feed.AppriseServiceURLs != "" {
-					appriseServiceURLs = feed.AppriseServiceURLs
-				}
-
-				client := apprise.NewClient(
-					appriseServiceURLs,
-					userIntegrations.AppriseURL,
-				)
-
-				if err := client.SendNotification(entry); err != nil {
-					slog.Error("Unable to send entry to Apprise",
-						slog.Int64("user_id", userIntegrations.UserID),
-						slog.Int64("entry_id", entry.ID),
-						slog.String("entry_url", entry.URL),
-						slog.String("apprise_url", userIntegrations.AppriseURL),
-						slog.Any("error", err),
-					)
-				}
+		client := apprise.NewClient(
+			appriseServiceURLs,
+			userIntegrations.AppriseURL,
+		)
+		for _, entry := range entries {
+			if err := client.SendNotification(entry); err != nil {
+				return err
 			}
 		}
+		return nil
 	}
+
+	return fmt.Errorf("%w: unknown integration %q", errUndeliverable, name)
 }
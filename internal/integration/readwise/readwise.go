@@ -16,8 +16,9 @@ import (
 )
 
 const (
-	readwiseApiEndpoint  = "https://readwise.io/api/v3/save/"
-	defaultClientTimeout = 10 * time.Second
+	readwiseApiEndpoint           = "https://readwise.io/api/v3/save/"
+	readwiseHighlightsApiEndpoint = "https://readwise.io/api/v2/highlights/"
+	defaultClientTimeout          = 10 * time.Second
 )
 
 type Client struct {
@@ -64,6 +65,61 @@ func (c *Client) CreateDocument(entryURL string) error {
 	return nil
 }
 
+// Highlight is a passage of a document, with an optional note.
+type Highlight struct {
+	Text          string    `json:"text"`
+	Title         string    `json:"title,omitempty"`
+	Author        string    `json:"author,omitempty"`
+	SourceURL     string    `json:"source_url,omitempty"`
+	SourceType    string    `json:"source_type"`
+	Category      string    `json:"category"`
+	Note          string    `json:"note,omitempty"`
+	HighlightedAt time.Time `json:"highlighted_at"`
+}
+
+// CreateHighlights imports highlights, Readwise ignores the ones already imported.
+func (c *Client) CreateHighlights(highlights []Highlight) error {
+	if c.apiKey == "" {
+		return fmt.Errorf("readwise: missing API key")
+	}
+
+	for i := range highlights {
+		highlights[i].SourceType = "miniflux"
+		highlights[i].Category = "articles"
+	}
+
+	requestBody, err := json.Marshal(&readwiseHighlights{Highlights: highlights})
+	if err != nil {
+		return fmt.Errorf("readwise: unable to encode request body: %v", err)
+	}
+
+	request, err := http.NewRequest(http.MethodPost, readwiseHighlightsApiEndpoint, bytes.NewReader(requestBody))
+	if err != nil {
+		return fmt.Errorf("readwise: unable to create request: %v", err)
+	}
+
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("User-Agent", "Miniflux/"+version.Version)
+	request.Header.Set("Authorization", "Token "+c.apiKey)
+
+	httpClient := &http.Client{Timeout: defaultClientTimeout}
+	response, err := httpClient.Do(request)
+	if err != nil {
+		return fmt.Errorf("readwise: unable to send request: %v", err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode >= 400 {
+		return fmt.Errorf("readwise: unable to create highlights: url=%s status=%d", readwiseHighlightsApiEndpoint, response.StatusCode)
+	}
+
+	return nil
+}
+
 type readwiseDocument struct {
 	URL string `json:"url"`
 }
+
+type readwiseHighlights struct {
+	Highlights []Highlight `json:"highlights"`
+}
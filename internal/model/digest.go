@@ -0,0 +1,55 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package model // import "miniflux.app/v2/internal/model"
+
+import "time"
+
+// Frequencies of the digest emails.
+const (
+	DigestFrequencyNone   = "none"
+	DigestFrequencyDaily  = "daily"
+	DigestFrequencyWeekly = "weekly"
+)
+
+// Entries included in the digest emails.
+const (
+	DigestEntriesUnread  = "unread"
+	DigestEntriesStarred = "starred"
+)
+
+// DigestFrequencies returns the frequencies of the digest emails.
+func DigestFrequencies() map[string]string {
+	return map[string]string{
+		DigestFrequencyNone:   "form.prefs.select.digest_none",
+		DigestFrequencyDaily:  "form.prefs.select.digest_daily",
+		DigestFrequencyWeekly: "form.prefs.select.digest_weekly",
+	}
+}
+
+// DigestEntriesOptions returns the kinds of entries included in the digest emails.
+func DigestEntriesOptions() map[string]string {
+	return map[string]string{
+		DigestEntriesUnread:  "form.prefs.select.digest_unread",
+		DigestEntriesStarred: "form.prefs.select.digest_starred",
+	}
+}
+
+// DigestPeriod returns the time covered by a digest sent at the given frequency.
+func DigestPeriod(frequency string) time.Duration {
+	switch frequency {
+	case DigestFrequencyDaily:
+		return 24 * time.Hour
+	case DigestFrequencyWeekly:
+		return 7 * 24 * time.Hour
+	default:
+		return 0
+	}
+}
+
+// DigestSchedule represents a digest due for a user.
+type DigestSchedule struct {
+	UserID int64
+	SentAt *time.Time
+	Since  time.Time
+}
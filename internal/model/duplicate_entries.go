@@ -0,0 +1,20 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package model // import "miniflux.app/v2/internal/model"
+
+// Actions applied to new entries duplicating an entry the user already read.
+const (
+	DuplicateEntriesActionNone   = "none"
+	DuplicateEntriesActionRead   = "read"
+	DuplicateEntriesActionRemove = "remove"
+)
+
+// DuplicateEntriesActions returns the actions applied to duplicate entries.
+func DuplicateEntriesActions() map[string]string {
+	return map[string]string{
+		DuplicateEntriesActionNone:   "form.prefs.select.duplicate_entries_none",
+		DuplicateEntriesActionRead:   "form.prefs.select.duplicate_entries_read",
+		DuplicateEntriesActionRemove: "form.prefs.select.duplicate_entries_remove",
+	}
+}
@@ -5,13 +5,17 @@ package model // import "miniflux.app/v2/internal/model"
 
 // Enclosure represents an attachment.
 type Enclosure struct {
-	ID               int64  `json:"id"`
-	UserID           int64  `json:"user_id"`
-	EntryID          int64  `json:"entry_id"`
-	URL              string `json:"url"`
-	MimeType         string `json:"mime_type"`
-	Size             int64  `json:"size"`
-	MediaProgression int64  `json:"media_progression"`
+	ID               int64              `json:"id"`
+	UserID           int64              `json:"user_id"`
+	EntryID          int64              `json:"entry_id"`
+	URL              string             `json:"url"`
+	MimeType         string             `json:"mime_type"`
+	Size             int64              `json:"size"`
+	MediaProgression int64              `json:"media_progression"`
+	Alternate        bool               `json:"alternate"`
+	ChaptersURL      string             `json:"chapters_url"`
+	Chapters         PodcastChapters    `json:"chapters"`
+	Transcripts      PodcastTranscripts `json:"transcripts"`
 }
 
 // Html5MimeType will modify the actual MimeType to allow direct playback from HTML5 player for some kind of MimeType
@@ -22,5 +26,15 @@ func (e Enclosure) Html5MimeType() string {
 	return e.MimeType
 }
 
+// HasCaptions returns true if one of the transcripts can be shown by the media player.
+func (e Enclosure) HasCaptions() bool {
+	for _, transcript := range e.Transcripts {
+		if transcript.IsCaptions() {
+			return true
+		}
+	}
+	return false
+}
+
 // EnclosureList represents a list of attachments.
 type EnclosureList []*Enclosure
@@ -16,33 +16,48 @@ const (
 	DefaultSortingDirection = "asc"
 )
 
+// Entry search modes.
+const (
+	SearchModeFullText = "fulltext"
+	SearchModeSemantic = "semantic"
+)
+
 // Entry represents a feed item in the system.
 type Entry struct {
-	ID          int64         `json:"id"`
-	UserID      int64         `json:"user_id"`
-	FeedID      int64         `json:"feed_id"`
-	Status      string        `json:"status"`
-	Hash        string        `json:"hash"`
-	Title       string        `json:"title"`
-	URL         string        `json:"url"`
-	CommentsURL string        `json:"comments_url"`
-	Date        time.Time     `json:"published_at"`
-	CreatedAt   time.Time     `json:"created_at"`
-	ChangedAt   time.Time     `json:"changed_at"`
-	Content     string        `json:"content"`
-	Author      string        `json:"author"`
-	ShareCode   string        `json:"share_code"`
-	Starred     bool          `json:"starred"`
-	ReadingTime int           `json:"reading_time"`
-	Enclosures  EnclosureList `json:"enclosures"`
-	Feed        *Feed         `json:"feed,omitempty"`
-	Tags        []string      `json:"tags"`
+	ID            int64          `json:"id"`
+	UserID        int64          `json:"user_id"`
+	FeedID        int64          `json:"feed_id"`
+	Status        string         `json:"status"`
+	Hash          string         `json:"hash"`
+	Title         string         `json:"title"`
+	URL           string         `json:"url"`
+	CommentsURL   string         `json:"comments_url"`
+	Date          time.Time      `json:"published_at"`
+	CreatedAt     time.Time      `json:"created_at"`
+	ChangedAt     time.Time      `json:"changed_at"`
+	Content       string         `json:"content"`
+	Author        string         `json:"author"`
+	ShareCode     string         `json:"share_code"`
+	Starred       bool           `json:"starred"`
+	ReadingTime   int            `json:"reading_time"`
+	Enclosures    EnclosureList  `json:"enclosures"`
+	Feed          *Feed          `json:"feed,omitempty"`
+	Tags          []string       `json:"tags"`
+	UserTags      []string       `json:"user_tags"`
+	Podcast       PodcastEpisode `json:"podcast"`
+	Similarity    float64        `json:"similarity,omitempty"`
+	StorySiblings int            `json:"story_siblings,omitempty"`
+
+	// Non persisted attributes
+	RuleActions         EntryRuleActions `json:"-"`
+	FilterNotifications bool             `json:"-"`
 }
 
 func NewEntry() *Entry {
 	return &Entry{
 		Enclosures: make(EnclosureList, 0),
 		Tags:       make([]string, 0),
+		UserTags:   make([]string, 0),
 		Feed: &Feed{
 			Category: &Category{},
 			Icon:     &FeedIcon{},
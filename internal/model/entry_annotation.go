@@ -0,0 +1,84 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package model // import "miniflux.app/v2/internal/model"
+
+import (
+	"strings"
+	"time"
+)
+
+// EntryAnnotation is a passage highlighted by the user in an entry, with an optional note.
+type EntryAnnotation struct {
+	ID        int64     `json:"id"`
+	UserID    int64     `json:"user_id"`
+	EntryID   int64     `json:"entry_id"`
+	Text      string    `json:"text"`
+	Note      string    `json:"note"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	// Attributes of the annotated entry, used by the exports.
+	EntryTitle  string `json:"entry_title"`
+	EntryURL    string `json:"entry_url"`
+	EntryAuthor string `json:"entry_author"`
+}
+
+// EntryAnnotations represents a list of annotations.
+type EntryAnnotations []*EntryAnnotation
+
+// Markdown exports the annotations grouped by entry, each highlight as a quote followed by its note.
+func (a EntryAnnotations) Markdown() string {
+	var builder strings.Builder
+	var entryID int64
+
+	for _, annotation := range a {
+		if annotation.EntryID != entryID {
+			if entryID != 0 {
+				builder.WriteString("\n")
+			}
+			entryID = annotation.EntryID
+
+			builder.WriteString("## [" + markdownEscaper.Replace(annotation.EntryTitle) + "](" + annotation.EntryURL + ")\n")
+			if annotation.EntryAuthor != "" {
+				builder.WriteString("\n" + markdownEscaper.Replace(annotation.EntryAuthor) + "\n")
+			}
+		}
+
+		builder.WriteString("\n")
+		for _, line := range strings.Split(strings.TrimSpace(annotation.Text), "\n") {
+			builder.WriteString(strings.TrimRight("> "+strings.TrimSpace(line), " ") + "\n")
+		}
+
+		if note := strings.TrimSpace(annotation.Note); note != "" {
+			builder.WriteString("\n" + note + "\n")
+		}
+	}
+
+	return builder.String()
+}
+
+var markdownEscaper = strings.NewReplacer("[", `\[`, "]", `\]`, "*", `\*`, "_", `\_`)
+
+// EntryAnnotationRequest represents the request to create an annotation.
+type EntryAnnotationRequest struct {
+	Text string `json:"text"`
+	Note string `json:"note"`
+}
+
+// EntryAnnotationModificationRequest represents the request to update an annotation.
+type EntryAnnotationModificationRequest struct {
+	Text *string `json:"text"`
+	Note *string `json:"note"`
+}
+
+// Patch updates the annotation with the request fields.
+func (r *EntryAnnotationModificationRequest) Patch(annotation *EntryAnnotation) {
+	if r.Text != nil {
+		annotation.Text = *r.Text
+	}
+
+	if r.Note != nil {
+		annotation.Note = *r.Note
+	}
+}
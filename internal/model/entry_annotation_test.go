@@ -0,0 +1,53 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package model // import "miniflux.app/v2/internal/model"
+
+import "testing"
+
+func TestEntryAnnotationsMarkdown(t *testing.T) {
+	annotations := EntryAnnotations{
+		{EntryID: 1, EntryTitle: "First [draft]", EntryURL: "https://example.org/1", EntryAuthor: "Jane", Text: "A quote\non two lines", Note: "My note"},
+		{EntryID: 1, EntryTitle: "First [draft]", EntryURL: "https://example.org/1", EntryAuthor: "Jane", Text: "Another quote"},
+		{EntryID: 2, EntryTitle: "Second", EntryURL: "https://example.org/2", Text: "Last quote\n\nwith a paragraph"},
+	}
+
+	expected := `## [First \[draft\]](https://example.org/1)
+
+Jane
+
+> A quote
+> on two lines
+
+My note
+
+> Another quote
+
+## [Second](https://example.org/2)
+
+> Last quote
+>
+> with a paragraph
+`
+
+	if result := annotations.Markdown(); result != expected {
+		t.Errorf("Unexpected Markdown export, got:\n%s\nexpected:\n%s", result, expected)
+	}
+}
+
+func TestEntryAnnotationsMarkdownWithoutAnnotation(t *testing.T) {
+	if result := (EntryAnnotations{}).Markdown(); result != "" {
+		t.Errorf(`An empty list should not export anything, got %q`, result)
+	}
+}
+
+func TestEntryAnnotationModificationRequestPatch(t *testing.T) {
+	annotation := &EntryAnnotation{Text: "Quote", Note: "Note"}
+	note := ""
+	request := &EntryAnnotationModificationRequest{Note: &note}
+	request.Patch(annotation)
+
+	if annotation.Text != "Quote" || annotation.Note != "" {
+		t.Errorf(`Unexpected annotation after patch: %+v`, annotation)
+	}
+}
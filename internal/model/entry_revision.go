@@ -0,0 +1,48 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package model // import "miniflux.app/v2/internal/model"
+
+import (
+	"time"
+
+	"miniflux.app/v2/internal/htmldiff"
+)
+
+// EntryRevision is a previous version of an entry, saved when the feed updates its title or content.
+type EntryRevision struct {
+	ID        int64     `json:"id"`
+	UserID    int64     `json:"user_id"`
+	EntryID   int64     `json:"entry_id"`
+	Title     string    `json:"title"`
+	Content   string    `json:"content"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// EntryRevisions is a list of entry revisions.
+type EntryRevisions []*EntryRevision
+
+// EntryRevisionDiff shows the changes between a revision and the following version of the entry.
+type EntryRevisionDiff struct {
+	Revision *EntryRevision `json:"revision"`
+	Title    string         `json:"title"`
+	Content  string         `json:"content"`
+}
+
+// NewEntryRevisionDiffs compares each revision with the version that replaced it.
+// Revisions must be sorted from the most recent to the oldest.
+func NewEntryRevisionDiffs(entry *Entry, revisions EntryRevisions) []*EntryRevisionDiff {
+	diffs := make([]*EntryRevisionDiff, 0, len(revisions))
+	nextTitle, nextContent := entry.Title, entry.Content
+
+	for _, revision := range revisions {
+		diffs = append(diffs, &EntryRevisionDiff{
+			Revision: revision,
+			Title:    htmldiff.Text(revision.Title, nextTitle),
+			Content:  htmldiff.HTML(revision.Content, nextContent),
+		})
+		nextTitle, nextContent = revision.Title, revision.Content
+	}
+
+	return diffs
+}
@@ -0,0 +1,382 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package model // import "miniflux.app/v2/internal/model"
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// List of fields available in the entry rule conditions.
+const (
+	EntryRuleFieldFeed        = "feed"
+	EntryRuleFieldCategory    = "category"
+	EntryRuleFieldTitle       = "title"
+	EntryRuleFieldAuthor      = "author"
+	EntryRuleFieldContent     = "content"
+	EntryRuleFieldTags        = "tags"
+	EntryRuleFieldURL         = "url"
+	EntryRuleFieldReadingTime = "reading_time"
+)
+
+// List of operators available in the entry rule conditions.
+const (
+	EntryRuleOperatorContains    = "contains"
+	EntryRuleOperatorNotContains = "not_contains"
+	EntryRuleOperatorEquals      = "equals"
+	EntryRuleOperatorNotEquals   = "not_equals"
+	EntryRuleOperatorMatches     = "matches"
+	EntryRuleOperatorGreaterThan = "greater_than"
+	EntryRuleOperatorLessThan    = "less_than"
+)
+
+// List of entry rule actions.
+const (
+	EntryRuleActionMarkAsRead        = "mark_as_read"
+	EntryRuleActionStar              = "star"
+	EntryRuleActionAddTag            = "add_tag"
+	EntryRuleActionMoveToCategory    = "move_to_category"
+	EntryRuleActionPushToIntegration = "push_to_integration"
+	EntryRuleActionNotify            = "notify"
+)
+
+// EntryRuleFields lists the fields and the operators they support.
+var EntryRuleFields = map[string][]string{
+	EntryRuleFieldFeed:        {EntryRuleOperatorEquals, EntryRuleOperatorNotEquals},
+	EntryRuleFieldCategory:    {EntryRuleOperatorEquals, EntryRuleOperatorNotEquals},
+	EntryRuleFieldTitle:       {EntryRuleOperatorContains, EntryRuleOperatorNotContains, EntryRuleOperatorEquals, EntryRuleOperatorNotEquals, EntryRuleOperatorMatches},
+	EntryRuleFieldAuthor:      {EntryRuleOperatorContains, EntryRuleOperatorNotContains, EntryRuleOperatorEquals, EntryRuleOperatorNotEquals, EntryRuleOperatorMatches},
+	EntryRuleFieldContent:     {EntryRuleOperatorContains, EntryRuleOperatorNotContains, EntryRuleOperatorMatches},
+	EntryRuleFieldTags:        {EntryRuleOperatorContains, EntryRuleOperatorNotContains, EntryRuleOperatorMatches},
+	EntryRuleFieldURL:         {EntryRuleOperatorContains, EntryRuleOperatorNotContains, EntryRuleOperatorEquals, EntryRuleOperatorNotEquals, EntryRuleOperatorMatches},
+	EntryRuleFieldReadingTime: {EntryRuleOperatorEquals, EntryRuleOperatorGreaterThan, EntryRuleOperatorLessThan},
+}
+
+// EntryRuleActionTypes lists the supported actions.
+var EntryRuleActionTypes = []string{
+	EntryRuleActionMarkAsRead,
+	EntryRuleActionStar,
+	EntryRuleActionAddTag,
+	EntryRuleActionMoveToCategory,
+	EntryRuleActionPushToIntegration,
+	EntryRuleActionNotify,
+}
+
+// EntryRule applies actions to the new entries matching its conditions.
+type EntryRule struct {
+	ID         int64               `json:"id"`
+	UserID     int64               `json:"user_id"`
+	Title      string              `json:"title"`
+	Disabled   bool                `json:"disabled"`
+	MatchAll   bool                `json:"match_all"`
+	Conditions EntryRuleConditions `json:"conditions"`
+	Actions    EntryRuleActions    `json:"actions"`
+	CreatedAt  time.Time           `json:"created_at"`
+}
+
+// Match returns true if the entry satisfies all the conditions of the rule, or any of them when MatchAll is false.
+func (r *EntryRule) Match(feed *Feed, entry *Entry) bool {
+	if len(r.Conditions) == 0 {
+		return false
+	}
+
+	for i := range r.Conditions {
+		matched := r.Conditions[i].Match(feed, entry)
+		if matched && !r.MatchAll {
+			return true
+		}
+		if !matched && r.MatchAll {
+			return false
+		}
+	}
+
+	return r.MatchAll
+}
+
+// Targets returns true if the entries of the feed may match the rule, according to its feed and category conditions.
+func (r *EntryRule) Targets(feed *Feed) bool {
+	var feedConditions, entryConditions int
+	for i := range r.Conditions {
+		if r.Conditions[i].Field != EntryRuleFieldFeed && r.Conditions[i].Field != EntryRuleFieldCategory {
+			entryConditions++
+			continue
+		}
+
+		feedConditions++
+		matched := r.Conditions[i].Match(feed, nil)
+		if matched && !r.MatchAll {
+			return true
+		}
+		if !matched && r.MatchAll {
+			return false
+		}
+	}
+
+	return feedConditions == 0 || r.MatchAll || entryConditions > 0
+}
+
+// Preview returns the entries matching the rule, whether the rule is disabled or not.
+func (r *EntryRule) Preview(entries Entries) Entries {
+	matchedEntries := make(Entries, 0)
+	for _, entry := range entries {
+		if entry.Feed != nil && r.Match(entry.Feed, entry) {
+			matchedEntries = append(matchedEntries, entry)
+		}
+	}
+	return matchedEntries
+}
+
+// EntryRules represents a list of entry rules.
+type EntryRules []*EntryRule
+
+// Evaluate returns the actions of the active rules matching the entry.
+func (r EntryRules) Evaluate(feed *Feed, entry *Entry) EntryRuleActions {
+	var actions EntryRuleActions
+	for _, rule := range r {
+		if !rule.Disabled && rule.Match(feed, entry) {
+			actions = append(actions, rule.Actions...)
+		}
+	}
+	return actions
+}
+
+// HasAction returns true if an active rule targeting the feed has the given action.
+func (r EntryRules) HasAction(feed *Feed, actionType string) bool {
+	return slices.ContainsFunc(r, func(rule *EntryRule) bool {
+		return !rule.Disabled && rule.Actions.Has(actionType) && rule.Targets(feed)
+	})
+}
+
+// EntryRuleCondition compares a field of the entry with a value.
+type EntryRuleCondition struct {
+	Field    string `json:"field"`
+	Operator string `json:"operator"`
+	Value    string `json:"value"`
+
+	regex *regexp.Regexp
+}
+
+// Match returns true if the entry satisfies the condition.
+func (c *EntryRuleCondition) Match(feed *Feed, entry *Entry) bool {
+	switch c.Field {
+	case EntryRuleFieldFeed:
+		return c.compare(strconv.FormatInt(feed.ID, 10))
+	case EntryRuleFieldCategory:
+		if feed.Category == nil {
+			return false
+		}
+		return c.compare(strconv.FormatInt(feed.Category.ID, 10))
+	case EntryRuleFieldTitle:
+		return c.compare(entry.Title)
+	case EntryRuleFieldAuthor:
+		return c.compare(entry.Author)
+	case EntryRuleFieldContent:
+		return c.compare(entry.Content)
+	case EntryRuleFieldURL:
+		return c.compare(entry.URL)
+	case EntryRuleFieldTags:
+		found := slices.ContainsFunc(entry.Tags, func(tag string) bool {
+			if c.Operator == EntryRuleOperatorMatches {
+				return c.matchString(tag)
+			}
+			return strings.EqualFold(tag, c.Value)
+		})
+		if c.Operator == EntryRuleOperatorNotContains {
+			return !found
+		}
+		return found
+	case EntryRuleFieldReadingTime:
+		minutes, err := strconv.Atoi(c.Value)
+		if err != nil {
+			return false
+		}
+		switch c.Operator {
+		case EntryRuleOperatorEquals:
+			return entry.ReadingTime == minutes
+		case EntryRuleOperatorGreaterThan:
+			return entry.ReadingTime > minutes
+		case EntryRuleOperatorLessThan:
+			return entry.ReadingTime < minutes
+		}
+	}
+
+	return false
+}
+
+// Validate returns an error if the operator is not supported by the field or if the value is invalid.
+func (c *EntryRuleCondition) Validate() error {
+	operators, found := EntryRuleFields[c.Field]
+	if !found {
+		return fmt.Errorf("entry rule: unknown field %q", c.Field)
+	}
+
+	if !slices.Contains(operators, c.Operator) {
+		return fmt.Errorf("entry rule: operator %q is not supported by the field %q", c.Operator, c.Field)
+	}
+
+	switch {
+	case c.Operator == EntryRuleOperatorMatches:
+		if _, err := regexp.Compile(c.Value); err != nil {
+			return fmt.Errorf("entry rule: invalid regular expression %q: %v", c.Value, err)
+		}
+	case c.Field == EntryRuleFieldFeed || c.Field == EntryRuleFieldCategory || c.Field == EntryRuleFieldReadingTime:
+		if _, err := strconv.ParseInt(c.Value, 10, 64); err != nil {
+			return fmt.Errorf("entry rule: the field %q requires a number", c.Field)
+		}
+	}
+
+	return nil
+}
+
+func (c *EntryRuleCondition) compare(value string) bool {
+	switch c.Operator {
+	case EntryRuleOperatorContains:
+		return strings.Contains(strings.ToLower(value), strings.ToLower(c.Value))
+	case EntryRuleOperatorNotContains:
+		return !strings.Contains(strings.ToLower(value), strings.ToLower(c.Value))
+	case EntryRuleOperatorEquals:
+		return strings.EqualFold(value, c.Value)
+	case EntryRuleOperatorNotEquals:
+		return !strings.EqualFold(value, c.Value)
+	case EntryRuleOperatorMatches:
+		return c.matchString(value)
+	}
+	return false
+}
+
+// matchString compiles the regular expression once per condition.
+func (c *EntryRuleCondition) matchString(value string) bool {
+	if c.regex == nil {
+		regex, err := regexp.Compile(c.Value)
+		if err != nil {
+			return false
+		}
+		c.regex = regex
+	}
+	return c.regex.MatchString(value)
+}
+
+// EntryRuleConditions represents the conditions of a rule.
+type EntryRuleConditions []EntryRuleCondition
+
+// Value converts the conditions to JSON.
+func (c EntryRuleConditions) Value() (driver.Value, error) {
+	if c == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal(c)
+}
+
+// Scan converts raw JSON data.
+func (c *EntryRuleConditions) Scan(src interface{}) error {
+	source, ok := src.([]byte)
+	if !ok {
+		return errors.New("entry rule: unable to assert type of src")
+	}
+
+	if err := json.Unmarshal(source, c); err != nil {
+		return fmt.Errorf("entry rule: %v", err)
+	}
+
+	return nil
+}
+
+// EntryRuleAction is applied to the entries matching a rule.
+// The value holds the tag, the category ID or the integration, depending on the type.
+// Moving to a category applies to the feed of the entry, once per refresh.
+type EntryRuleAction struct {
+	Type  string `json:"type"`
+	Value string `json:"value,omitempty"`
+}
+
+// Validate returns an error if the action is unknown or if its value is invalid.
+func (a *EntryRuleAction) Validate() error {
+	switch a.Type {
+	case EntryRuleActionMarkAsRead, EntryRuleActionStar, EntryRuleActionNotify:
+		return nil
+	case EntryRuleActionAddTag:
+		if strings.TrimSpace(a.Value) == "" {
+			return errors.New("entry rule: the tag is required")
+		}
+	case EntryRuleActionMoveToCategory:
+		if _, err := strconv.ParseInt(a.Value, 10, 64); err != nil {
+			return errors.New("entry rule: the category is required")
+		}
+	case EntryRuleActionPushToIntegration:
+		if !slices.Contains(SaveEntryIntegrations, a.Value) {
+			return fmt.Errorf("entry rule: unknown integration %q", a.Value)
+		}
+	default:
+		return fmt.Errorf("entry rule: unknown action %q", a.Type)
+	}
+	return nil
+}
+
+// EntryRuleActions represents the actions of a rule.
+type EntryRuleActions []EntryRuleAction
+
+// Has returns true if the list contains the given action type.
+func (a EntryRuleActions) Has(actionType string) bool {
+	return slices.ContainsFunc(a, func(action EntryRuleAction) bool {
+		return action.Type == actionType
+	})
+}
+
+// Values returns the values of the given action type.
+func (a EntryRuleActions) Values(actionType string) []string {
+	var values []string
+	for _, action := range a {
+		if action.Type == actionType {
+			values = append(values, action.Value)
+		}
+	}
+	return values
+}
+
+// Value converts the actions to JSON.
+func (a EntryRuleActions) Value() (driver.Value, error) {
+	if a == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal(a)
+}
+
+// Scan converts raw JSON data.
+func (a *EntryRuleActions) Scan(src interface{}) error {
+	source, ok := src.([]byte)
+	if !ok {
+		return errors.New("entry rule: unable to assert type of src")
+	}
+
+	if err := json.Unmarshal(source, a); err != nil {
+		return fmt.Errorf("entry rule: %v", err)
+	}
+
+	return nil
+}
+
+// EntryRuleRequest represents the request to create or update an entry rule.
+type EntryRuleRequest struct {
+	Title      string              `json:"title"`
+	Disabled   bool                `json:"disabled"`
+	MatchAll   bool                `json:"match_all"`
+	Conditions EntryRuleConditions `json:"conditions"`
+	Actions    EntryRuleActions    `json:"actions"`
+}
+
+// Patch updates the rule with the request fields.
+func (r *EntryRuleRequest) Patch(rule *EntryRule) {
+	rule.Title = r.Title
+	rule.Disabled = r.Disabled
+	rule.MatchAll = r.MatchAll
+	rule.Conditions = r.Conditions
+	rule.Actions = r.Actions
+}
@@ -0,0 +1,199 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package model // import "miniflux.app/v2/internal/model"
+
+import "testing"
+
+func TestEntryRuleConditionMatch(t *testing.T) {
+	feed := &Feed{ID: 42, Category: &Category{ID: 7}}
+	entry := &Entry{
+		Title:       "Release of Miniflux 2.2",
+		Author:      "Frédéric",
+		URL:         "https://example.org/blog/release",
+		Content:     "<p>Changelog</p>",
+		Tags:        []string{"Go", "Release"},
+		ReadingTime: 5,
+	}
+
+	scenarios := []struct {
+		condition EntryRuleCondition
+		expected  bool
+	}{
+		{EntryRuleCondition{Field: EntryRuleFieldFeed, Operator: EntryRuleOperatorEquals, Value: "42"}, true},
+		{EntryRuleCondition{Field: EntryRuleFieldFeed, Operator: EntryRuleOperatorNotEquals, Value: "42"}, false},
+		{EntryRuleCondition{Field: EntryRuleFieldCategory, Operator: EntryRuleOperatorEquals, Value: "7"}, true},
+		{EntryRuleCondition{Field: EntryRuleFieldTitle, Operator: EntryRuleOperatorContains, Value: "miniflux"}, true},
+		{EntryRuleCondition{Field: EntryRuleFieldTitle, Operator: EntryRuleOperatorNotContains, Value: "miniflux"}, false},
+		{EntryRuleCondition{Field: EntryRuleFieldTitle, Operator: EntryRuleOperatorMatches, Value: `\d+\.\d+$`}, true},
+		{EntryRuleCondition{Field: EntryRuleFieldAuthor, Operator: EntryRuleOperatorEquals, Value: "frédéric"}, true},
+		{EntryRuleCondition{Field: EntryRuleFieldContent, Operator: EntryRuleOperatorContains, Value: "changelog"}, true},
+		{EntryRuleCondition{Field: EntryRuleFieldURL, Operator: EntryRuleOperatorContains, Value: "/blog/"}, true},
+		{EntryRuleCondition{Field: EntryRuleFieldTags, Operator: EntryRuleOperatorContains, Value: "go"}, true},
+		{EntryRuleCondition{Field: EntryRuleFieldTags, Operator: EntryRuleOperatorContains, Value: "Rust"}, false},
+		{EntryRuleCondition{Field: EntryRuleFieldTags, Operator: EntryRuleOperatorNotContains, Value: "Rust"}, true},
+		{EntryRuleCondition{Field: EntryRuleFieldTags, Operator: EntryRuleOperatorMatches, Value: "^Rel"}, true},
+		{EntryRuleCondition{Field: EntryRuleFieldReadingTime, Operator: EntryRuleOperatorGreaterThan, Value: "4"}, true},
+		{EntryRuleCondition{Field: EntryRuleFieldReadingTime, Operator: EntryRuleOperatorLessThan, Value: "5"}, false},
+		{EntryRuleCondition{Field: EntryRuleFieldReadingTime, Operator: EntryRuleOperatorEquals, Value: "5"}, true},
+	}
+
+	for _, scenario := range scenarios {
+		if result := scenario.condition.Match(feed, entry); result != scenario.expected {
+			t.Errorf(`Unexpected result for %+v, got %v instead of %v`, scenario.condition, result, scenario.expected)
+		}
+	}
+}
+
+func TestEntryRuleConditionValidate(t *testing.T) {
+	scenarios := []struct {
+		condition EntryRuleCondition
+		valid     bool
+	}{
+		{EntryRuleCondition{Field: EntryRuleFieldTitle, Operator: EntryRuleOperatorContains, Value: "go"}, true},
+		{EntryRuleCondition{Field: "unknown", Operator: EntryRuleOperatorContains, Value: "go"}, false},
+		{EntryRuleCondition{Field: EntryRuleFieldReadingTime, Operator: EntryRuleOperatorContains, Value: "5"}, false},
+		{EntryRuleCondition{Field: EntryRuleFieldReadingTime, Operator: EntryRuleOperatorGreaterThan, Value: "five"}, false},
+		{EntryRuleCondition{Field: EntryRuleFieldFeed, Operator: EntryRuleOperatorEquals, Value: "12"}, true},
+		{EntryRuleCondition{Field: EntryRuleFieldURL, Operator: EntryRuleOperatorMatches, Value: "(["}, false},
+	}
+
+	for _, scenario := range scenarios {
+		if err := scenario.condition.Validate(); (err == nil) != scenario.valid {
+			t.Errorf(`Unexpected validation result for %+v: %v`, scenario.condition, err)
+		}
+	}
+}
+
+func TestEntryRuleActionValidate(t *testing.T) {
+	scenarios := []struct {
+		action EntryRuleAction
+		valid  bool
+	}{
+		{EntryRuleAction{Type: EntryRuleActionAddTag, Value: "digest"}, true},
+		{EntryRuleAction{Type: EntryRuleActionAddTag}, false},
+		{EntryRuleAction{Type: EntryRuleActionMoveToCategory, Value: "3"}, true},
+		{EntryRuleAction{Type: EntryRuleActionMoveToCategory, Value: "news"}, false},
+		{EntryRuleAction{Type: "unknown"}, false},
+	}
+
+	for _, scenario := range scenarios {
+		if err := scenario.action.Validate(); (err == nil) != scenario.valid {
+			t.Errorf(`Unexpected validation result for %+v: %v`, scenario.action, err)
+		}
+	}
+}
+
+func TestEntryRulesEvaluate(t *testing.T) {
+	feed := &Feed{ID: 1}
+	entry := &Entry{Title: "Weekly podcast", ReadingTime: 1}
+
+	rules := EntryRules{
+		{
+			MatchAll: true,
+			Conditions: EntryRuleConditions{
+				{Field: EntryRuleFieldTitle, Operator: EntryRuleOperatorContains, Value: "podcast"},
+				{Field: EntryRuleFieldReadingTime, Operator: EntryRuleOperatorLessThan, Value: "2"},
+			},
+			Actions: EntryRuleActions{{Type: EntryRuleActionMarkAsRead}},
+		},
+		{
+			MatchAll: false,
+			Conditions: EntryRuleConditions{
+				{Field: EntryRuleFieldTitle, Operator: EntryRuleOperatorContains, Value: "video"},
+				{Field: EntryRuleFieldTitle, Operator: EntryRuleOperatorContains, Value: "weekly"},
+			},
+			Actions: EntryRuleActions{{Type: EntryRuleActionAddTag, Value: "digest"}},
+		},
+		{
+			MatchAll: true,
+			Conditions: EntryRuleConditions{
+				{Field: EntryRuleFieldTitle, Operator: EntryRuleOperatorContains, Value: "weekly"},
+				{Field: EntryRuleFieldTitle, Operator: EntryRuleOperatorContains, Value: "video"},
+			},
+			Actions: EntryRuleActions{{Type: EntryRuleActionStar}},
+		},
+		{
+			Disabled:   true,
+			Conditions: EntryRuleConditions{{Field: EntryRuleFieldTitle, Operator: EntryRuleOperatorContains, Value: "podcast"}},
+			Actions:    EntryRuleActions{{Type: EntryRuleActionNotify}},
+		},
+	}
+
+	actions := rules.Evaluate(feed, entry)
+	if len(actions) != 2 {
+		t.Fatalf(`Unexpected actions, got %+v`, actions)
+	}
+
+	if !actions.Has(EntryRuleActionMarkAsRead) || actions.Has(EntryRuleActionStar) || actions.Has(EntryRuleActionNotify) {
+		t.Errorf(`Unexpected actions, got %+v`, actions)
+	}
+
+	if tags := actions.Values(EntryRuleActionAddTag); len(tags) != 1 || tags[0] != "digest" {
+		t.Errorf(`Unexpected tags, got %v`, tags)
+	}
+
+	if rules.HasAction(feed, EntryRuleActionNotify) {
+		t.Errorf(`A disabled rule should not be considered`)
+	}
+}
+
+func TestEntryRuleTargets(t *testing.T) {
+	feed := &Feed{ID: 42, Category: &Category{ID: 7}}
+	otherFeed := &Feed{ID: 43, Category: &Category{ID: 8}}
+
+	feedCondition := EntryRuleCondition{Field: EntryRuleFieldFeed, Operator: EntryRuleOperatorEquals, Value: "42"}
+	categoryCondition := EntryRuleCondition{Field: EntryRuleFieldCategory, Operator: EntryRuleOperatorEquals, Value: "7"}
+	titleCondition := EntryRuleCondition{Field: EntryRuleFieldTitle, Operator: EntryRuleOperatorContains, Value: "release"}
+
+	scenarios := []struct {
+		rule     EntryRule
+		expected bool
+	}{
+		{EntryRule{MatchAll: true, Conditions: EntryRuleConditions{titleCondition}}, true},
+		{EntryRule{MatchAll: true, Conditions: EntryRuleConditions{feedCondition, titleCondition}}, false},
+		{EntryRule{MatchAll: false, Conditions: EntryRuleConditions{feedCondition, categoryCondition}}, false},
+		{EntryRule{MatchAll: false, Conditions: EntryRuleConditions{feedCondition, titleCondition}}, true},
+		{EntryRule{MatchAll: true, Conditions: EntryRuleConditions{{Field: EntryRuleFieldFeed, Operator: EntryRuleOperatorNotEquals, Value: "42"}}}, true},
+	}
+
+	for i, scenario := range scenarios {
+		if result := scenario.rule.Targets(otherFeed); result != scenario.expected {
+			t.Errorf(`Scenario #%d: got %v instead of %v`, i, result, scenario.expected)
+		}
+	}
+
+	rules := EntryRules{{
+		MatchAll:   true,
+		Conditions: EntryRuleConditions{feedCondition, titleCondition},
+		Actions:    EntryRuleActions{{Type: EntryRuleActionNotify}},
+	}}
+
+	if !rules.HasAction(feed, EntryRuleActionNotify) {
+		t.Errorf(`The rule should target the entries of its feed`)
+	}
+
+	if rules.HasAction(otherFeed, EntryRuleActionNotify) {
+		t.Errorf(`The rule should not filter the notifications of the other feeds`)
+	}
+}
+
+func TestEntryRulePreview(t *testing.T) {
+	feed := &Feed{ID: 1, Category: &Category{ID: 1}}
+	entries := Entries{
+		{ID: 1, Title: "Go 1.23 is released", Feed: feed},
+		{ID: 2, Title: "Weekly links", Feed: feed},
+		{ID: 3, Title: "Go modules", Feed: nil},
+	}
+
+	rule := &EntryRule{
+		Disabled:   true,
+		MatchAll:   true,
+		Conditions: EntryRuleConditions{{Field: EntryRuleFieldTitle, Operator: EntryRuleOperatorContains, Value: "go "}},
+	}
+
+	matchedEntries := rule.Preview(entries)
+	if len(matchedEntries) != 1 || matchedEntries[0].ID != 1 {
+		t.Fatalf(`Unexpected preview, got %+v`, matchedEntries)
+	}
+}
@@ -23,36 +23,41 @@ const (
 
 // Feed represents a feed in the application.
 type Feed struct {
-	ID                          int64     `json:"id"`
-	UserID                      int64     `json:"user_id"`
-	FeedURL                     string    `json:"feed_url"`
-	SiteURL                     string    `json:"site_url"`
-	Title                       string    `json:"title"`
-	Description                 string    `json:"description"`
-	CheckedAt                   time.Time `json:"checked_at"`
-	NextCheckAt                 time.Time `json:"next_check_at"`
-	EtagHeader                  string    `json:"etag_header"`
-	LastModifiedHeader          string    `json:"last_modified_header"`
-	ParsingErrorMsg             string    `json:"parsing_error_message"`
-	ParsingErrorCount           int       `json:"parsing_error_count"`
-	ScraperRules                string    `json:"scraper_rules"`
-	RewriteRules                string    `json:"rewrite_rules"`
-	Crawler                     bool      `json:"crawler"`
-	BlocklistRules              string    `json:"blocklist_rules"`
-	KeeplistRules               string    `json:"keeplist_rules"`
-	UrlRewriteRules             string    `json:"urlrewrite_rules"`
-	UserAgent                   string    `json:"user_agent"`
-	Cookie                      string    `json:"cookie"`
-	Username                    string    `json:"username"`
-	Password                    string    `json:"password"`
-	Disabled                    bool      `json:"disabled"`
-	NoMediaPlayer               bool      `json:"no_media_player"`
-	IgnoreHTTPCache             bool      `json:"ignore_http_cache"`
-	AllowSelfSignedCertificates bool      `json:"allow_self_signed_certificates"`
-	FetchViaProxy               bool      `json:"fetch_via_proxy"`
-	HideGlobally                bool      `json:"hide_globally"`
-	AppriseServiceURLs          string    `json:"apprise_service_urls"`
-	DisableHTTP2                bool      `json:"disable_http2"`
+	ID                          int64            `json:"id"`
+	UserID                      int64            `json:"user_id"`
+	FeedURL                     string           `json:"feed_url"`
+	SiteURL                     string           `json:"site_url"`
+	Title                       string           `json:"title"`
+	Description                 string           `json:"description"`
+	CheckedAt                   time.Time        `json:"checked_at"`
+	NextCheckAt                 time.Time        `json:"next_check_at"`
+	EtagHeader                  string           `json:"etag_header"`
+	LastModifiedHeader          string           `json:"last_modified_header"`
+	ParsingErrorMsg             string           `json:"parsing_error_message"`
+	ParsingErrorCount           int              `json:"parsing_error_count"`
+	ScraperRules                string           `json:"scraper_rules"`
+	RewriteRules                string           `json:"rewrite_rules"`
+	Crawler                     bool             `json:"crawler"`
+	BlocklistRules              string           `json:"blocklist_rules"`
+	KeeplistRules               string           `json:"keeplist_rules"`
+	UrlRewriteRules             string           `json:"urlrewrite_rules"`
+	UserAgent                   string           `json:"user_agent"`
+	Cookie                      string           `json:"cookie"`
+	Username                    string           `json:"username"`
+	Password                    string           `json:"password"`
+	Disabled                    bool             `json:"disabled"`
+	NoMediaPlayer               bool             `json:"no_media_player"`
+	IgnoreHTTPCache             bool             `json:"ignore_http_cache"`
+	AllowSelfSignedCertificates bool             `json:"allow_self_signed_certificates"`
+	FetchViaProxy               bool             `json:"fetch_via_proxy"`
+	HideGlobally                bool             `json:"hide_globally"`
+	AppriseServiceURLs          string           `json:"apprise_service_urls"`
+	DisableHTTP2                bool             `json:"disable_http2"`
+	PageWatcherRules            PageWatcherRules `json:"page_watcher_rules"`
+	JSONMapping                 JSONMapping      `json:"json_mapping"`
+	MarkUnreadOnChange          bool             `json:"mark_unread_on_change"`
+	StaleSince                  *time.Time       `json:"stale_since"`
+	RedirectedURL               string           `json:"redirected_url"`
 
 	// Non persisted attributes
 	Category *Category `json:"category,omitempty"`
@@ -61,6 +66,7 @@ type Feed struct {
 
 	TTL                    int    `json:"-"`
 	IconURL                string `json:"-"`
+	HubURL                 string `json:"-"`
 	UnreadCount            int    `json:"-"`
 	ReadCount              int    `json:"-"`
 	NumberOfVisibleEntries int    `json:"-"`
@@ -134,25 +140,28 @@ func (f *Feed) ScheduleNextCheck(weeklyCount int, newTTL int) {
 
 // FeedCreationRequest represents the request to create a feed.
 type FeedCreationRequest struct {
-	FeedURL                     string `json:"feed_url"`
-	CategoryID                  int64  `json:"category_id"`
-	UserAgent                   string `json:"user_agent"`
-	Cookie                      string `json:"cookie"`
-	Username                    string `json:"username"`
-	Password                    string `json:"password"`
-	Crawler                     bool   `json:"crawler"`
-	Disabled                    bool   `json:"disabled"`
-	NoMediaPlayer               bool   `json:"no_media_player"`
-	IgnoreHTTPCache             bool   `json:"ignore_http_cache"`
-	AllowSelfSignedCertificates bool   `json:"allow_self_signed_certificates"`
-	FetchViaProxy               bool   `json:"fetch_via_proxy"`
-	ScraperRules                string `json:"scraper_rules"`
-	RewriteRules                string `json:"rewrite_rules"`
-	BlocklistRules              string `json:"blocklist_rules"`
-	KeeplistRules               string `json:"keeplist_rules"`
-	HideGlobally                bool   `json:"hide_globally"`
-	UrlRewriteRules             string `json:"urlrewrite_rules"`
-	DisableHTTP2                bool   `json:"disable_http2"`
+	FeedURL                     string           `json:"feed_url"`
+	CategoryID                  int64            `json:"category_id"`
+	UserAgent                   string           `json:"user_agent"`
+	Cookie                      string           `json:"cookie"`
+	Username                    string           `json:"username"`
+	Password                    string           `json:"password"`
+	Crawler                     bool             `json:"crawler"`
+	Disabled                    bool             `json:"disabled"`
+	NoMediaPlayer               bool             `json:"no_media_player"`
+	IgnoreHTTPCache             bool             `json:"ignore_http_cache"`
+	AllowSelfSignedCertificates bool             `json:"allow_self_signed_certificates"`
+	FetchViaProxy               bool             `json:"fetch_via_proxy"`
+	ScraperRules                string           `json:"scraper_rules"`
+	RewriteRules                string           `json:"rewrite_rules"`
+	BlocklistRules              string           `json:"blocklist_rules"`
+	KeeplistRules               string           `json:"keeplist_rules"`
+	HideGlobally                bool             `json:"hide_globally"`
+	UrlRewriteRules             string           `json:"urlrewrite_rules"`
+	DisableHTTP2                bool             `json:"disable_http2"`
+	PageWatcherRules            PageWatcherRules `json:"page_watcher_rules"`
+	JSONMapping                 JSONMapping      `json:"json_mapping"`
+	MarkUnreadOnChange          bool             `json:"mark_unread_on_change"`
 }
 
 type FeedCreationRequestFromSubscriptionDiscovery struct {
@@ -165,28 +174,31 @@ type FeedCreationRequestFromSubscriptionDiscovery struct {
 
 // FeedModificationRequest represents the request to update a feed.
 type FeedModificationRequest struct {
-	FeedURL                     *string `json:"feed_url"`
-	SiteURL                     *string `json:"site_url"`
-	Title                       *string `json:"title"`
-	Description                 *string `json:"description"`
-	ScraperRules                *string `json:"scraper_rules"`
-	RewriteRules                *string `json:"rewrite_rules"`
-	BlocklistRules              *string `json:"blocklist_rules"`
-	KeeplistRules               *string `json:"keeplist_rules"`
-	UrlRewriteRules             *string `json:"urlrewrite_rules"`
-	Crawler                     *bool   `json:"crawler"`
-	UserAgent                   *string `json:"user_agent"`
-	Cookie                      *string `json:"cookie"`
-	Username                    *string `json:"username"`
-	Password                    *string `json:"password"`
-	CategoryID                  *int64  `json:"category_id"`
-	Disabled                    *bool   `json:"disabled"`
-	NoMediaPlayer               *bool   `json:"no_media_player"`
-	IgnoreHTTPCache             *bool   `json:"ignore_http_cache"`
-	AllowSelfSignedCertificates *bool   `json:"allow_self_signed_certificates"`
-	FetchViaProxy               *bool   `json:"fetch_via_proxy"`
-	HideGlobally                *bool   `json:"hide_globally"`
-	DisableHTTP2                *bool   `json:"disable_http2"`
+	FeedURL                     *string           `json:"feed_url"`
+	SiteURL                     *string           `json:"site_url"`
+	Title                       *string           `json:"title"`
+	Description                 *string           `json:"description"`
+	ScraperRules                *string           `json:"scraper_rules"`
+	RewriteRules                *string           `json:"rewrite_rules"`
+	BlocklistRules              *string           `json:"blocklist_rules"`
+	KeeplistRules               *string           `json:"keeplist_rules"`
+	UrlRewriteRules             *string           `json:"urlrewrite_rules"`
+	Crawler                     *bool             `json:"crawler"`
+	UserAgent                   *string           `json:"user_agent"`
+	Cookie                      *string           `json:"cookie"`
+	Username                    *string           `json:"username"`
+	Password                    *string           `json:"password"`
+	CategoryID                  *int64            `json:"category_id"`
+	Disabled                    *bool             `json:"disabled"`
+	NoMediaPlayer               *bool             `json:"no_media_player"`
+	IgnoreHTTPCache             *bool             `json:"ignore_http_cache"`
+	AllowSelfSignedCertificates *bool             `json:"allow_self_signed_certificates"`
+	FetchViaProxy               *bool             `json:"fetch_via_proxy"`
+	HideGlobally                *bool             `json:"hide_globally"`
+	DisableHTTP2                *bool             `json:"disable_http2"`
+	PageWatcherRules            *PageWatcherRules `json:"page_watcher_rules"`
+	JSONMapping                 *JSONMapping      `json:"json_mapping"`
+	MarkUnreadOnChange          *bool             `json:"mark_unread_on_change"`
 }
 
 // Patch updates a feed with modified values.
@@ -278,6 +290,18 @@ func (f *FeedModificationRequest) Patch(feed *Feed) {
 	if f.DisableHTTP2 != nil {
 		feed.DisableHTTP2 = *f.DisableHTTP2
 	}
+
+	if f.PageWatcherRules != nil {
+		feed.PageWatcherRules = *f.PageWatcherRules
+	}
+
+	if f.JSONMapping != nil {
+		feed.JSONMapping = *f.JSONMapping
+	}
+
+	if f.MarkUnreadOnChange != nil {
+		feed.MarkUnreadOnChange = *f.MarkUnreadOnChange
+	}
 }
 
 // Feeds is a list of feed
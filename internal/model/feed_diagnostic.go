@@ -0,0 +1,85 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package model // import "miniflux.app/v2/internal/model"
+
+import "time"
+
+// FeedDiagnostic records the outcome of a single feed refresh.
+type FeedDiagnostic struct {
+	ID           int64     `json:"id"`
+	UserID       int64     `json:"user_id"`
+	FeedID       int64     `json:"feed_id"`
+	CheckedAt    time.Time `json:"checked_at"`
+	StatusCode   int       `json:"status_code"`
+	Duration     int64     `json:"duration"`
+	ResponseSize int64     `json:"response_size"`
+	NotModified  bool      `json:"not_modified"`
+	ErrorMsg     string    `json:"error_message"`
+	NewEntries   int       `json:"new_entries"`
+}
+
+// Failed returns true if the refresh did not complete.
+func (f *FeedDiagnostic) Failed() bool {
+	return f.ErrorMsg != ""
+}
+
+// FeedDiagnostics is a list of feed refreshes.
+type FeedDiagnostics []*FeedDiagnostic
+
+// FeedDiagnosticsSummary aggregates the recent refreshes of a feed.
+type FeedDiagnosticsSummary struct {
+	Checks            int        `json:"checks"`
+	Errors            int        `json:"errors"`
+	NotModified       int        `json:"not_modified"`
+	AverageDuration   int64      `json:"average_duration"`
+	NewEntries        int        `json:"new_entries"`
+	LastNewEntriesAt  *time.Time `json:"last_new_entries_at"`
+	SuccessRate       float64    `json:"success_rate"`
+	CacheHitRate      float64    `json:"cache_hit_rate"`
+	FirstCheckedAt    *time.Time `json:"first_checked_at"`
+	ConsecutiveErrors int        `json:"consecutive_errors"`
+}
+
+// Summary aggregates the refreshes, the list must be sorted from the most recent to the oldest.
+func (f FeedDiagnostics) Summary() *FeedDiagnosticsSummary {
+	summary := &FeedDiagnosticsSummary{Checks: len(f)}
+	if len(f) == 0 {
+		return summary
+	}
+
+	var totalDuration int64
+	countingConsecutiveErrors := true
+	for _, diagnostic := range f {
+		totalDuration += diagnostic.Duration
+		summary.NewEntries += diagnostic.NewEntries
+
+		if diagnostic.Failed() {
+			summary.Errors++
+			if countingConsecutiveErrors {
+				summary.ConsecutiveErrors++
+			}
+		} else {
+			countingConsecutiveErrors = false
+		}
+
+		if diagnostic.NotModified {
+			summary.NotModified++
+		}
+
+		if diagnostic.NewEntries > 0 && summary.LastNewEntriesAt == nil {
+			checkedAt := diagnostic.CheckedAt
+			summary.LastNewEntriesAt = &checkedAt
+		}
+	}
+
+	firstCheckedAt := f[len(f)-1].CheckedAt
+	summary.FirstCheckedAt = &firstCheckedAt
+	summary.AverageDuration = totalDuration / int64(len(f))
+	summary.SuccessRate = float64(len(f)-summary.Errors) / float64(len(f))
+	if successfulChecks := len(f) - summary.Errors; successfulChecks > 0 {
+		summary.CacheHitRate = float64(summary.NotModified) / float64(successfulChecks)
+	}
+
+	return summary
+}
@@ -0,0 +1,53 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package model // import "miniflux.app/v2/internal/model"
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFeedDiagnosticsSummary(t *testing.T) {
+	now := time.Now()
+	diagnostics := FeedDiagnostics{
+		{CheckedAt: now, ErrorMsg: "timeout", Duration: 3000},
+		{CheckedAt: now.Add(-time.Hour), ErrorMsg: "timeout", Duration: 3000},
+		{CheckedAt: now.Add(-2 * time.Hour), NotModified: true, Duration: 100},
+		{CheckedAt: now.Add(-3 * time.Hour), NewEntries: 2, Duration: 300},
+		{CheckedAt: now.Add(-4 * time.Hour), NewEntries: 1, Duration: 100},
+	}
+
+	summary := diagnostics.Summary()
+
+	if summary.Checks != 5 || summary.Errors != 2 || summary.ConsecutiveErrors != 2 || summary.NotModified != 1 || summary.NewEntries != 3 {
+		t.Errorf(`Unexpected counters: %+v`, summary)
+	}
+
+	if summary.AverageDuration != 1300 {
+		t.Errorf(`Unexpected average duration, got %d`, summary.AverageDuration)
+	}
+
+	if summary.SuccessRate != 0.6 {
+		t.Errorf(`Unexpected success rate, got %v`, summary.SuccessRate)
+	}
+
+	if summary.CacheHitRate != float64(1)/3 {
+		t.Errorf(`Unexpected cache hit rate, got %v`, summary.CacheHitRate)
+	}
+
+	if summary.LastNewEntriesAt == nil || !summary.LastNewEntriesAt.Equal(now.Add(-3*time.Hour)) {
+		t.Errorf(`Unexpected date of the last new entries, got %v`, summary.LastNewEntriesAt)
+	}
+
+	if summary.FirstCheckedAt == nil || !summary.FirstCheckedAt.Equal(now.Add(-4*time.Hour)) {
+		t.Errorf(`Unexpected date of the first check, got %v`, summary.FirstCheckedAt)
+	}
+}
+
+func TestEmptyFeedDiagnosticsSummary(t *testing.T) {
+	summary := FeedDiagnostics{}.Summary()
+	if summary.Checks != 0 || summary.LastNewEntriesAt != nil || summary.SuccessRate != 0 {
+		t.Errorf(`Unexpected summary: %+v`, summary)
+	}
+}
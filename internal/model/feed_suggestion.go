@@ -0,0 +1,26 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package model // import "miniflux.app/v2/internal/model"
+
+import "time"
+
+// List of feed suggestion reasons.
+const (
+	FeedSuggestionStale = "stale"
+	FeedSuggestionMoved = "moved"
+)
+
+// FeedSuggestion proposes to unsubscribe from a stale feed or to update the URL of a moved feed.
+type FeedSuggestion struct {
+	FeedID      int64      `json:"feed_id"`
+	FeedTitle   string     `json:"feed_title"`
+	FeedURL     string     `json:"feed_url"`
+	SiteURL     string     `json:"site_url"`
+	Reason      string     `json:"reason"`
+	LastEntryAt *time.Time `json:"last_entry_at,omitempty"`
+	NewFeedURL  string     `json:"new_feed_url,omitempty"`
+}
+
+// FeedSuggestions represents a list of feed suggestions.
+type FeedSuggestions []*FeedSuggestion
@@ -0,0 +1,159 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package model // import "miniflux.app/v2/internal/model"
+
+import "time"
+
+// List of events delivered to the third-party integrations.
+const (
+	IntegrationEventSaveEntry   = "save_entry"
+	IntegrationEventNewEntries  = "new_entries"
+	IntegrationEventAnnotations = "annotations"
+)
+
+// List of integration delivery statuses.
+const (
+	IntegrationDeliveryStatusPending   = "pending"
+	IntegrationDeliveryStatusDelivered = "delivered"
+	IntegrationDeliveryStatusFailed    = "failed"
+)
+
+const (
+	// MaxIntegrationDeliveryAttempts is the number of attempts before a delivery is given up.
+	MaxIntegrationDeliveryAttempts = 10
+
+	maxIntegrationDeliveryBackoff = 12 * time.Hour
+)
+
+// IntegrationDelivery represents an event sent, or to be sent, to a third-party integration.
+type IntegrationDelivery struct {
+	ID            int64      `json:"id"`
+	UserID        int64      `json:"user_id"`
+	Integration   string     `json:"integration"`
+	EventType     string     `json:"event_type"`
+	FeedID        int64      `json:"feed_id"`
+	EntryIDs      []int64    `json:"entry_ids"`
+	Status        string     `json:"status"`
+	Attempts      int        `json:"attempts"`
+	LastError     string     `json:"last_error"`
+	CreatedAt     time.Time  `json:"created_at"`
+	NextAttemptAt time.Time  `json:"next_attempt_at"`
+	DeliveredAt   *time.Time `json:"delivered_at"`
+}
+
+// MarkAsDelivered records a successful attempt.
+func (d *IntegrationDelivery) MarkAsDelivered() {
+	now := time.Now()
+	d.Attempts++
+	d.Status = IntegrationDeliveryStatusDelivered
+	d.LastError = ""
+	d.DeliveredAt = &now
+}
+
+// MarkAsFailed records a failed attempt and schedules the next one with an exponential backoff,
+// unless the maximum number of attempts is reached.
+func (d *IntegrationDelivery) MarkAsFailed(errorMessage string) {
+	d.Attempts++
+	d.LastError = errorMessage
+
+	if d.Attempts >= MaxIntegrationDeliveryAttempts {
+		d.Status = IntegrationDeliveryStatusFailed
+		return
+	}
+
+	d.Status = IntegrationDeliveryStatusPending
+	d.NextAttemptAt = time.Now().Add(IntegrationDeliveryBackoff(d.Attempts))
+}
+
+// MarkAsAbandoned records a failed attempt that would not succeed later on.
+func (d *IntegrationDelivery) MarkAsAbandoned(errorMessage string) {
+	d.Attempts++
+	d.LastError = errorMessage
+	d.Status = IntegrationDeliveryStatusFailed
+}
+
+// Reset makes a delivery due again with a new set of attempts.
+func (d *IntegrationDelivery) Reset() {
+	d.Status = IntegrationDeliveryStatusPending
+	d.Attempts = 0
+	d.NextAttemptAt = time.Now()
+}
+
+// CanBeResent returns true if the user can send the delivery again.
+func (d *IntegrationDelivery) CanBeResent() bool {
+	return d.Status == IntegrationDeliveryStatusFailed || (d.Status == IntegrationDeliveryStatusPending && d.Attempts > 0)
+}
+
+// IntegrationDeliveryBackoff returns the delay before the next attempt, doubling after each failure.
+func IntegrationDeliveryBackoff(attempts int) time.Duration {
+	if attempts < 1 {
+		return 0
+	}
+
+	if attempts > 16 {
+		return maxIntegrationDeliveryBackoff
+	}
+
+	return min(time.Minute<<(attempts-1), maxIntegrationDeliveryBackoff)
+}
+
+// IntegrationName returns the display name of the integration.
+func (d *IntegrationDelivery) IntegrationName() string {
+	return IntegrationName(d.Integration)
+}
+
+// IntegrationName returns the display name of an integration identifier.
+func IntegrationName(integration string) string {
+	if name, found := integrationNames[integration]; found {
+		return name
+	}
+	return integration
+}
+
+// SaveEntryIntegrations lists the integrations receiving the entries saved by the user.
+var SaveEntryIntegrations = []string{
+	"espial",
+	"instapaper",
+	"linkace",
+	"linkding",
+	"linkwarden",
+	"notion",
+	"nunux_keeper",
+	"omnivore",
+	"pinboard",
+	"pocket",
+	"raindrop",
+	"readeck",
+	"readwise",
+	"shaarli",
+	"shiori",
+	"wallabag",
+	"webhook",
+}
+
+var integrationNames = map[string]string{
+	"apprise":      "Apprise",
+	"espial":       "Espial",
+	"instapaper":   "Instapaper",
+	"linkace":      "LinkAce",
+	"linkding":     "Linkding",
+	"linkwarden":   "Linkwarden",
+	"matrix_bot":   "Matrix",
+	"notion":       "Notion",
+	"nunux_keeper": "Nunux Keeper",
+	"omnivore":     "Omnivore",
+	"pinboard":     "Pinboard",
+	"pocket":       "Pocket",
+	"raindrop":     "Raindrop",
+	"readeck":      "Readeck",
+	"readwise":     "Readwise Reader",
+	"shaarli":      "Shaarli",
+	"shiori":       "Shiori",
+	"telegram_bot": "Telegram",
+	"wallabag":     "Wallabag",
+	"webhook":      "Webhook",
+}
+
+// IntegrationDeliveries represents a list of integration deliveries.
+type IntegrationDeliveries []*IntegrationDelivery
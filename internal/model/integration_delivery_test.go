@@ -0,0 +1,62 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package model // import "miniflux.app/v2/internal/model"
+
+import (
+	"testing"
+	"time"
+)
+
+func TestIntegrationDeliveryBackoff(t *testing.T) {
+	scenarios := map[int]time.Duration{
+		0:  0,
+		1:  time.Minute,
+		2:  2 * time.Minute,
+		5:  16 * time.Minute,
+		10: 512 * time.Minute,
+		11: 12 * time.Hour,
+		64: 12 * time.Hour,
+	}
+
+	for attempts, expected := range scenarios {
+		if result := IntegrationDeliveryBackoff(attempts); result != expected {
+			t.Errorf(`Unexpected backoff after %d attempts, got %v instead of %v`, attempts, result, expected)
+		}
+	}
+}
+
+func TestIntegrationDeliveryMarkAsFailed(t *testing.T) {
+	delivery := &IntegrationDelivery{Status: IntegrationDeliveryStatusPending}
+
+	delivery.MarkAsFailed("connection refused")
+	if delivery.Status != IntegrationDeliveryStatusPending {
+		t.Fatalf(`Unexpected status after the first failure, got %q`, delivery.Status)
+	}
+
+	if delivery.NextAttemptAt.Before(time.Now()) {
+		t.Fatalf(`The next attempt should be scheduled in the future, got %v`, delivery.NextAttemptAt)
+	}
+
+	if !delivery.CanBeResent() {
+		t.Fatalf(`A delivery being retried should be resendable`)
+	}
+
+	for delivery.Attempts < MaxIntegrationDeliveryAttempts {
+		delivery.MarkAsFailed("connection refused")
+	}
+
+	if delivery.Status != IntegrationDeliveryStatusFailed {
+		t.Fatalf(`Unexpected status after %d failures, got %q`, delivery.Attempts, delivery.Status)
+	}
+
+	delivery.Reset()
+	delivery.MarkAsDelivered()
+	if delivery.Status != IntegrationDeliveryStatusDelivered || delivery.Attempts != 1 || delivery.LastError != "" || delivery.DeliveredAt == nil {
+		t.Fatalf(`Unexpected delivery after a successful attempt: %+v`, delivery)
+	}
+
+	if delivery.CanBeResent() {
+		t.Fatalf(`A delivered event should not be resendable`)
+	}
+}
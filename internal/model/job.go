@@ -3,10 +3,19 @@
 
 package model // import "miniflux.app/v2/internal/model"
 
+// Job states.
+const (
+	JobStateQueued  = "queued"
+	JobStateRunning = "running"
+	JobStateFailed  = "failed"
+)
+
 // Job represents a payload sent to the processing queue.
 type Job struct {
-	UserID int64
-	FeedID int64
+	ID       int64
+	UserID   int64
+	FeedID   int64
+	Attempts int
 }
 
 // JobList represents a list of jobs.
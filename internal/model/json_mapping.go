@@ -0,0 +1,45 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package model // import "miniflux.app/v2/internal/model"
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// JSONMapping contains the path expressions used to generate a feed from an arbitrary JSON document.
+type JSONMapping struct {
+	ItemsPath   string `json:"items_path"`
+	IDPath      string `json:"id_path"`
+	TitlePath   string `json:"title_path"`
+	URLPath     string `json:"url_path"`
+	DatePath    string `json:"date_path"`
+	ContentPath string `json:"content_path"`
+}
+
+// Enabled returns true if the feed is generated from a JSON document.
+func (j JSONMapping) Enabled() bool {
+	return j.ItemsPath != ""
+}
+
+// Value converts the mapping to JSON.
+func (j JSONMapping) Value() (driver.Value, error) {
+	return json.Marshal(j)
+}
+
+// Scan converts raw JSON data.
+func (j *JSONMapping) Scan(src interface{}) error {
+	source, ok := src.([]byte)
+	if !ok {
+		return errors.New("json mapping: unable to assert type of src")
+	}
+
+	if err := json.Unmarshal(source, j); err != nil {
+		return fmt.Errorf("json mapping: %v", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,44 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package model // import "miniflux.app/v2/internal/model"
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// PageWatcherRules contains the CSS selectors used to generate a feed from a HTML page.
+type PageWatcherRules struct {
+	ItemSelector    string `json:"item_selector"`
+	TitleSelector   string `json:"title_selector"`
+	LinkSelector    string `json:"link_selector"`
+	DateSelector    string `json:"date_selector"`
+	ContentSelector string `json:"content_selector"`
+}
+
+// Enabled returns true if the feed is generated from a HTML page.
+func (p PageWatcherRules) Enabled() bool {
+	return p.ItemSelector != ""
+}
+
+// Value converts the rules to JSON.
+func (p PageWatcherRules) Value() (driver.Value, error) {
+	return json.Marshal(p)
+}
+
+// Scan converts raw JSON data.
+func (p *PageWatcherRules) Scan(src interface{}) error {
+	source, ok := src.([]byte)
+	if !ok {
+		return errors.New("page watcher: unable to assert type of src")
+	}
+
+	if err := json.Unmarshal(source, p); err != nil {
+		return fmt.Errorf("page watcher: %v", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,139 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package model // import "miniflux.app/v2/internal/model"
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// PodcastEpisode contains the Podcasting 2.0 metadata of an entry.
+type PodcastEpisode struct {
+	Persons    []PodcastPerson    `json:"persons,omitempty"`
+	Funding    []PodcastFunding   `json:"funding,omitempty"`
+	Soundbites []PodcastSoundbite `json:"soundbites,omitempty"`
+}
+
+// IsEmpty returns true if the feed does not use the Podcasting 2.0 namespace.
+func (p PodcastEpisode) IsEmpty() bool {
+	return len(p.Persons) == 0 && len(p.Funding) == 0 && len(p.Soundbites) == 0
+}
+
+// Value converts the episode metadata to JSON.
+func (p PodcastEpisode) Value() (driver.Value, error) {
+	return json.Marshal(p)
+}
+
+// Scan converts raw JSON data.
+func (p *PodcastEpisode) Scan(src interface{}) error {
+	source, ok := src.([]byte)
+	if !ok {
+		return errors.New("podcast: unable to assert type of src")
+	}
+
+	if err := json.Unmarshal(source, p); err != nil {
+		return fmt.Errorf("podcast: %v", err)
+	}
+
+	return nil
+}
+
+// PodcastPerson is a person involved in the episode, for example a host or a guest.
+type PodcastPerson struct {
+	Name     string `json:"name"`
+	Role     string `json:"role"`
+	Group    string `json:"group"`
+	ImageURL string `json:"image_url,omitempty"`
+	URL      string `json:"url,omitempty"`
+}
+
+// PodcastFunding is a link to donate to the podcast.
+type PodcastFunding struct {
+	URL   string `json:"url"`
+	Title string `json:"title"`
+}
+
+// PodcastSoundbite is a short segment of the episode, times are expressed in seconds.
+type PodcastSoundbite struct {
+	StartTime float64 `json:"start_time"`
+	Duration  float64 `json:"duration"`
+	Title     string  `json:"title"`
+}
+
+// PodcastChapter is a section of an audio or video file, the start time is expressed in seconds.
+type PodcastChapter struct {
+	StartTime float64 `json:"start_time"`
+	Title     string  `json:"title"`
+	URL       string  `json:"url,omitempty"`
+	ImageURL  string  `json:"image_url,omitempty"`
+}
+
+// PodcastChapters is a list of chapters sorted by start time.
+type PodcastChapters []PodcastChapter
+
+// Value converts the chapters to JSON.
+func (p PodcastChapters) Value() (driver.Value, error) {
+	if p == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal(p)
+}
+
+// Scan converts raw JSON data.
+func (p *PodcastChapters) Scan(src interface{}) error {
+	source, ok := src.([]byte)
+	if !ok {
+		return errors.New("podcast chapters: unable to assert type of src")
+	}
+
+	if err := json.Unmarshal(source, p); err != nil {
+		return fmt.Errorf("podcast chapters: %v", err)
+	}
+
+	return nil
+}
+
+// PodcastTranscript is a link to the transcript or the captions of an audio or video file.
+type PodcastTranscript struct {
+	URL      string `json:"url"`
+	MimeType string `json:"mime_type"`
+	Language string `json:"language,omitempty"`
+	Rel      string `json:"rel,omitempty"`
+}
+
+// IsCaptions returns true if the transcript is timed text the media player can show, WebVTT or SubRip.
+func (p PodcastTranscript) IsCaptions() bool {
+	switch p.MimeType {
+	case "text/vtt", "application/x-subrip", "application/srt", "text/srt":
+		return true
+	}
+	return false
+}
+
+// PodcastTranscripts is a list of transcripts.
+type PodcastTranscripts []PodcastTranscript
+
+// Value converts the transcripts to JSON.
+func (p PodcastTranscripts) Value() (driver.Value, error) {
+	if p == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal(p)
+}
+
+// Scan converts raw JSON data.
+func (p *PodcastTranscripts) Scan(src interface{}) error {
+	source, ok := src.([]byte)
+	if !ok {
+		return errors.New("podcast transcripts: unable to assert type of src")
+	}
+
+	if err := json.Unmarshal(source, p); err != nil {
+		return fmt.Errorf("podcast transcripts: %v", err)
+	}
+
+	return nil
+}
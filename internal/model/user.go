@@ -11,31 +11,38 @@ import (
 
 // User represents a user in the system.
 type User struct {
-	ID                     int64      `json:"id"`
-	Username               string     `json:"username"`
-	Password               string     `json:"-"`
-	IsAdmin                bool       `json:"is_admin"`
-	Theme                  string     `json:"theme"`
-	Language               string     `json:"language"`
-	Timezone               string     `json:"timezone"`
-	EntryDirection         string     `json:"entry_sorting_direction"`
-	EntryOrder             string     `json:"entry_sorting_order"`
-	Stylesheet             string     `json:"stylesheet"`
-	GoogleID               string     `json:"google_id"`
-	OpenIDConnectID        string     `json:"openid_connect_id"`
-	EntriesPerPage         int        `json:"entries_per_page"`
-	KeyboardShortcuts      bool       `json:"keyboard_shortcuts"`
-	ShowReadingTime        bool       `json:"show_reading_time"`
-	EntrySwipe             bool       `json:"entry_swipe"`
-	GestureNav             string     `json:"gesture_nav"`
-	LastLoginAt            *time.Time `json:"last_login_at"`
-	DisplayMode            string     `json:"display_mode"`
-	DefaultReadingSpeed    int        `json:"default_reading_speed"`
-	CJKReadingSpeed        int        `json:"cjk_reading_speed"`
-	DefaultHomePage        string     `json:"default_home_page"`
-	CategoriesSortingOrder string     `json:"categories_sorting_order"`
-	MarkReadOnView         bool       `json:"mark_read_on_view"`
-	MediaPlaybackRate      float64    `json:"media_playback_rate"`
+	ID                          int64      `json:"id"`
+	Username                    string     `json:"username"`
+	Password                    string     `json:"-"`
+	IsAdmin                     bool       `json:"is_admin"`
+	Theme                       string     `json:"theme"`
+	Language                    string     `json:"language"`
+	Timezone                    string     `json:"timezone"`
+	EntryDirection              string     `json:"entry_sorting_direction"`
+	EntryOrder                  string     `json:"entry_sorting_order"`
+	Stylesheet                  string     `json:"stylesheet"`
+	GoogleID                    string     `json:"google_id"`
+	OpenIDConnectID             string     `json:"openid_connect_id"`
+	EntriesPerPage              int        `json:"entries_per_page"`
+	KeyboardShortcuts           bool       `json:"keyboard_shortcuts"`
+	ShowReadingTime             bool       `json:"show_reading_time"`
+	EntrySwipe                  bool       `json:"entry_swipe"`
+	GestureNav                  string     `json:"gesture_nav"`
+	LastLoginAt                 *time.Time `json:"last_login_at"`
+	DisplayMode                 string     `json:"display_mode"`
+	DefaultReadingSpeed         int        `json:"default_reading_speed"`
+	CJKReadingSpeed             int        `json:"cjk_reading_speed"`
+	DefaultHomePage             string     `json:"default_home_page"`
+	CategoriesSortingOrder      string     `json:"categories_sorting_order"`
+	MarkReadOnView              bool       `json:"mark_read_on_view"`
+	MediaPlaybackRate           float64    `json:"media_playback_rate"`
+	CollapseSimilarEntries      bool       `json:"collapse_similar_entries"`
+	DuplicateEntriesAction      string     `json:"duplicate_entries_action"`
+	DuplicateEntriesThreshold   float64    `json:"duplicate_entries_threshold"`
+	DuplicateEntriesCategoryIDs []int64    `json:"duplicate_entries_category_ids"`
+	DigestFrequency             string     `json:"digest_frequency"`
+	DigestEntries               string     `json:"digest_entries"`
+	DigestEmail                 string     `json:"digest_email"`
 }
 
 // UserCreationRequest represents the request to create a user.
@@ -49,29 +56,36 @@ type UserCreationRequest struct {
 
 // UserModificationRequest represents the request to update a user.
 type UserModificationRequest struct {
-	Username               *string  `json:"username"`
-	Password               *string  `json:"password"`
-	Theme                  *string  `json:"theme"`
-	Language               *string  `json:"language"`
-	Timezone               *string  `json:"timezone"`
-	EntryDirection         *string  `json:"entry_sorting_direction"`
-	EntryOrder             *string  `json:"entry_sorting_order"`
-	Stylesheet             *string  `json:"stylesheet"`
-	GoogleID               *string  `json:"google_id"`
-	OpenIDConnectID        *string  `json:"openid_connect_id"`
-	EntriesPerPage         *int     `json:"entries_per_page"`
-	IsAdmin                *bool    `json:"is_admin"`
-	KeyboardShortcuts      *bool    `json:"keyboard_shortcuts"`
-	ShowReadingTime        *bool    `json:"show_reading_time"`
-	EntrySwipe             *bool    `json:"entry_swipe"`
-	GestureNav             *string  `json:"gesture_nav"`
-	DisplayMode            *string  `json:"display_mode"`
-	DefaultReadingSpeed    *int     `json:"default_reading_speed"`
-	CJKReadingSpeed        *int     `json:"cjk_reading_speed"`
-	DefaultHomePage        *string  `json:"default_home_page"`
-	CategoriesSortingOrder *string  `json:"categories_sorting_order"`
-	MarkReadOnView         *bool    `json:"mark_read_on_view"`
-	MediaPlaybackRate      *float64 `json:"media_playback_rate"`
+	Username                    *string  `json:"username"`
+	Password                    *string  `json:"password"`
+	Theme                       *string  `json:"theme"`
+	Language                    *string  `json:"language"`
+	Timezone                    *string  `json:"timezone"`
+	EntryDirection              *string  `json:"entry_sorting_direction"`
+	EntryOrder                  *string  `json:"entry_sorting_order"`
+	Stylesheet                  *string  `json:"stylesheet"`
+	GoogleID                    *string  `json:"google_id"`
+	OpenIDConnectID             *string  `json:"openid_connect_id"`
+	EntriesPerPage              *int     `json:"entries_per_page"`
+	IsAdmin                     *bool    `json:"is_admin"`
+	KeyboardShortcuts           *bool    `json:"keyboard_shortcuts"`
+	ShowReadingTime             *bool    `json:"show_reading_time"`
+	EntrySwipe                  *bool    `json:"entry_swipe"`
+	GestureNav                  *string  `json:"gesture_nav"`
+	DisplayMode                 *string  `json:"display_mode"`
+	DefaultReadingSpeed         *int     `json:"default_reading_speed"`
+	CJKReadingSpeed             *int     `json:"cjk_reading_speed"`
+	DefaultHomePage             *string  `json:"default_home_page"`
+	CategoriesSortingOrder      *string  `json:"categories_sorting_order"`
+	MarkReadOnView              *bool    `json:"mark_read_on_view"`
+	MediaPlaybackRate           *float64 `json:"media_playback_rate"`
+	CollapseSimilarEntries      *bool    `json:"collapse_similar_entries"`
+	DuplicateEntriesAction      *string  `json:"duplicate_entries_action"`
+	DuplicateEntriesThreshold   *float64 `json:"duplicate_entries_threshold"`
+	DuplicateEntriesCategoryIDs *[]int64 `json:"duplicate_entries_category_ids"`
+	DigestFrequency             *string  `json:"digest_frequency"`
+	DigestEntries               *string  `json:"digest_entries"`
+	DigestEmail                 *string  `json:"digest_email"`
 }
 
 // Patch updates the User object with the modification request.
@@ -167,6 +181,34 @@ func (u *UserModificationRequest) Patch(user *User) {
 	if u.MediaPlaybackRate != nil {
 		user.MediaPlaybackRate = *u.MediaPlaybackRate
 	}
+
+	if u.CollapseSimilarEntries != nil {
+		user.CollapseSimilarEntries = *u.CollapseSimilarEntries
+	}
+
+	if u.DuplicateEntriesAction != nil {
+		user.DuplicateEntriesAction = *u.DuplicateEntriesAction
+	}
+
+	if u.DuplicateEntriesThreshold != nil {
+		user.DuplicateEntriesThreshold = *u.DuplicateEntriesThreshold
+	}
+
+	if u.DuplicateEntriesCategoryIDs != nil {
+		user.DuplicateEntriesCategoryIDs = *u.DuplicateEntriesCategoryIDs
+	}
+
+	if u.DigestFrequency != nil {
+		user.DigestFrequency = *u.DigestFrequency
+	}
+
+	if u.DigestEntries != nil {
+		user.DigestEntries = *u.DigestEntries
+	}
+
+	if u.DigestEmail != nil {
+		user.DigestEmail = *u.DigestEmail
+	}
 }
 
 // UseTimezone converts last login date to the given timezone.
@@ -0,0 +1,73 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package model // import "miniflux.app/v2/internal/model"
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	// MaxUserTagLength is the maximum number of characters of a user tag.
+	MaxUserTagLength = 100
+
+	// MaxEntryUserTags is the maximum number of user tags of an entry.
+	MaxEntryUserTags = 50
+)
+
+// UserTag is a label added by the user to some entries, unlike the tags provided by the feeds.
+type UserTag struct {
+	Name       string `json:"name"`
+	EntryCount int    `json:"entry_count"`
+}
+
+// UserTags represents a list of user tags.
+type UserTags []*UserTag
+
+// EntryUserTagsRequest represents the request to replace the user tags of an entry.
+type EntryUserTagsRequest struct {
+	Tags []string `json:"tags"`
+}
+
+// UserTagModificationRequest represents the request to rename a user tag.
+// Renaming a tag to the name of another tag merges them.
+type UserTagModificationRequest struct {
+	Name string `json:"name"`
+}
+
+// NormalizeUserTags trims the tags and removes the empty and duplicated ones, keeping the original order.
+func NormalizeUserTags(tags []string) []string {
+	normalizedTags := make([]string, 0, len(tags))
+	seen := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		tag = strings.TrimSpace(tag)
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		normalizedTags = append(normalizedTags, tag)
+	}
+	return normalizedTags
+}
+
+// ParseUserTags splits a comma separated list of tags.
+func ParseUserTags(value string) []string {
+	return NormalizeUserTags(strings.Split(value, ","))
+}
+
+// ValidateUserTags returns an error if there are too many tags or if a tag is too long.
+func ValidateUserTags(tags []string) error {
+	if len(tags) > MaxEntryUserTags {
+		return fmt.Errorf("user tag: an entry cannot have more than %d labels", MaxEntryUserTags)
+	}
+
+	for _, tag := range tags {
+		if utf8.RuneCountInString(tag) > MaxUserTagLength {
+			return fmt.Errorf("user tag: the label %q is longer than %d characters", tag, MaxUserTagLength)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,48 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package model // import "miniflux.app/v2/internal/model"
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestNormalizeUserTags(t *testing.T) {
+	tags := NormalizeUserTags([]string{" go ", "", "Go", "go", "  ", "reading list"})
+	expected := []string{"go", "Go", "reading list"}
+	if !slices.Equal(tags, expected) {
+		t.Errorf(`Unexpected tags, got %q instead of %q`, tags, expected)
+	}
+}
+
+func TestParseUserTags(t *testing.T) {
+	tags := ParseUserTags("later, work,,later ")
+	expected := []string{"later", "work"}
+	if !slices.Equal(tags, expected) {
+		t.Errorf(`Unexpected tags, got %q instead of %q`, tags, expected)
+	}
+
+	if tags := ParseUserTags(""); len(tags) != 0 {
+		t.Errorf(`An empty value should not return any tag, got %q`, tags)
+	}
+}
+
+func TestValidateUserTags(t *testing.T) {
+	if err := ValidateUserTags([]string{"later", strings.Repeat("é", MaxUserTagLength)}); err != nil {
+		t.Errorf(`The tags should be valid: %v`, err)
+	}
+
+	if err := ValidateUserTags([]string{strings.Repeat("a", MaxUserTagLength+1)}); err == nil {
+		t.Error(`A tag longer than the limit should be rejected`)
+	}
+
+	tags := make([]string, MaxEntryUserTags+1)
+	for i := range tags {
+		tags[i] = strings.Repeat("a", i+1)
+	}
+	if err := ValidateUserTags(tags); err == nil {
+		t.Error(`Too many tags should be rejected`)
+	}
+}
@@ -0,0 +1,27 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package model // import "miniflux.app/v2/internal/model"
+
+import "time"
+
+// WebSub subscription states.
+const (
+	WebSubStatePending       = "pending"
+	WebSubStateActive        = "active"
+	WebSubStateDenied        = "denied"
+	WebSubStateUnsubscribing = "unsubscribing"
+)
+
+// WebSubSubscription represents a WebSub subscription of a feed to the hub it advertises.
+type WebSubSubscription struct {
+	FeedID         int64
+	UserID         int64
+	HubURL         string
+	TopicURL       string
+	Secret         string
+	State          string
+	PendingMode    string // Mode of the request sent to the hub and not verified yet, if any.
+	LeaseExpiresAt *time.Time
+	CreatedAt      time.Time
+}
@@ -39,6 +39,13 @@ func (a *Atom10Adapter) BuildFeed(baseURL string) *model.Feed {
 		feed.FeedURL = baseURL
 	}
 
+	// Populate the WebSub hub URL.
+	if hubURL := a.atomFeed.Links.firstLinkWithRelation("hub"); hubURL != "" {
+		if absoluteHubURL, err := urllib.AbsoluteURL(baseURL, hubURL); err == nil {
+			feed.HubURL = absoluteHubURL
+		}
+	}
+
 	// Populate the site URL.
 	siteURL := a.atomFeed.Links.OriginalLink()
 	if siteURL != "" {
@@ -0,0 +1,189 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package fetcher // import "miniflux.app/v2/internal/reader/fetcher"
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// defaultRetryAfter is the cool down applied to a host answering 429 or 503 without Retry-After header.
+	defaultRetryAfter = time.Minute
+	maxRetryAfter     = 24 * time.Hour
+
+	// maxHostWait is the longest delay a request waits for its host, longer delays fail immediately.
+	maxHostWait = 30 * time.Second
+
+	maxTrackedHosts = 10000
+)
+
+// hosts is the rate limiter shared by all the requests of the process, disabled until configured.
+var hosts = newHostLimiter(0, 0)
+
+// SetHostRateLimit configures the number of requests per minute and the burst allowed for each host.
+// A rate of zero disables the limit, the Retry-After delays are always honored.
+func SetHostRateLimit(requestsPerMinute float64, burst int) {
+	hosts.configure(requestsPerMinute/60, float64(max(burst, 1)))
+}
+
+// RateLimitError is returned when a request is not sent because its host asked to slow down.
+type RateLimitError struct {
+	Host       string
+	RetryAfter time.Duration
+}
+
+func (e *RateLimitError) Error() string {
+	return fmt.Sprintf("fetcher: too many requests to %s, retry after %s", e.Host, e.RetryAfter.Round(time.Second))
+}
+
+type hostBucket struct {
+	tokens       float64
+	updatedAt    time.Time
+	blockedUntil time.Time
+}
+
+// hostLimiter is a token bucket per host.
+type hostLimiter struct {
+	mu      sync.Mutex
+	rate    float64
+	burst   float64
+	buckets map[string]*hostBucket
+	now     func() time.Time
+}
+
+func newHostLimiter(rate, burst float64) *hostLimiter {
+	return &hostLimiter{
+		rate:    rate,
+		burst:   burst,
+		buckets: make(map[string]*hostBucket),
+		now:     time.Now,
+	}
+}
+
+func (l *hostLimiter) configure(rate, burst float64) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.rate = rate
+	l.burst = burst
+}
+
+// bucket returns the bucket of the host with its tokens refilled up to now.
+func (l *hostLimiter) bucket(host string, now time.Time) *hostBucket {
+	bucket, found := l.buckets[host]
+	if !found {
+		if len(l.buckets) >= maxTrackedHosts {
+			l.prune(now)
+		}
+		bucket = &hostBucket{tokens: l.burst, updatedAt: now}
+		l.buckets[host] = bucket
+		return bucket
+	}
+
+	bucket.tokens = min(l.burst, bucket.tokens+now.Sub(bucket.updatedAt).Seconds()*l.rate)
+	bucket.updatedAt = now
+	return bucket
+}
+
+// prune forgets the hosts with a full bucket, they behave like unknown hosts.
+func (l *hostLimiter) prune(now time.Time) {
+	for host, bucket := range l.buckets {
+		refilled := bucket.tokens + now.Sub(bucket.updatedAt).Seconds()*l.rate
+		if refilled >= l.burst && now.After(bucket.blockedUntil) {
+			delete(l.buckets, host)
+		}
+	}
+}
+
+// reserve takes a token for the host and returns the delay before the request may be sent.
+// No token is taken when the delay would exceed maxHostWait.
+func (l *hostLimiter) reserve(host string) (time.Duration, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	bucket := l.bucket(host, now)
+
+	var delay time.Duration
+	if bucket.blockedUntil.After(now) {
+		delay = bucket.blockedUntil.Sub(now)
+	}
+
+	if l.rate > 0 && bucket.tokens < 1 {
+		delay = max(delay, time.Duration((1-bucket.tokens)/l.rate*float64(time.Second)))
+	}
+
+	if delay > maxHostWait {
+		return 0, &RateLimitError{Host: host, RetryAfter: delay}
+	}
+
+	if l.rate > 0 {
+		bucket.tokens--
+	}
+	return delay, nil
+}
+
+// block prevents requests to the host for the given duration.
+func (l *hostLimiter) block(host string, duration time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	bucket := l.bucket(host, now)
+	if until := now.Add(duration); until.After(bucket.blockedUntil) {
+		bucket.blockedUntil = until
+	}
+}
+
+// wait blocks until a request to the host may be sent, or until the context is done.
+func (l *hostLimiter) wait(ctx context.Context, host string) error {
+	delay, err := l.reserve(host)
+	if err != nil {
+		return err
+	}
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// observe blocks the host when the response asks to slow down.
+func (l *hostLimiter) observe(host string, response *http.Response) {
+	if retryAfter := retryAfterDelay(response, l.now()); retryAfter > 0 {
+		l.block(host, retryAfter)
+	}
+}
+
+// retryAfterDelay returns how long to wait before the next request, for 429 and 503 responses.
+// The Retry-After header contains either a number of seconds or an HTTP date.
+func retryAfterDelay(response *http.Response, now time.Time) time.Duration {
+	if response == nil || (response.StatusCode != http.StatusTooManyRequests && response.StatusCode != http.StatusServiceUnavailable) {
+		return 0
+	}
+
+	delay := defaultRetryAfter
+	if value := strings.TrimSpace(response.Header.Get("Retry-After")); value != "" {
+		if seconds, err := strconv.Atoi(value); err == nil {
+			delay = time.Duration(seconds) * time.Second
+		} else if date, err := http.ParseTime(value); err == nil {
+			delay = date.Sub(now)
+		}
+	}
+
+	return min(max(delay, time.Second), maxRetryAfter)
+}
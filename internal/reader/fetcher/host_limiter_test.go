@@ -0,0 +1,129 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package fetcher // import "miniflux.app/v2/internal/reader/fetcher"
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func newTestHostLimiter(rate, burst float64, now *time.Time) *hostLimiter {
+	limiter := newHostLimiter(rate, burst)
+	limiter.now = func() time.Time { return *now }
+	return limiter
+}
+
+func TestHostLimiterBurst(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	limiter := newTestHostLimiter(1, 2, &now)
+
+	for i := 0; i < 2; i++ {
+		if delay, err := limiter.reserve("example.org"); err != nil || delay != 0 {
+			t.Fatalf(`Request #%d should not be delayed, got %v, %v`, i, delay, err)
+		}
+	}
+
+	if delay, err := limiter.reserve("example.org"); err != nil || delay != time.Second {
+		t.Fatalf(`Expected a delay of 1s, got %v, %v`, delay, err)
+	}
+
+	if delay, err := limiter.reserve("example.com"); err != nil || delay != 0 {
+		t.Fatalf(`Other hosts should not be delayed, got %v, %v`, delay, err)
+	}
+}
+
+func TestHostLimiterRefill(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	limiter := newTestHostLimiter(1, 1, &now)
+
+	limiter.reserve("example.org")
+	now = now.Add(time.Second)
+
+	if delay, err := limiter.reserve("example.org"); err != nil || delay != 0 {
+		t.Fatalf(`The bucket should be refilled, got %v, %v`, delay, err)
+	}
+}
+
+func TestHostLimiterDisabled(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	limiter := newTestHostLimiter(0, 1, &now)
+
+	for i := 0; i < 100; i++ {
+		if delay, err := limiter.reserve("example.org"); err != nil || delay != 0 {
+			t.Fatalf(`Request #%d should not be delayed, got %v, %v`, i, delay, err)
+		}
+	}
+}
+
+func TestHostLimiterBlock(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	limiter := newTestHostLimiter(0, 1, &now)
+	limiter.block("example.org", time.Hour)
+
+	_, err := limiter.reserve("example.org")
+	var rateLimitErr *RateLimitError
+	if !errors.As(err, &rateLimitErr) {
+		t.Fatalf(`Expected a rate limit error, got %v`, err)
+	}
+
+	if rateLimitErr.RetryAfter != time.Hour {
+		t.Fatalf(`Unexpected retry delay, got %v`, rateLimitErr.RetryAfter)
+	}
+
+	now = now.Add(time.Hour)
+	if _, err := limiter.reserve("example.org"); err != nil {
+		t.Fatalf(`The host should not be blocked anymore, got %v`, err)
+	}
+}
+
+func TestRetryAfterDelay(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	scenarios := []struct {
+		statusCode int
+		header     string
+		expected   time.Duration
+	}{
+		{http.StatusOK, "120", 0},
+		{http.StatusNotFound, "", 0},
+		{http.StatusTooManyRequests, "", defaultRetryAfter},
+		{http.StatusTooManyRequests, "120", 2 * time.Minute},
+		{http.StatusServiceUnavailable, "0", time.Second},
+		{http.StatusServiceUnavailable, "Mon, 01 Jan 2024 00:10:00 GMT", 10 * time.Minute},
+		{http.StatusTooManyRequests, "invalid", defaultRetryAfter},
+		{http.StatusTooManyRequests, "9999999", maxRetryAfter},
+	}
+
+	for _, scenario := range scenarios {
+		response := &http.Response{StatusCode: scenario.statusCode, Header: http.Header{}}
+		if scenario.header != "" {
+			response.Header.Set("Retry-After", scenario.header)
+		}
+
+		if result := retryAfterDelay(response, now); result != scenario.expected {
+			t.Errorf(`Unexpected delay for status %d and header %q, got %v instead of %v`, scenario.statusCode, scenario.header, result, scenario.expected)
+		}
+	}
+}
+
+func TestHostLimiterWaitHonorsContext(t *testing.T) {
+	now := time.Now()
+	limiter := newTestHostLimiter(0, 1, &now)
+	limiter.block("example.org", maxHostWait)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	start := time.Now()
+	if err := limiter.wait(ctx, "example.org"); !errors.Is(err, context.Canceled) {
+		t.Fatalf(`Expected the wait to be cancelled, got %v`, err)
+	}
+
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf(`A cancelled wait should return immediately, took %v`, elapsed)
+	}
+}
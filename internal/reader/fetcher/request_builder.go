@@ -10,6 +10,7 @@ import (
 	"net"
 	"net/http"
 	"net/url"
+	"strings"
 	"time"
 )
 
@@ -184,5 +185,15 @@ func (r *RequestBuilder) ExecuteRequest(requestURL string) (*http.Response, erro
 		slog.Bool("disable_http2", r.disableHTTP2),
 	))
 
-	return client.Do(req)
+	host := strings.ToLower(req.URL.Hostname())
+	if err := hosts.wait(req.Context(), host); err != nil {
+		return nil, err
+	}
+
+	response, err := client.Do(req)
+	if err == nil {
+		hosts.observe(host, response)
+	}
+
+	return response, err
 }
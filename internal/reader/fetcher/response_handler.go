@@ -14,10 +14,46 @@ import (
 	"net/url"
 	"os"
 	"strings"
+	"time"
 
 	"miniflux.app/v2/internal/locale"
 )
 
+// StatusCodeError is returned when the remote server answers with an error status code.
+type StatusCodeError struct {
+	StatusCode int
+	message    string
+}
+
+func (e *StatusCodeError) Error() string {
+	return e.message
+}
+
+func newStatusCodeError(statusCode int, format string, args ...any) error {
+	return &StatusCodeError{StatusCode: statusCode, message: fmt.Sprintf(format, args...)}
+}
+
+// IsTransientError returns true if the request may succeed when retried later:
+// network errors, timeouts, server errors and rate limiting.
+func IsTransientError(err error) bool {
+	var statusCodeErr *StatusCodeError
+	if errors.As(err, &statusCodeErr) {
+		return statusCodeErr.StatusCode == http.StatusTooManyRequests || statusCodeErr.StatusCode >= 500
+	}
+
+	var rateLimitErr *RateLimitError
+	if errors.As(err, &rateLimitErr) {
+		return true
+	}
+
+	if isSSLError(err) {
+		return false
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr) || errors.Is(err, io.EOF)
+}
+
 type ResponseHandler struct {
 	httpResponse *http.Response
 	clientErr    error
@@ -31,6 +67,33 @@ func (r *ResponseHandler) EffectiveURL() string {
 	return r.httpResponse.Request.URL.String()
 }
 
+// PermanentRedirectURL returns the final URL when every hop of the redirect chain
+// was a permanent redirect (301 or 308), or an empty string otherwise.
+func (r *ResponseHandler) PermanentRedirectURL() string {
+	if r.httpResponse == nil || r.httpResponse.Request == nil || r.httpResponse.Request.Response == nil {
+		return ""
+	}
+
+	for redirect := r.httpResponse.Request.Response; redirect != nil; redirect = redirect.Request.Response {
+		if redirect.StatusCode != http.StatusMovedPermanently && redirect.StatusCode != http.StatusPermanentRedirect {
+			return ""
+		}
+		if redirect.Request == nil {
+			break
+		}
+	}
+
+	return r.EffectiveURL()
+}
+
+// StatusCode returns the HTTP status code, or zero when the server did not reply.
+func (r *ResponseHandler) StatusCode() int {
+	if r.httpResponse == nil {
+		return 0
+	}
+	return r.httpResponse.StatusCode
+}
+
 func (r *ResponseHandler) ContentType() string {
 	return r.httpResponse.Header.Get("Content-Type")
 }
@@ -115,9 +178,22 @@ func (r *ResponseHandler) ReadBody(maxBodySize int64) ([]byte, *locale.Localized
 	return buffer, nil
 }
 
+// RetryAfter returns how long the remote server asked to wait before the next request, or zero.
+func (r *ResponseHandler) RetryAfter() time.Duration {
+	var rateLimitErr *RateLimitError
+	if errors.As(r.clientErr, &rateLimitErr) {
+		return rateLimitErr.RetryAfter
+	}
+
+	return retryAfterDelay(r.httpResponse, time.Now())
+}
+
 func (r *ResponseHandler) LocalizedError() *locale.LocalizedErrorWrapper {
 	if r.clientErr != nil {
+		var rateLimitErr *RateLimitError
 		switch {
+		case errors.As(r.clientErr, &rateLimitErr):
+			return locale.NewLocalizedErrorWrapper(fmt.Errorf("fetcher: %w", r.clientErr), "error.http_too_many_requests")
 		case isSSLError(r.clientErr):
 			return locale.NewLocalizedErrorWrapper(fmt.Errorf("fetcher: %w", r.clientErr), "error.tls_error", r.clientErr)
 		case isNetworkError(r.clientErr):
@@ -133,25 +209,25 @@ func (r *ResponseHandler) LocalizedError() *locale.LocalizedErrorWrapper {
 
 	switch r.httpResponse.StatusCode {
 	case http.StatusUnauthorized:
-		return locale.NewLocalizedErrorWrapper(fmt.Errorf("fetcher: access unauthorized (401 status code)"), "error.http_not_authorized")
+		return locale.NewLocalizedErrorWrapper(newStatusCodeError(http.StatusUnauthorized, "fetcher: access unauthorized (401 status code)"), "error.http_not_authorized")
 	case http.StatusForbidden:
-		return locale.NewLocalizedErrorWrapper(fmt.Errorf("fetcher: access forbidden (403 status code)"), "error.http_forbidden")
+		return locale.NewLocalizedErrorWrapper(newStatusCodeError(http.StatusForbidden, "fetcher: access forbidden (403 status code)"), "error.http_forbidden")
 	case http.StatusTooManyRequests:
-		return locale.NewLocalizedErrorWrapper(fmt.Errorf("fetcher: too many requests (429 status code)"), "error.http_too_many_requests")
+		return locale.NewLocalizedErrorWrapper(newStatusCodeError(http.StatusTooManyRequests, "fetcher: too many requests (429 status code)"), "error.http_too_many_requests")
 	case http.StatusNotFound, http.StatusGone:
-		return locale.NewLocalizedErrorWrapper(fmt.Errorf("fetcher: resource not found (%d status code)", r.httpResponse.StatusCode), "error.http_resource_not_found")
+		return locale.NewLocalizedErrorWrapper(newStatusCodeError(r.httpResponse.StatusCode, "fetcher: resource not found (%d status code)", r.httpResponse.StatusCode), "error.http_resource_not_found")
 	case http.StatusInternalServerError:
-		return locale.NewLocalizedErrorWrapper(fmt.Errorf("fetcher: remote server error (%d status code)", r.httpResponse.StatusCode), "error.http_internal_server_error")
+		return locale.NewLocalizedErrorWrapper(newStatusCodeError(r.httpResponse.StatusCode, "fetcher: remote server error (%d status code)", r.httpResponse.StatusCode), "error.http_internal_server_error")
 	case http.StatusBadGateway:
-		return locale.NewLocalizedErrorWrapper(fmt.Errorf("fetcher: bad gateway (%d status code)", r.httpResponse.StatusCode), "error.http_bad_gateway")
+		return locale.NewLocalizedErrorWrapper(newStatusCodeError(r.httpResponse.StatusCode, "fetcher: bad gateway (%d status code)", r.httpResponse.StatusCode), "error.http_bad_gateway")
 	case http.StatusServiceUnavailable:
-		return locale.NewLocalizedErrorWrapper(fmt.Errorf("fetcher: service unavailable (%d status code)", r.httpResponse.StatusCode), "error.http_service_unavailable")
+		return locale.NewLocalizedErrorWrapper(newStatusCodeError(r.httpResponse.StatusCode, "fetcher: service unavailable (%d status code)", r.httpResponse.StatusCode), "error.http_service_unavailable")
 	case http.StatusGatewayTimeout:
-		return locale.NewLocalizedErrorWrapper(fmt.Errorf("fetcher: gateway timeout (%d status code)", r.httpResponse.StatusCode), "error.http_gateway_timeout")
+		return locale.NewLocalizedErrorWrapper(newStatusCodeError(r.httpResponse.StatusCode, "fetcher: gateway timeout (%d status code)", r.httpResponse.StatusCode), "error.http_gateway_timeout")
 	}
 
 	if r.httpResponse.StatusCode >= 400 {
-		return locale.NewLocalizedErrorWrapper(fmt.Errorf("fetcher: unexpected status code (%d status code)", r.httpResponse.StatusCode), "error.http_unexpected_status_code", r.httpResponse.StatusCode)
+		return locale.NewLocalizedErrorWrapper(newStatusCodeError(r.httpResponse.StatusCode, "fetcher: unexpected status code (%d status code)", r.httpResponse.StatusCode), "error.http_unexpected_status_code", r.httpResponse.StatusCode)
 	}
 
 	if r.httpResponse.StatusCode != 304 {
@@ -0,0 +1,86 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package fetcher // import "miniflux.app/v2/internal/reader/fetcher"
+
+import (
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func TestPermanentRedirectURL(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/feed", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok"))
+	})
+	mux.HandleFunc("/moved", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/feed", http.StatusMovedPermanently)
+	})
+	mux.HandleFunc("/moved-twice", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/moved", http.StatusPermanentRedirect)
+	})
+	mux.HandleFunc("/temporary", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/moved", http.StatusFound)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	scenarios := map[string]string{
+		"/feed":        "",
+		"/moved":       server.URL + "/feed",
+		"/moved-twice": server.URL + "/feed",
+		"/temporary":   "",
+	}
+
+	for path, expected := range scenarios {
+		resp, err := http.Get(server.URL + path)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		handler := NewResponseHandler(resp, nil)
+		if result := handler.PermanentRedirectURL(); result != expected {
+			t.Errorf(`Unexpected redirect URL for %q, got %q instead of %q`, path, result, expected)
+		}
+		handler.Close()
+	}
+}
+
+func TestIsTransientError(t *testing.T) {
+	scenarios := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{"server error", newStatusCodeError(http.StatusBadGateway, "bad gateway"), true},
+		{"too many requests", newStatusCodeError(http.StatusTooManyRequests, "too many requests"), true},
+		{"not found", newStatusCodeError(http.StatusNotFound, "not found"), false},
+		{"forbidden", newStatusCodeError(http.StatusForbidden, "forbidden"), false},
+		{"rate limited", fmt.Errorf("fetcher: %w", &RateLimitError{Host: "example.org"}), true},
+		{"network error", fmt.Errorf("fetcher: %w", &url.Error{Op: "Get", URL: "https://example.org", Err: errors.New("connection refused")}), true},
+		{"empty response", fmt.Errorf("fetcher: %w", io.EOF), true},
+		{"certificate error", fmt.Errorf("fetcher: %w", &url.Error{Op: "Get", URL: "https://example.org", Err: x509.UnknownAuthorityError{}}), false},
+		{"parsing error", errors.New("parser: unable to detect feed format"), false},
+	}
+
+	for _, scenario := range scenarios {
+		if result := IsTransientError(scenario.err); result != scenario.expected {
+			t.Errorf(`Unexpected result for %s, got %v instead of %v`, scenario.name, result, scenario.expected)
+		}
+	}
+}
+
+func TestLocalizedErrorKeepsStatusCode(t *testing.T) {
+	handler := NewResponseHandler(&http.Response{StatusCode: http.StatusServiceUnavailable}, nil)
+
+	var statusCodeErr *StatusCodeError
+	if !errors.As(handler.LocalizedError().Error(), &statusCodeErr) || statusCodeErr.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf(`The localized error should wrap the status code`)
+	}
+}
@@ -0,0 +1,299 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package fetcher // import "miniflux.app/v2/internal/reader/fetcher"
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	maxRobotsTxtSize = 500 * 1024
+
+	// robotsTxtErrorCacheDuration is used when robots.txt is unreachable, all the pages are disallowed meanwhile.
+	robotsTxtErrorCacheDuration = time.Hour
+)
+
+var ErrDisallowedByRobotsTxt = errors.New("fetcher: disallowed by robots.txt")
+
+// robots is the robots.txt cache shared by all the requests of the process, disabled until configured.
+var robots = &robotsCache{entries: make(map[string]*robotsCacheEntry)}
+
+// SetRobotsTxtPolicy enables the robots.txt checks and sets how long a robots.txt file is kept in cache.
+func SetRobotsTxtPolicy(enabled bool, cacheDuration time.Duration) {
+	robots.mu.Lock()
+	defer robots.mu.Unlock()
+	robots.enabled = enabled
+	robots.cacheDuration = cacheDuration
+}
+
+type robotsCacheEntry struct {
+	file      *robotsTxt
+	expiresAt time.Time
+}
+
+type robotsCache struct {
+	mu            sync.Mutex
+	enabled       bool
+	cacheDuration time.Duration
+	entries       map[string]*robotsCacheEntry
+}
+
+func (c *robotsCache) get(key string) (file *robotsTxt, enabled, found bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.enabled {
+		return nil, false, false
+	}
+
+	entry, found := c.entries[key]
+	if !found || time.Now().After(entry.expiresAt) {
+		return nil, true, false
+	}
+	return entry.file, true, true
+}
+
+func (c *robotsCache) set(key string, file *robotsTxt, unreachable bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	if len(c.entries) >= maxTrackedHosts {
+		for entryKey, entry := range c.entries {
+			if now.After(entry.expiresAt) {
+				delete(c.entries, entryKey)
+			}
+		}
+	}
+
+	duration := c.cacheDuration
+	if unreachable {
+		duration = min(duration, robotsTxtErrorCacheDuration)
+	}
+	c.entries[key] = &robotsCacheEntry{file: file, expiresAt: now.Add(duration)}
+}
+
+// CheckRobotsTxt returns ErrDisallowedByRobotsTxt when the robots.txt file of the website
+// does not allow the configured User-Agent to fetch the page.
+func (r *RequestBuilder) CheckRobotsTxt(pageURL string) error {
+	parsedURL, err := url.Parse(pageURL)
+	if err != nil || parsedURL.Host == "" {
+		return nil
+	}
+
+	key := strings.ToLower(parsedURL.Scheme + "://" + parsedURL.Host)
+	file, enabled, found := robots.get(key)
+	if !enabled {
+		return nil
+	}
+
+	if !found {
+		var unreachable bool
+		file, unreachable, err = r.fetchRobotsTxt(key + "/robots.txt")
+		if err != nil {
+			return err
+		}
+		robots.set(key, file, unreachable)
+	}
+
+	if !file.isAllowed(r.headers.Get("User-Agent"), parsedURL.EscapedPath(), parsedURL.RawQuery) {
+		return fmt.Errorf("%w: %s", ErrDisallowedByRobotsTxt, pageURL)
+	}
+
+	return nil
+}
+
+// fetchRobotsTxt downloads and parses a robots.txt file, following RFC 9309:
+// a missing file allows everything, an unreachable file disallows everything.
+// Only rate limit errors are returned, the file is not cached in this case.
+func (r *RequestBuilder) fetchRobotsTxt(robotsURL string) (file *robotsTxt, unreachable bool, err error) {
+	builder := *r
+	builder.headers = r.headers.Clone()
+	builder.headers.Del("If-None-Match")
+	builder.headers.Del("If-Modified-Since")
+
+	response, err := builder.ExecuteRequest(robotsURL)
+	var rateLimitErr *RateLimitError
+	if errors.As(err, &rateLimitErr) {
+		return nil, false, err
+	}
+	if err != nil {
+		slog.Debug("Unable to fetch robots.txt",
+			slog.String("robots_url", robotsURL),
+			slog.Any("error", err),
+		)
+		return &robotsTxt{disallowAll: true}, true, nil
+	}
+	defer response.Body.Close()
+
+	switch {
+	case response.StatusCode == http.StatusTooManyRequests || response.StatusCode >= 500:
+		return &robotsTxt{disallowAll: true}, true, nil
+	case response.StatusCode >= 400:
+		return &robotsTxt{}, false, nil
+	case response.StatusCode >= 300:
+		// Redirects are followed by the HTTP client, any other 3xx status code is considered as a missing file.
+		return &robotsTxt{}, false, nil
+	}
+
+	return parseRobotsTxt(io.LimitReader(response.Body, maxRobotsTxtSize)), false, nil
+}
+
+type robotsRule struct {
+	allow   bool
+	pattern string
+}
+
+type robotsGroup struct {
+	agents []string
+	rules  []robotsRule
+}
+
+type robotsTxt struct {
+	disallowAll bool
+	groups      []*robotsGroup
+}
+
+func parseRobotsTxt(r io.Reader) *robotsTxt {
+	file := &robotsTxt{}
+
+	var group *robotsGroup
+	lastLineWasAgent := false
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line, _, _ := strings.Cut(scanner.Text(), "#")
+		key, value, found := strings.Cut(line, ":")
+		if !found {
+			continue
+		}
+
+		key = strings.ToLower(strings.TrimSpace(key))
+		value = strings.TrimSpace(value)
+
+		switch key {
+		case "user-agent":
+			if !lastLineWasAgent {
+				group = &robotsGroup{}
+				file.groups = append(file.groups, group)
+			}
+			group.agents = append(group.agents, strings.ToLower(value))
+			lastLineWasAgent = true
+		case "allow", "disallow":
+			lastLineWasAgent = false
+			if group == nil || value == "" {
+				continue
+			}
+			group.rules = append(group.rules, robotsRule{allow: key == "allow", pattern: value})
+		default:
+			lastLineWasAgent = false
+		}
+	}
+
+	return file
+}
+
+// rulesForAgent returns the rules of the groups matching the User-Agent, the most specific agent name wins.
+func (f *robotsTxt) rulesForAgent(userAgent string) []robotsRule {
+	userAgent = strings.ToLower(userAgent)
+
+	var matchedAgent string
+	for _, group := range f.groups {
+		for _, agent := range group.agents {
+			if agent != "*" && len(agent) > len(matchedAgent) && strings.Contains(userAgent, agent) {
+				matchedAgent = agent
+			}
+		}
+	}
+
+	if matchedAgent == "" {
+		matchedAgent = "*"
+	}
+
+	var rules []robotsRule
+	for _, group := range f.groups {
+		for _, agent := range group.agents {
+			if agent == matchedAgent {
+				rules = append(rules, group.rules...)
+				break
+			}
+		}
+	}
+
+	return rules
+}
+
+// isAllowed applies the longest matching rule, allow rules win over disallow rules of the same length.
+func (f *robotsTxt) isAllowed(userAgent, path, query string) bool {
+	if f.disallowAll {
+		return false
+	}
+
+	if path == "" {
+		path = "/"
+	}
+
+	if path == "/robots.txt" {
+		return true
+	}
+
+	if query != "" {
+		path += "?" + query
+	}
+
+	allowed := true
+	matchedLength := -1
+	for _, rule := range f.rulesForAgent(userAgent) {
+		if !matchRobotsPattern(rule.pattern, path) {
+			continue
+		}
+
+		if len(rule.pattern) > matchedLength || (len(rule.pattern) == matchedLength && rule.allow) {
+			allowed = rule.allow
+			matchedLength = len(rule.pattern)
+		}
+	}
+
+	return allowed
+}
+
+// matchRobotsPattern matches a path against a robots.txt pattern,
+// where "*" matches any sequence of characters and a trailing "$" anchors the end of the path.
+func matchRobotsPattern(pattern, path string) bool {
+	anchored := strings.HasSuffix(pattern, "$")
+	pattern = strings.TrimSuffix(pattern, "$")
+
+	parts := strings.Split(pattern, "*")
+	if !strings.HasPrefix(path, parts[0]) {
+		return false
+	}
+
+	if len(parts) == 1 {
+		return !anchored || path == parts[0]
+	}
+
+	position := len(parts[0])
+	for _, part := range parts[1 : len(parts)-1] {
+		index := strings.Index(path[position:], part)
+		if index < 0 {
+			return false
+		}
+		position += index + len(part)
+	}
+
+	last := parts[len(parts)-1]
+	if anchored {
+		return len(path)-position >= len(last) && strings.HasSuffix(path, last)
+	}
+	return strings.Contains(path[position:], last)
+}
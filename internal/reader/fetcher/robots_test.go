@@ -0,0 +1,105 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package fetcher // import "miniflux.app/v2/internal/reader/fetcher"
+
+import (
+	"strings"
+	"testing"
+)
+
+const testUserAgent = "Mozilla/5.0 (compatible; Miniflux/2.1.3; +https://miniflux.app)"
+
+func TestRobotsTxtWildcardGroup(t *testing.T) {
+	file := parseRobotsTxt(strings.NewReader(`
+# Comment
+User-agent: *
+Disallow: /private/
+Allow: /private/public.html
+Disallow: /*.pdf$
+`))
+
+	scenarios := map[string]bool{
+		"/":                     true,
+		"/articles/1":           true,
+		"/private/":             false,
+		"/private/page.html":    false,
+		"/private/public.html":  true,
+		"/files/document.pdf":   false,
+		"/files/document.pdf?x": true,
+		"/robots.txt":           true,
+	}
+
+	for path, expected := range scenarios {
+		path, query, _ := strings.Cut(path, "?")
+		if result := file.isAllowed(testUserAgent, path, query); result != expected {
+			t.Errorf(`Unexpected result for %q, got %v instead of %v`, path, result, expected)
+		}
+	}
+}
+
+func TestRobotsTxtSpecificGroup(t *testing.T) {
+	file := parseRobotsTxt(strings.NewReader(`
+User-agent: *
+Disallow: /
+
+User-agent: googlebot
+User-agent: miniflux
+Disallow: /drafts
+`))
+
+	if !file.isAllowed(testUserAgent, "/articles/1", "") {
+		t.Error(`The Miniflux group should allow /articles/1`)
+	}
+
+	if file.isAllowed(testUserAgent, "/drafts/1", "") {
+		t.Error(`The Miniflux group should disallow /drafts/1`)
+	}
+
+	if file.isAllowed("SomeBot/1.0", "/articles/1", "") {
+		t.Error(`The wildcard group should disallow everything`)
+	}
+}
+
+func TestRobotsTxtEmptyDisallow(t *testing.T) {
+	file := parseRobotsTxt(strings.NewReader("User-agent: *\nDisallow:\n"))
+
+	if !file.isAllowed(testUserAgent, "/articles/1", "") {
+		t.Error(`An empty Disallow rule should allow everything`)
+	}
+}
+
+func TestRobotsTxtDisallowAll(t *testing.T) {
+	file := &robotsTxt{disallowAll: true}
+
+	if file.isAllowed(testUserAgent, "/articles/1", "") {
+		t.Error(`An unreachable robots.txt should disallow everything`)
+	}
+}
+
+func TestMatchRobotsPattern(t *testing.T) {
+	scenarios := []struct {
+		pattern  string
+		path     string
+		expected bool
+	}{
+		{"/", "/anything", true},
+		{"/fish", "/fish.html", true},
+		{"/fish", "/Fish.html", false},
+		{"/fish$", "/fish", true},
+		{"/fish$", "/fish/", false},
+		{"/*.php", "/folder/filename.php?parameters", true},
+		{"/*.php$", "/filename.php", true},
+		{"/*.php$", "/filename.php?parameters", false},
+		{"/fish*.php", "/fishheads/catfish.php", true},
+		{"/fish*.php", "/Fish.PHP", false},
+		{"/a*b*c$", "/abxc", true},
+		{"/a*b*c$", "/abcx", false},
+	}
+
+	for _, scenario := range scenarios {
+		if result := matchRobotsPattern(scenario.pattern, scenario.path); result != scenario.expected {
+			t.Errorf(`Unexpected result for pattern %q and path %q, got %v instead of %v`, scenario.pattern, scenario.path, result, scenario.expected)
+		}
+	}
+}
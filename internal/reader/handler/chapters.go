@@ -0,0 +1,78 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package handler // import "miniflux.app/v2/internal/reader/handler"
+
+import (
+	"log/slog"
+
+	"miniflux.app/v2/internal/config"
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/reader/fetcher"
+	"miniflux.app/v2/internal/reader/podcast"
+	"miniflux.app/v2/internal/storage"
+)
+
+// maxChaptersPerRefresh limits the chapters files downloaded after a refresh of a feed,
+// the remaining ones are downloaded after the next refreshes.
+const maxChaptersPerRefresh = 5
+
+// updateEnclosureChapters downloads in the background the Podcasting 2.0 chapters of the feed enclosures not checked yet.
+func updateEnclosureChapters(store *storage.Storage, feed *model.Feed) {
+	enclosures, err := store.EnclosuresWithUncheckedChapters(feed.UserID, feed.ID, maxChaptersPerRefresh)
+	if err != nil {
+		slog.Error("Unable to fetch the enclosures with unchecked chapters",
+			slog.Int64("user_id", feed.UserID),
+			slog.Int64("feed_id", feed.ID),
+			slog.Any("error", err),
+		)
+		return
+	}
+
+	if len(enclosures) == 0 {
+		return
+	}
+
+	go func() {
+		for _, enclosure := range enclosures {
+			// Files which can't be downloaded are not tried again, the enclosure is kept without chapters.
+			chapters, err := fetchPodcastChapters(feed, enclosure.ChaptersURL)
+			if err != nil {
+				slog.Warn("Unable to fetch podcast chapters",
+					slog.Int64("enclosure_id", enclosure.ID),
+					slog.String("chapters_url", enclosure.ChaptersURL),
+					slog.Int64("feed_id", feed.ID),
+					slog.String("feed_url", feed.FeedURL),
+					slog.Any("error", err),
+				)
+			}
+
+			if err := store.UpdateEnclosureChapters(enclosure.ID, chapters); err != nil {
+				slog.Error("Unable to store podcast chapters",
+					slog.Int64("enclosure_id", enclosure.ID),
+					slog.Any("error", err),
+				)
+				return
+			}
+		}
+	}()
+}
+
+func fetchPodcastChapters(feed *model.Feed, chaptersURL string) (model.PodcastChapters, error) {
+	requestBuilder := fetcher.NewRequestBuilder()
+	requestBuilder.WithUserAgent(feed.UserAgent, config.Opts.HTTPClientUserAgent())
+	requestBuilder.WithTimeout(config.Opts.HTTPClientTimeout())
+	requestBuilder.WithProxy(config.Opts.HTTPClientProxy())
+	requestBuilder.UseProxy(feed.FetchViaProxy)
+	requestBuilder.IgnoreTLSErrors(feed.AllowSelfSignedCertificates)
+	requestBuilder.DisableHTTP2(feed.DisableHTTP2)
+
+	responseHandler := fetcher.NewResponseHandler(requestBuilder.ExecuteRequest(chaptersURL))
+	defer responseHandler.Close()
+
+	if localizedError := responseHandler.LocalizedError(); localizedError != nil {
+		return nil, localizedError.Error()
+	}
+
+	return podcast.ParseChapters(responseHandler.EffectiveURL(), responseHandler.Body(config.Opts.HTTPClientMaxBodySize()))
+}
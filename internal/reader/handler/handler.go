@@ -7,6 +7,9 @@ import (
 	"bytes"
 	"errors"
 	"log/slog"
+	"math"
+	"strconv"
+	"time"
 
 	"miniflux.app/v2/internal/config"
 	"miniflux.app/v2/internal/integration"
@@ -14,9 +17,14 @@ import (
 	"miniflux.app/v2/internal/model"
 	"miniflux.app/v2/internal/reader/fetcher"
 	"miniflux.app/v2/internal/reader/icon"
+	"miniflux.app/v2/internal/reader/jsonmapping"
 	"miniflux.app/v2/internal/reader/parser"
 	"miniflux.app/v2/internal/reader/processor"
+	"miniflux.app/v2/internal/reader/scraper"
+	"miniflux.app/v2/internal/similarity"
 	"miniflux.app/v2/internal/storage"
+
+	"golang.org/x/net/html/charset"
 )
 
 var (
@@ -77,6 +85,9 @@ func CreateFeedFromSubscriptionDiscovery(store *storage.Storage, userID int64, f
 		return nil, locale.NewLocalizedErrorWrapper(storeErr, "error.database_error", storeErr)
 	}
 
+	applyEntryRuleActions(store, userID, subscription.Entries)
+	updateEnclosureChapters(store, subscription)
+
 	slog.Debug("Created feed",
 		slog.Int64("user_id", userID),
 		slog.Int64("feed_id", subscription.ID),
@@ -148,9 +159,9 @@ func CreateFeed(store *storage.Storage, userID int64, feedCreationRequest *model
 		return nil, locale.NewLocalizedErrorWrapper(ErrDuplicatedFeed, "error.duplicated_feed")
 	}
 
-	subscription, parseErr := parser.ParseFeed(responseHandler.EffectiveURL(), bytes.NewReader(responseBody))
-	if parseErr != nil {
-		return nil, locale.NewLocalizedErrorWrapper(parseErr, "error.unable_to_parse_feed", parseErr)
+	subscription, localizedError := parseFeed(responseHandler.EffectiveURL(), responseHandler.ContentType(), responseBody, &feedCreationRequest.PageWatcherRules, &feedCreationRequest.JSONMapping)
+	if localizedError != nil {
+		return nil, localizedError
 	}
 
 	subscription.UserID = userID
@@ -163,6 +174,9 @@ func CreateFeed(store *storage.Storage, userID int64, feedCreationRequest *model
 	subscription.IgnoreHTTPCache = feedCreationRequest.IgnoreHTTPCache
 	subscription.AllowSelfSignedCertificates = feedCreationRequest.AllowSelfSignedCertificates
 	subscription.DisableHTTP2 = feedCreationRequest.DisableHTTP2
+	subscription.PageWatcherRules = feedCreationRequest.PageWatcherRules
+	subscription.JSONMapping = feedCreationRequest.JSONMapping
+	subscription.MarkUnreadOnChange = feedCreationRequest.MarkUnreadOnChange
 	subscription.FetchViaProxy = feedCreationRequest.FetchViaProxy
 	subscription.ScraperRules = feedCreationRequest.ScraperRules
 	subscription.RewriteRules = feedCreationRequest.RewriteRules
@@ -181,6 +195,9 @@ func CreateFeed(store *storage.Storage, userID int64, feedCreationRequest *model
 		return nil, locale.NewLocalizedErrorWrapper(storeErr, "error.database_error", storeErr)
 	}
 
+	applyEntryRuleActions(store, userID, subscription.Entries)
+	updateEnclosureChapters(store, subscription)
+
 	slog.Debug("Created feed",
 		slog.Int64("user_id", userID),
 		slog.Int64("feed_id", subscription.ID),
@@ -199,6 +216,18 @@ func CreateFeed(store *storage.Storage, userID int64, feedCreationRequest *model
 
 // RefreshFeed refreshes a feed.
 func RefreshFeed(store *storage.Storage, userID, feedID int64, forceRefresh bool) *locale.LocalizedErrorWrapper {
+	refreshErr, _ := refreshFeed(store, userID, feedID, forceRefresh, true)
+	return refreshErr
+}
+
+// RefreshFeedAttempt refreshes a feed from a background job which may be retried.
+// Transient fetch errors are only recorded on the feed on the last attempt,
+// the delay asked by the remote server before the next request is returned with them.
+func RefreshFeedAttempt(store *storage.Storage, userID, feedID int64, lastAttempt bool) (*locale.LocalizedErrorWrapper, time.Duration) {
+	return refreshFeed(store, userID, feedID, false, lastAttempt)
+}
+
+func refreshFeed(store *storage.Storage, userID, feedID int64, forceRefresh, lastAttempt bool) (refreshErr *locale.LocalizedErrorWrapper, retryAfter time.Duration) {
 	slog.Debug("Begin feed refresh process",
 		slog.Int64("user_id", userID),
 		slog.Int64("feed_id", feedID),
@@ -207,25 +236,39 @@ func RefreshFeed(store *storage.Storage, userID, feedID int64, forceRefresh bool
 
 	user, storeErr := store.UserByID(userID)
 	if storeErr != nil {
-		return locale.NewLocalizedErrorWrapper(storeErr, "error.database_error", storeErr)
+		return locale.NewLocalizedErrorWrapper(storeErr, "error.database_error", storeErr), 0
 	}
 
 	originalFeed, storeErr := store.FeedByID(userID, feedID)
 	if storeErr != nil {
-		return locale.NewLocalizedErrorWrapper(storeErr, "error.database_error", storeErr)
+		return locale.NewLocalizedErrorWrapper(storeErr, "error.database_error", storeErr), 0
 	}
 
 	if originalFeed == nil {
-		return locale.NewLocalizedErrorWrapper(ErrFeedNotFound, "error.feed_not_found")
+		return locale.NewLocalizedErrorWrapper(ErrFeedNotFound, "error.feed_not_found"), 0
 	}
 
+	diagnostic := &model.FeedDiagnostic{UserID: userID, FeedID: feedID}
+	defer func() {
+		if refreshErr != nil {
+			diagnostic.ErrorMsg = refreshErr.Translate(user.Language)
+		}
+		if err := store.CreateFeedDiagnostic(diagnostic); err != nil {
+			slog.Error("Unable to save feed diagnostic",
+				slog.Int64("user_id", userID),
+				slog.Int64("feed_id", feedID),
+				slog.Any("error", err),
+			)
+		}
+	}()
+
 	weeklyEntryCount := 0
 	newTTL := 0
 	if config.Opts.PollingScheduler() == model.SchedulerEntryFrequency {
 		var weeklyCountErr error
 		weeklyEntryCount, weeklyCountErr = store.WeeklyFeedEntryCount(userID, feedID)
 		if weeklyCountErr != nil {
-			return locale.NewLocalizedErrorWrapper(weeklyCountErr, "error.database_error", weeklyCountErr)
+			return locale.NewLocalizedErrorWrapper(weeklyCountErr, "error.database_error", weeklyCountErr), 0
 		}
 	}
 
@@ -248,23 +291,39 @@ func RefreshFeed(store *storage.Storage, userID, feedID int64, forceRefresh bool
 		requestBuilder.WithLastModified(originalFeed.LastModifiedHeader)
 	}
 
+	fetchStartTime := time.Now()
 	responseHandler := fetcher.NewResponseHandler(requestBuilder.ExecuteRequest(originalFeed.FeedURL))
 	defer responseHandler.Close()
 
+	diagnostic.StatusCode = responseHandler.StatusCode()
+	diagnostic.Duration = time.Since(fetchStartTime).Milliseconds()
+
 	if localizedError := responseHandler.LocalizedError(); localizedError != nil {
 		slog.Warn("Unable to fetch feed", slog.String("feed_url", originalFeed.FeedURL), slog.Any("error", localizedError.Error()))
+		retryAfter = responseHandler.RetryAfter()
+		if !lastAttempt && fetcher.IsTransientError(localizedError.Error()) {
+			// The error is only counted once the job gives up.
+			return localizedError, retryAfter
+		}
+		if retryAfter > 0 {
+			// Do not check the feed again before the remote server allows it.
+			originalFeed.ScheduleNextCheck(weeklyEntryCount, int(math.Ceil(retryAfter.Minutes())))
+		}
 		originalFeed.WithTranslatedErrorMessage(localizedError.Translate(user.Language))
 		store.UpdateFeedError(originalFeed)
-		return localizedError
+		return localizedError, retryAfter
 	}
 
 	if store.AnotherFeedURLExists(userID, originalFeed.ID, responseHandler.EffectiveURL()) {
 		localizedError := locale.NewLocalizedErrorWrapper(ErrDuplicatedFeed, "error.duplicated_feed")
 		originalFeed.WithTranslatedErrorMessage(localizedError.Translate(user.Language))
 		store.UpdateFeedError(originalFeed)
-		return localizedError
+		return localizedError, 0
 	}
 
+	checkPermanentRedirect(store, originalFeed, responseHandler.PermanentRedirectURL())
+
+	var crawlerErr *locale.LocalizedErrorWrapper
 	if ignoreHTTPCache || responseHandler.IsModified(originalFeed.EtagHeader, originalFeed.LastModifiedHeader) {
 		slog.Debug("Feed modified",
 			slog.Int64("user_id", userID),
@@ -272,22 +331,18 @@ func RefreshFeed(store *storage.Storage, userID, feedID int64, forceRefresh bool
 		)
 
 		responseBody, localizedError := responseHandler.ReadBody(config.Opts.HTTPClientMaxBodySize())
+		diagnostic.Duration = time.Since(fetchStartTime).Milliseconds()
 		if localizedError != nil {
 			slog.Warn("Unable to fetch feed", slog.String("feed_url", originalFeed.FeedURL), slog.Any("error", localizedError.Error()))
-			return localizedError
+			return localizedError, 0
 		}
+		diagnostic.ResponseSize = int64(len(responseBody))
 
-		updatedFeed, parseErr := parser.ParseFeed(responseHandler.EffectiveURL(), bytes.NewReader(responseBody))
-		if parseErr != nil {
-			localizedError := locale.NewLocalizedErrorWrapper(parseErr, "error.unable_to_parse_feed", parseErr)
-
-			if errors.Is(parseErr, parser.ErrFeedFormatNotDetected) {
-				localizedError = locale.NewLocalizedErrorWrapper(parseErr, "error.feed_format_not_detected", parseErr)
-			}
-
+		updatedFeed, localizedError := parseFeed(responseHandler.EffectiveURL(), responseHandler.ContentType(), responseBody, &originalFeed.PageWatcherRules, &originalFeed.JSONMapping)
+		if localizedError != nil {
 			originalFeed.WithTranslatedErrorMessage(localizedError.Translate(user.Language))
 			store.UpdateFeedError(originalFeed)
-			return localizedError
+			return localizedError, 0
 		}
 
 		// If the feed has a TTL defined, we use it to make sure we don't check it too often.
@@ -302,7 +357,7 @@ func RefreshFeed(store *storage.Storage, userID, feedID int64, forceRefresh bool
 		)
 
 		originalFeed.Entries = updatedFeed.Entries
-		processor.ProcessFeedEntries(store, originalFeed, user, forceRefresh)
+		crawlerErr = processor.ProcessFeedEntries(store, originalFeed, user, forceRefresh)
 
 		// We don't update existing entries when the crawler is enabled (we crawl only inexisting entries). Unless it is forced to refresh
 		updateExistingEntries := forceRefresh || !originalFeed.Crawler
@@ -311,19 +366,11 @@ func RefreshFeed(store *storage.Storage, userID, feedID int64, forceRefresh bool
 			localizedError := locale.NewLocalizedErrorWrapper(storeErr, "error.database_error", storeErr)
 			originalFeed.WithTranslatedErrorMessage(localizedError.Translate(user.Language))
 			store.UpdateFeedError(originalFeed)
-			return localizedError
+			return localizedError, 0
 		}
 
-		userIntegrations, intErr := store.Integration(userID)
-		if intErr != nil {
-			slog.Error("Fetching integrations failed; the refresh process will go on, but no integrations will run this time",
-				slog.Int64("user_id", userID),
-				slog.Int64("feed_id", feedID),
-				slog.Any("error", intErr),
-			)
-		} else if userIntegrations != nil && len(newEntries) > 0 {
-			go integration.PushEntries(originalFeed, newEntries, userIntegrations)
-		}
+		diagnostic.NewEntries = len(newEntries)
+		processNewEntries(store, user, originalFeed, newEntries)
 
 		// We update caching headers only if the feed has been modified,
 		// because some websites don't return the same headers when replying with a 304.
@@ -337,23 +384,243 @@ func RefreshFeed(store *storage.Storage, userID, feedID int64, forceRefresh bool
 			originalFeed.SiteURL,
 			updatedFeed.IconURL,
 		)
+
+		if config.Opts.WebSub() {
+			updateWebSubSubscription(store, originalFeed, updatedFeed.HubURL, updatedFeed.FeedURL)
+		}
 	} else {
 		slog.Debug("Feed not modified",
 			slog.Int64("user_id", userID),
 			slog.Int64("feed_id", feedID),
 		)
+		diagnostic.NotModified = true
 	}
 
 	originalFeed.ResetErrorCounter()
+	if crawlerErr != nil {
+		// The feed itself is fine, the error is reported without counting toward the parsing error limit.
+		originalFeed.ParsingErrorMsg = crawlerErr.Translate(user.Language)
+	}
 
 	if storeErr := store.UpdateFeed(originalFeed); storeErr != nil {
 		localizedError := locale.NewLocalizedErrorWrapper(storeErr, "error.database_error", storeErr)
 		originalFeed.WithTranslatedErrorMessage(localizedError.Translate(user.Language))
 		store.UpdateFeedError(originalFeed)
-		return localizedError
+		return localizedError, 0
+	}
+
+	return nil, 0
+}
+
+// parseFeed parses a feed document, or generates the feed from a HTML page when page watcher rules are defined
+// and from a JSON document when a JSON mapping is defined.
+func parseFeed(baseURL, contentType string, body []byte, pageWatcherRules *model.PageWatcherRules, jsonMapping *model.JSONMapping) (*model.Feed, *locale.LocalizedErrorWrapper) {
+	if pageWatcherRules.Enabled() {
+		pageReader, err := charset.NewReader(bytes.NewReader(body), contentType)
+		if err != nil {
+			return nil, locale.NewLocalizedErrorWrapper(err, "error.unable_to_parse_feed", err)
+		}
+
+		feed, err := scraper.ParsePage(baseURL, pageReader, pageWatcherRules)
+		if errors.Is(err, scraper.ErrNoPageItems) {
+			return nil, locale.NewLocalizedErrorWrapper(err, "error.page_watcher_no_items")
+		}
+		if err != nil {
+			return nil, locale.NewLocalizedErrorWrapper(err, "error.unable_to_parse_feed", err)
+		}
+		return feed, nil
 	}
 
-	return nil
+	if jsonMapping.Enabled() {
+		feed, err := parser.ParseJSONMapping(baseURL, bytes.NewReader(body), jsonMapping)
+		if errors.Is(err, jsonmapping.ErrNoItems) {
+			return nil, locale.NewLocalizedErrorWrapper(err, "error.json_mapping_no_items")
+		}
+		if err != nil {
+			return nil, locale.NewLocalizedErrorWrapper(err, "error.unable_to_parse_feed", err)
+		}
+		return feed, nil
+	}
+
+	feed, err := parser.ParseFeed(baseURL, bytes.NewReader(body))
+	if errors.Is(err, parser.ErrFeedFormatNotDetected) {
+		return nil, locale.NewLocalizedErrorWrapper(err, "error.feed_format_not_detected", err)
+	}
+	if err != nil {
+		return nil, locale.NewLocalizedErrorWrapper(err, "error.unable_to_parse_feed", err)
+	}
+	return feed, nil
+}
+
+// processNewEntries applies the entry rules, indexes the new entries of a feed for the similarity features and pushes them to the integrations.
+func processNewEntries(store *storage.Storage, user *model.User, feed *model.Feed, newEntries model.Entries) {
+	applyEntryRuleActions(store, user.ID, newEntries)
+	applyMoveToCategoryAction(store, feed, newEntries)
+	updateEnclosureChapters(store, feed)
+
+	if err := similarity.AddDocuments(store, user.ID, newEntries); err != nil {
+		slog.Error("Unable to add new entries to the similarity corpus",
+			slog.Int64("user_id", user.ID),
+			slog.Int64("feed_id", feed.ID),
+			slog.Any("error", err),
+		)
+	}
+
+	updateSimilarEntries(store, user, newEntries)
+
+	userIntegrations, intErr := store.Integration(user.ID)
+	if intErr != nil {
+		slog.Error("Fetching integrations failed; the refresh process will go on, but no integrations will run this time",
+			slog.Int64("user_id", user.ID),
+			slog.Int64("feed_id", feed.ID),
+			slog.Any("error", intErr),
+		)
+	} else if userIntegrations != nil && len(newEntries) > 0 {
+		// The notification providers only receive the entries matching the rules with the notify action,
+		// for the entries evaluated by such rules.
+		var notifiedEntries model.Entries
+		for _, entry := range newEntries {
+			if !entry.FilterNotifications || entry.RuleActions.Has(model.EntryRuleActionNotify) {
+				notifiedEntries = append(notifiedEntries, entry)
+			}
+		}
+
+		go func() {
+			integration.PushEntries(store, feed, newEntries, notifiedEntries, userIntegrations)
+
+			for _, entry := range newEntries {
+				for _, name := range entry.RuleActions.Values(model.EntryRuleActionPushToIntegration) {
+					integration.SendEntryTo(store, entry, userIntegrations, name)
+				}
+			}
+		}()
+	}
+}
+
+// applyEntryRuleActions marks as read and stars the new entries matching the entry rules.
+func applyEntryRuleActions(store *storage.Storage, userID int64, newEntries model.Entries) {
+	var readEntryIDs, starredEntryIDs []int64
+	for _, entry := range newEntries {
+		if entry.RuleActions.Has(model.EntryRuleActionMarkAsRead) {
+			entry.Status = model.EntryStatusRead
+			readEntryIDs = append(readEntryIDs, entry.ID)
+		}
+		if entry.RuleActions.Has(model.EntryRuleActionStar) {
+			entry.Starred = true
+			starredEntryIDs = append(starredEntryIDs, entry.ID)
+		}
+	}
+
+	if len(readEntryIDs) > 0 {
+		if err := store.SetEntriesStatus(userID, readEntryIDs, model.EntryStatusRead); err != nil {
+			slog.Error("Unable to mark as read the entries matching the rules",
+				slog.Int64("user_id", userID),
+				slog.Any("error", err),
+			)
+		}
+	}
+
+	if len(starredEntryIDs) > 0 {
+		if err := store.SetEntriesBookmarkedState(userID, starredEntryIDs, true); err != nil {
+			slog.Error("Unable to star the entries matching the rules",
+				slog.Int64("user_id", userID),
+				slog.Any("error", err),
+			)
+		}
+	}
+}
+
+// applyMoveToCategoryAction moves the feed to the category of the first rule matching a new entry.
+// The caller saves the feed, so the feed is moved at most once per refresh.
+func applyMoveToCategoryAction(store *storage.Storage, feed *model.Feed, newEntries model.Entries) {
+	for _, entry := range newEntries {
+		for _, value := range entry.RuleActions.Values(model.EntryRuleActionMoveToCategory) {
+			categoryID, err := strconv.ParseInt(value, 10, 64)
+			if err != nil || (feed.Category != nil && feed.Category.ID == categoryID) {
+				continue
+			}
+
+			if !store.CategoryIDExists(feed.UserID, categoryID) {
+				continue
+			}
+
+			slog.Info("Moving the feed to the category of a matching rule",
+				slog.Int64("user_id", feed.UserID),
+				slog.Int64("feed_id", feed.ID),
+				slog.Int64("entry_id", entry.ID),
+				slog.Int64("category_id", categoryID),
+			)
+			feed.WithCategoryID(categoryID)
+			return
+		}
+	}
+}
+
+func updateSimilarEntries(store *storage.Storage, user *model.User, newEntries model.Entries) {
+	windowHours := config.Opts.SimilarityWindowHours()
+	if windowHours <= 0 || len(newEntries) == 0 {
+		return
+	}
+
+	window := time.Duration(windowHours) * time.Hour
+	if err := similarity.RefreshEntries(store, user.ID, newEntries, config.Opts.SimilarityAlgorithm(), config.Opts.SimilarityThreshold(), window, config.Opts.SimilarityWindowSize()); err != nil {
+		slog.Error("Unable to update similar entries",
+			slog.Int64("user_id", user.ID),
+			slog.Int("new_entries", len(newEntries)),
+			slog.Any("error", err),
+		)
+		return
+	}
+
+	count, err := similarity.ApplyDuplicateAction(store, user, newEntries)
+	if err != nil {
+		slog.Error("Unable to apply the duplicate entries action",
+			slog.Int64("user_id", user.ID),
+			slog.String("action", user.DuplicateEntriesAction),
+			slog.Any("error", err),
+		)
+	} else if count > 0 {
+		slog.Debug("Applied the duplicate entries action",
+			slog.Int64("user_id", user.ID),
+			slog.String("action", user.DuplicateEntriesAction),
+			slog.Int("entries", count),
+		)
+	}
+}
+
+// checkPermanentRedirect updates the URL of a feed permanently moved when enabled,
+// otherwise the new location is kept to be suggested to the user.
+func checkPermanentRedirect(store *storage.Storage, feed *model.Feed, redirectedURL string) {
+	if redirectedURL == feed.FeedURL {
+		redirectedURL = ""
+	}
+
+	// The new location is only suggested when the user is already subscribed to it,
+	// two feeds of the same user can't have the same URL.
+	if redirectedURL != "" && config.Opts.UpdateFeedURLOnPermanentRedirect() && !store.AnotherFeedURLExists(feed.UserID, feed.ID, redirectedURL) {
+		slog.Info("Feed permanently redirected, updating the feed URL",
+			slog.Int64("user_id", feed.UserID),
+			slog.Int64("feed_id", feed.ID),
+			slog.String("old_feed_url", feed.FeedURL),
+			slog.String("new_feed_url", redirectedURL),
+		)
+		feed.FeedURL = redirectedURL
+		redirectedURL = ""
+	}
+
+	if redirectedURL == feed.RedirectedURL {
+		return
+	}
+
+	if err := store.UpdateFeedRedirection(feed.UserID, feed.ID, redirectedURL); err != nil {
+		slog.Error("Unable to update the feed redirection",
+			slog.Int64("user_id", feed.UserID),
+			slog.Int64("feed_id", feed.ID),
+			slog.Any("error", err),
+		)
+		return
+	}
+	feed.RedirectedURL = redirectedURL
 }
 
 func checkFeedIcon(store *storage.Storage, requestBuilder *fetcher.RequestBuilder, feedID int64, websiteURL, feedIconURL string) {
@@ -0,0 +1,153 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package handler // import "miniflux.app/v2/internal/reader/handler"
+
+import (
+	"bytes"
+	"log/slog"
+
+	"miniflux.app/v2/internal/config"
+	"miniflux.app/v2/internal/crypto"
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/reader/parser"
+	"miniflux.app/v2/internal/reader/processor"
+	"miniflux.app/v2/internal/reader/websub"
+	"miniflux.app/v2/internal/storage"
+)
+
+// ProcessWebSubContent stores the entries of a feed document delivered by a WebSub hub.
+func ProcessWebSubContent(store *storage.Storage, subscription *model.WebSubSubscription, body []byte) error {
+	user, err := store.UserByID(subscription.UserID)
+	if err != nil {
+		return err
+	}
+
+	feed, err := store.FeedByID(subscription.UserID, subscription.FeedID)
+	if err != nil {
+		return err
+	}
+
+	if feed == nil {
+		return ErrFeedNotFound
+	}
+
+	if feed.Disabled {
+		return nil
+	}
+
+	updatedFeed, err := parser.ParseFeed(feed.FeedURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	feed.Entries = updatedFeed.Entries
+	crawlerErr := processor.ProcessFeedEntries(store, feed, user, false)
+
+	newEntries, err := store.RefreshFeedEntries(feed.UserID, feed.ID, feed.Entries, !feed.Crawler)
+	if err != nil {
+		return err
+	}
+
+	slog.Debug("Received WebSub content",
+		slog.Int64("user_id", feed.UserID),
+		slog.Int64("feed_id", feed.ID),
+		slog.Int("new_entries", len(newEntries)),
+	)
+
+	processNewEntries(store, user, feed, newEntries)
+
+	feed.CheckedNow()
+	feed.ResetErrorCounter()
+	if crawlerErr != nil {
+		// The feed itself is fine, the error is reported without counting toward the parsing error limit.
+		feed.ParsingErrorMsg = crawlerErr.Translate(user.Language)
+	}
+	return store.UpdateFeed(feed)
+}
+
+// RenewWebSubSubscription sends a new subscription request to the hub, before the lease expires.
+// Subscriptions never verified by their hub get a new secret.
+func RenewWebSubSubscription(store *storage.Storage, subscription *model.WebSubSubscription) error {
+	if subscription.State == model.WebSubStatePending {
+		subscription.Secret = crypto.GenerateRandomStringHex(20)
+		if err := store.CreateWebSubSubscription(subscription); err != nil {
+			return err
+		}
+	} else if err := store.RenewWebSubSubscription(subscription.FeedID); err != nil {
+		return err
+	}
+
+	return websub.Subscribe(
+		subscription.HubURL,
+		subscription.TopicURL,
+		websub.CallbackURL(config.Opts.BaseURL(), subscription.FeedID),
+		subscription.Secret,
+		config.Opts.WebSubLeaseSeconds(),
+	)
+}
+
+// updateWebSubSubscription subscribes the feed to the hub it advertises, or unsubscribes it
+// when the feed stopped advertising a hub. Existing subscriptions to the same hub and topic are left to the renewal.
+func updateWebSubSubscription(store *storage.Storage, feed *model.Feed, hubURL, topicURL string) {
+	subscription, err := store.WebSubSubscription(feed.ID)
+	if err != nil {
+		slog.Error("Unable to fetch the WebSub subscription",
+			slog.Int64("feed_id", feed.ID),
+			slog.Any("error", err),
+		)
+		return
+	}
+
+	callbackURL := websub.CallbackURL(config.Opts.BaseURL(), feed.ID)
+
+	if hubURL == "" {
+		if subscription != nil && subscription.State != model.WebSubStateUnsubscribing {
+			if err := store.UnsubscribeWebSubSubscription(feed.ID); err != nil {
+				slog.Error("Unable to unsubscribe the WebSub subscription",
+					slog.Int64("feed_id", feed.ID),
+					slog.Any("error", err),
+				)
+				return
+			}
+			go websub.Unsubscribe(subscription.HubURL, subscription.TopicURL, callbackURL)
+		}
+		return
+	}
+
+	if subscription != nil && subscription.State != model.WebSubStateUnsubscribing && subscription.HubURL == hubURL && subscription.TopicURL == topicURL {
+		return
+	}
+
+	subscription = &model.WebSubSubscription{
+		FeedID:   feed.ID,
+		UserID:   feed.UserID,
+		HubURL:   hubURL,
+		TopicURL: topicURL,
+		Secret:   crypto.GenerateRandomStringHex(20),
+	}
+
+	// The subscription is stored first since some hubs verify the intent before answering the request.
+	if err := store.CreateWebSubSubscription(subscription); err != nil {
+		slog.Error("Unable to create the WebSub subscription",
+			slog.Int64("feed_id", feed.ID),
+			slog.Any("error", err),
+		)
+		return
+	}
+
+	if err := websub.Subscribe(hubURL, topicURL, callbackURL, subscription.Secret, config.Opts.WebSubLeaseSeconds()); err != nil {
+		slog.Warn("Unable to subscribe to the WebSub hub, the feed is still polled",
+			slog.Int64("feed_id", feed.ID),
+			slog.String("hub_url", hubURL),
+			slog.Any("error", err),
+		)
+		return
+	}
+
+	slog.Debug("Sent WebSub subscription request",
+		slog.Int64("feed_id", feed.ID),
+		slog.String("hub_url", hubURL),
+		slog.String("topic_url", topicURL),
+	)
+}
@@ -64,6 +64,17 @@ func (j *JSONAdapter) BuildFeed(baseURL string) *model.Feed {
 		}
 	}
 
+	// Populate the WebSub hub URL if present.
+	for _, hub := range j.jsonFeed.Hubs {
+		hubURL := strings.TrimSpace(hub.URL)
+		if hubURL != "" && strings.EqualFold(hub.Type, "WebSub") {
+			if absoluteHubURL, err := urllib.AbsoluteURL(feed.FeedURL, hubURL); err == nil {
+				feed.HubURL = absoluteHubURL
+				break
+			}
+		}
+	}
+
 	for _, item := range j.jsonFeed.Items {
 		entry := model.NewEntry()
 		entry.Title = strings.TrimSpace(item.Title)
@@ -0,0 +1,190 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package jsonmapping // import "miniflux.app/v2/internal/reader/jsonmapping"
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+
+	"miniflux.app/v2/internal/crypto"
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/reader/date"
+	"miniflux.app/v2/internal/reader/sanitizer"
+	"miniflux.app/v2/internal/urllib"
+)
+
+var ErrNoItems = errors.New("jsonmapping: no item found in the document")
+
+type compiledMapping struct {
+	items   Path
+	id      Path
+	title   Path
+	url     Path
+	date    Path
+	content Path
+}
+
+func compileMapping(mapping *model.JSONMapping) (*compiledMapping, error) {
+	compiled := &compiledMapping{}
+	for _, field := range []struct {
+		expression string
+		path       *Path
+	}{
+		{mapping.ItemsPath, &compiled.items},
+		{mapping.IDPath, &compiled.id},
+		{mapping.TitlePath, &compiled.title},
+		{mapping.URLPath, &compiled.url},
+		{mapping.DatePath, &compiled.date},
+		{mapping.ContentPath, &compiled.content},
+	} {
+		if strings.TrimSpace(field.expression) == "" {
+			continue
+		}
+
+		path, err := ParsePath(field.expression)
+		if err != nil {
+			return nil, err
+		}
+		*field.path = path
+	}
+
+	return compiled, nil
+}
+
+// ValidateMapping returns an error if one of the path expressions is invalid.
+func ValidateMapping(mapping *model.JSONMapping) error {
+	_, err := compileMapping(mapping)
+	return err
+}
+
+// Parse returns a normalized feed struct from a JSON document described by the mapping.
+func Parse(baseURL string, data io.Reader, mapping *model.JSONMapping) (*model.Feed, error) {
+	compiled, err := compileMapping(mapping)
+	if err != nil {
+		return nil, err
+	}
+
+	decoder := json.NewDecoder(data)
+	decoder.UseNumber()
+
+	var document any
+	if err := decoder.Decode(&document); err != nil {
+		return nil, fmt.Errorf("jsonmapping: unable to parse document: %w", err)
+	}
+
+	items := compiled.items.Evaluate(document)
+
+	// A path to an array without wildcard returns the array itself.
+	if len(items) == 1 {
+		if array, ok := items[0].([]any); ok {
+			items = array
+		}
+	}
+
+	feed := &model.Feed{
+		Title:   urllib.Domain(baseURL),
+		FeedURL: baseURL,
+		SiteURL: baseURL,
+	}
+
+	for _, item := range items {
+		if item == nil {
+			continue
+		}
+
+		entry := model.NewEntry()
+		entry.Title = stringValue(compiled.title.First(item))
+		entry.Content = stringValue(compiled.content.First(item))
+		entry.URL = stringValue(compiled.url.First(item))
+
+		if entry.URL != "" {
+			if absoluteURL, err := urllib.AbsoluteURL(baseURL, entry.URL); err == nil {
+				entry.URL = absoluteURL
+			}
+		} else {
+			entry.URL = baseURL
+		}
+
+		// The entry title is optional, so we need to find a fallback.
+		if entry.Title == "" {
+			entry.Title = sanitizer.TruncateHTML(entry.Content, 100)
+		}
+
+		if entry.Title == "" {
+			entry.Title = entry.URL
+		}
+
+		entry.Date = dateValue(compiled.date.First(item))
+		if entry.Date.IsZero() {
+			entry.Date = time.Now()
+		}
+
+		// Generate a hash for the entry.
+		for _, value := range []string{stringValue(compiled.id.First(item)), stringValue(compiled.url.First(item)), entry.Title + entry.Content} {
+			if value != "" {
+				entry.Hash = crypto.Hash(value)
+				break
+			}
+		}
+
+		feed.Entries = append(feed.Entries, entry)
+	}
+
+	if len(feed.Entries) == 0 {
+		return nil, ErrNoItems
+	}
+
+	return feed, nil
+}
+
+// stringValue converts a scalar JSON value to a string, objects and arrays are ignored.
+func stringValue(value any) string {
+	switch typedValue := value.(type) {
+	case string:
+		return strings.TrimSpace(typedValue)
+	case json.Number:
+		return typedValue.String()
+	case bool:
+		return strconv.FormatBool(typedValue)
+	default:
+		return ""
+	}
+}
+
+// dateValue parses a date string or a Unix timestamp in seconds or milliseconds.
+func dateValue(value any) time.Time {
+	switch typedValue := value.(type) {
+	case json.Number:
+		timestamp, err := typedValue.Int64()
+		if err != nil {
+			return time.Time{}
+		}
+		if timestamp > 1e12 {
+			return time.UnixMilli(timestamp)
+		}
+		return time.Unix(timestamp, 0)
+	case string:
+		if typedValue == "" {
+			return time.Time{}
+		}
+
+		parsedDate, err := date.Parse(typedValue)
+		if err != nil {
+			slog.Debug("Unable to parse date from JSON document",
+				slog.String("date", typedValue),
+				slog.Any("error", err),
+			)
+			return time.Time{}
+		}
+		return parsedDate
+	}
+
+	return time.Time{}
+}
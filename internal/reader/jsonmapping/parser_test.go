@@ -0,0 +1,128 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package jsonmapping // import "miniflux.app/v2/internal/reader/jsonmapping"
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"miniflux.app/v2/internal/model"
+)
+
+func TestParseGitHubReleases(t *testing.T) {
+	data := `[
+		{
+			"id": 1001,
+			"html_url": "https://github.com/miniflux/v2/releases/tag/2.1.0",
+			"name": "Miniflux 2.1.0",
+			"published_at": "2024-02-17T20:00:00Z",
+			"body": "Release notes"
+		},
+		{
+			"id": 1000,
+			"html_url": "https://github.com/miniflux/v2/releases/tag/2.0.51",
+			"name": "",
+			"published_at": "2023-12-13T10:00:00Z",
+			"body": "Older release"
+		}
+	]`
+
+	mapping := &model.JSONMapping{
+		ItemsPath:   "$",
+		IDPath:      "id",
+		TitlePath:   "name",
+		URLPath:     "html_url",
+		DatePath:    "published_at",
+		ContentPath: "body",
+	}
+
+	feed, err := Parse("https://api.github.com/repos/miniflux/v2/releases", strings.NewReader(data), mapping)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if feed.Title != "api.github.com" {
+		t.Errorf(`Unexpected feed title, got %q`, feed.Title)
+	}
+
+	if len(feed.Entries) != 2 {
+		t.Fatalf(`Unexpected number of entries, got %d`, len(feed.Entries))
+	}
+
+	entry := feed.Entries[0]
+	if entry.Title != "Miniflux 2.1.0" {
+		t.Errorf(`Unexpected entry title, got %q`, entry.Title)
+	}
+
+	if entry.URL != "https://github.com/miniflux/v2/releases/tag/2.1.0" {
+		t.Errorf(`Unexpected entry URL, got %q`, entry.URL)
+	}
+
+	if !entry.Date.Equal(time.Date(2024, 2, 17, 20, 0, 0, 0, time.UTC)) {
+		t.Errorf(`Unexpected entry date, got %v`, entry.Date)
+	}
+
+	if entry.Content != "Release notes" {
+		t.Errorf(`Unexpected entry content, got %q`, entry.Content)
+	}
+
+	if entry.Hash == "" || entry.Hash == feed.Entries[1].Hash {
+		t.Errorf(`Unexpected entry hashes %q and %q`, entry.Hash, feed.Entries[1].Hash)
+	}
+
+	if feed.Entries[1].Title != "Older release" {
+		t.Errorf(`The content should be used when the title is empty, got %q`, feed.Entries[1].Title)
+	}
+}
+
+func TestParseNestedItemsWithTimestamps(t *testing.T) {
+	data := `{"result": {"incidents": [
+		{"title": "Outage", "link": "/incidents/1", "updated": 1700000000},
+		{"title": "Maintenance", "link": "/incidents/2", "updated": 1700000000000}
+	]}}`
+
+	mapping := &model.JSONMapping{
+		ItemsPath: "result.incidents",
+		TitlePath: "title",
+		URLPath:   "link",
+		DatePath:  "updated",
+	}
+
+	feed, err := Parse("https://status.example.org/api/incidents.json", strings.NewReader(data), mapping)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(feed.Entries) != 2 {
+		t.Fatalf(`Unexpected number of entries, got %d`, len(feed.Entries))
+	}
+
+	if feed.Entries[0].URL != "https://status.example.org/incidents/1" {
+		t.Errorf(`Unexpected entry URL, got %q`, feed.Entries[0].URL)
+	}
+
+	for _, entry := range feed.Entries {
+		if entry.Date.Unix() != 1700000000 {
+			t.Errorf(`Unexpected entry date, got %v`, entry.Date)
+		}
+	}
+}
+
+func TestParseWithoutItems(t *testing.T) {
+	mapping := &model.JSONMapping{ItemsPath: "items"}
+
+	if _, err := Parse("https://example.org/", strings.NewReader(`{"data": []}`), mapping); !errors.Is(err, ErrNoItems) {
+		t.Errorf(`Expected ErrNoItems, got %v`, err)
+	}
+}
+
+func TestParseInvalidDocument(t *testing.T) {
+	mapping := &model.JSONMapping{ItemsPath: "items"}
+
+	if _, err := Parse("https://example.org/", strings.NewReader(`{"items": [`), mapping); err == nil {
+		t.Error(`Expected an error for an invalid document`)
+	}
+}
@@ -0,0 +1,151 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package jsonmapping // import "miniflux.app/v2/internal/reader/jsonmapping"
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// pathStep is a single step of a path expression: an object key, an array index or a wildcard.
+type pathStep struct {
+	key      string
+	index    int
+	isIndex  bool
+	wildcard bool
+}
+
+// Path is a compiled path expression.
+//
+// The syntax is a subset of JSONPath and jq filters:
+// "$.data.items[*]", ".data.items[]", "items", "author.name", "assets[0].url" or "['content-html']".
+type Path []pathStep
+
+// ParsePath compiles a path expression.
+func ParsePath(expression string) (Path, error) {
+	expression = strings.TrimSpace(expression)
+	expression = strings.TrimPrefix(expression, "$")
+
+	path := Path{}
+	for position := 0; position < len(expression); {
+		switch expression[position] {
+		case '.':
+			position++
+			if position < len(expression) && expression[position] == '.' {
+				return nil, fmt.Errorf("jsonmapping: recursive descent is not supported in %q", expression)
+			}
+		case '[':
+			end := strings.IndexByte(expression[position:], ']')
+			if end < 0 {
+				return nil, fmt.Errorf("jsonmapping: missing closing bracket in %q", expression)
+			}
+
+			step, err := parseBracket(expression[position+1 : position+end])
+			if err != nil {
+				return nil, fmt.Errorf("jsonmapping: %v in %q", err, expression)
+			}
+
+			path = append(path, step)
+			position += end + 1
+		default:
+			end := strings.IndexAny(expression[position:], ".[")
+			if end < 0 {
+				end = len(expression) - position
+			}
+
+			key := expression[position : position+end]
+			if key == "*" {
+				path = append(path, pathStep{wildcard: true})
+			} else {
+				path = append(path, pathStep{key: key})
+			}
+			position += end
+		}
+	}
+
+	return path, nil
+}
+
+func parseBracket(value string) (pathStep, error) {
+	value = strings.TrimSpace(value)
+
+	switch {
+	case value == "" || value == "*":
+		return pathStep{wildcard: true}, nil
+	case len(value) >= 2 && (value[0] == '\'' || value[0] == '"') && value[len(value)-1] == value[0]:
+		return pathStep{key: value[1 : len(value)-1]}, nil
+	}
+
+	index, err := strconv.Atoi(value)
+	if err != nil {
+		return pathStep{}, fmt.Errorf("invalid array index %q", value)
+	}
+
+	return pathStep{index: index, isIndex: true}, nil
+}
+
+// Evaluate returns all the values matched by the path.
+func (p Path) Evaluate(document any) []any {
+	values := []any{document}
+
+	for _, step := range p {
+		var next []any
+		for _, value := range values {
+			next = append(next, step.apply(value)...)
+		}
+		values = next
+	}
+
+	return values
+}
+
+// First returns the first non-null value matched by the path, an undefined path matches nothing.
+func (p Path) First(document any) any {
+	if p == nil {
+		return nil
+	}
+
+	for _, value := range p.Evaluate(document) {
+		if value != nil {
+			return value
+		}
+	}
+
+	return nil
+}
+
+func (s pathStep) apply(value any) []any {
+	switch typedValue := value.(type) {
+	case map[string]any:
+		if s.wildcard {
+			values := make([]any, 0, len(typedValue))
+			for _, child := range typedValue {
+				values = append(values, child)
+			}
+			return values
+		}
+
+		if child, found := typedValue[s.key]; found && !s.isIndex {
+			return []any{child}
+		}
+	case []any:
+		if s.wildcard {
+			return typedValue
+		}
+
+		if s.isIndex {
+			index := s.index
+			if index < 0 {
+				index += len(typedValue)
+			}
+
+			if index >= 0 && index < len(typedValue) {
+				return []any{typedValue[index]}
+			}
+		}
+	}
+
+	return nil
+}
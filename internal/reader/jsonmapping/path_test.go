@@ -0,0 +1,65 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package jsonmapping // import "miniflux.app/v2/internal/reader/jsonmapping"
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestPathEvaluate(t *testing.T) {
+	var document any
+	if err := json.Unmarshal([]byte(`{
+		"data": {
+			"items": [
+				{"name": "a", "tags": ["x", "y"], "content-html": "<p>A</p>"},
+				{"name": "b", "tags": ["z"]}
+			]
+		}
+	}`), &document); err != nil {
+		t.Fatal(err)
+	}
+
+	scenarios := []struct {
+		expression string
+		expected   []any
+	}{
+		{"$.data.items[*].name", []any{"a", "b"}},
+		{".data.items[].name", []any{"a", "b"}},
+		{"data.items[0].name", []any{"a"}},
+		{"data.items[-1].name", []any{"b"}},
+		{"data.items[*].tags[0]", []any{"x", "z"}},
+		{`data.items[0]["content-html"]`, []any{"<p>A</p>"}},
+		{"data.items[0]['content-html']", []any{"<p>A</p>"}},
+		{"data.missing", nil},
+		{"data.items[5].name", nil},
+	}
+
+	for _, scenario := range scenarios {
+		path, err := ParsePath(scenario.expression)
+		if err != nil {
+			t.Fatalf(`Unable to parse %q: %v`, scenario.expression, err)
+		}
+
+		if result := path.Evaluate(document); !reflect.DeepEqual(result, scenario.expected) {
+			t.Errorf(`Unexpected result for %q, got %v instead of %v`, scenario.expression, result, scenario.expected)
+		}
+	}
+}
+
+func TestParseInvalidPath(t *testing.T) {
+	for _, expression := range []string{"$..name", "items[0", "items[abc]"} {
+		if _, err := ParsePath(expression); err == nil {
+			t.Errorf(`Expected an error for %q`, expression)
+		}
+	}
+}
+
+func TestUndefinedPathMatchesNothing(t *testing.T) {
+	var path Path
+	if value := path.First(map[string]any{"name": "a"}); value != nil {
+		t.Errorf(`An undefined path should match nothing, got %v`, value)
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"miniflux.app/v2/internal/model"
 	"miniflux.app/v2/internal/reader/atom"
 	"miniflux.app/v2/internal/reader/json"
+	"miniflux.app/v2/internal/reader/jsonmapping"
 	"miniflux.app/v2/internal/reader/rdf"
 	"miniflux.app/v2/internal/reader/rss"
 )
@@ -37,3 +38,8 @@ func ParseFeed(baseURL string, r io.ReadSeeker) (*model.Feed, error) {
 		return nil, ErrFeedFormatNotDetected
 	}
 }
+
+// ParseJSONMapping returns a normalized feed object from an arbitrary JSON document described by the mapping.
+func ParseJSONMapping(baseURL string, r io.Reader, mapping *model.JSONMapping) (*model.Feed, error) {
+	return jsonmapping.Parse(baseURL, r, mapping)
+}
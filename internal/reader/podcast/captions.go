@@ -0,0 +1,39 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package podcast // import "miniflux.app/v2/internal/reader/podcast"
+
+import (
+	"bytes"
+	"errors"
+	"regexp"
+)
+
+var subRipTimestampRegex = regexp.MustCompile(`(\d{2}:\d{2}:\d{2}),(\d{3})`)
+
+// ConvertCaptions returns the WebVTT captions of a WebVTT or SubRip document, the only format supported by the media players.
+func ConvertCaptions(mimeType string, data []byte) ([]byte, error) {
+	data = bytes.TrimPrefix(data, []byte("\ufeff"))
+	data = bytes.ReplaceAll(data, []byte("\r\n"), []byte("\n"))
+
+	if mimeType == "text/vtt" {
+		if !bytes.HasPrefix(data, []byte("WEBVTT")) {
+			return nil, errors.New("podcast: invalid WebVTT captions")
+		}
+		return data, nil
+	}
+
+	lines := bytes.Split(bytes.TrimSpace(data), []byte("\n"))
+	for i, line := range lines {
+		// Only the timing lines use commas as decimal separators, the text of the cues is left as is.
+		if bytes.Contains(line, []byte("-->")) {
+			lines[i] = subRipTimestampRegex.ReplaceAll(line, []byte("$1.$2"))
+		}
+	}
+
+	var buffer bytes.Buffer
+	buffer.WriteString("WEBVTT\n\n")
+	buffer.Write(bytes.Join(lines, []byte("\n")))
+	buffer.WriteString("\n")
+	return buffer.Bytes(), nil
+}
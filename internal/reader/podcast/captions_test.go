@@ -0,0 +1,39 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package podcast // import "miniflux.app/v2/internal/reader/podcast"
+
+import "testing"
+
+func TestConvertSubRipCaptions(t *testing.T) {
+	data := "\ufeff1\r\n00:00:01,500 --> 00:00:04,000\r\nHello, world\r\n\r\n2\r\n00:01:00,000 --> 00:01:02,250\r\nBye\r\n"
+
+	captions, err := ConvertCaptions("application/x-subrip", []byte(data))
+	if err != nil {
+		t.Fatalf(`Unexpected error: %v`, err)
+	}
+
+	expected := "WEBVTT\n\n1\n00:00:01.500 --> 00:00:04.000\nHello, world\n\n2\n00:01:00.000 --> 00:01:02.250\nBye\n"
+	if string(captions) != expected {
+		t.Errorf(`Unexpected captions, got %q`, captions)
+	}
+}
+
+func TestConvertWebVTTCaptions(t *testing.T) {
+	data := "WEBVTT\n\n00:00:01.500 --> 00:00:04.000\nHello\n"
+
+	captions, err := ConvertCaptions("text/vtt", []byte(data))
+	if err != nil {
+		t.Fatalf(`Unexpected error: %v`, err)
+	}
+
+	if string(captions) != data {
+		t.Errorf(`WebVTT captions should be kept as is, got %q`, captions)
+	}
+}
+
+func TestConvertInvalidWebVTTCaptions(t *testing.T) {
+	if _, err := ConvertCaptions("text/vtt", []byte("<html></html>")); err == nil {
+		t.Error(`A document without the WebVTT header should be rejected`)
+	}
+}
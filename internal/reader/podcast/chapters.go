@@ -0,0 +1,67 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package podcast // import "miniflux.app/v2/internal/reader/podcast"
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"miniflux.app/v2/internal/model"
+)
+
+// maxChapters limits the number of chapters kept for an enclosure.
+const maxChapters = 500
+
+// Specs: https://github.com/Podcastindex-org/podcast-namespace/blob/main/chapters/jsonChapters.md
+type jsonChapters struct {
+	Chapters []jsonChapter `json:"chapters"`
+}
+
+type jsonChapter struct {
+	StartTime float64 `json:"startTime"`
+	Title     string  `json:"title"`
+	Image     string  `json:"img"`
+	URL       string  `json:"url"`
+	TOC       *bool   `json:"toc"`
+}
+
+// ParseChapters parses a JSON chapters file.
+// Chapters hidden from the table of contents are ignored.
+func ParseChapters(baseURL string, r io.Reader) (model.PodcastChapters, error) {
+	var document jsonChapters
+	if err := json.NewDecoder(r).Decode(&document); err != nil {
+		return nil, fmt.Errorf("podcast: unable to parse chapters: %w", err)
+	}
+
+	chapters := make(model.PodcastChapters, 0, len(document.Chapters))
+	for _, chapter := range document.Chapters {
+		if chapter.TOC != nil && !*chapter.TOC {
+			continue
+		}
+
+		if chapter.StartTime < 0 {
+			continue
+		}
+
+		chapters = append(chapters, model.PodcastChapter{
+			StartTime: chapter.StartTime,
+			Title:     strings.TrimSpace(chapter.Title),
+			URL:       absoluteURL(baseURL, chapter.URL),
+			ImageURL:  absoluteURL(baseURL, chapter.Image),
+		})
+
+		if len(chapters) == maxChapters {
+			break
+		}
+	}
+
+	sort.SliceStable(chapters, func(i, j int) bool {
+		return chapters[i].StartTime < chapters[j].StartTime
+	})
+
+	return chapters, nil
+}
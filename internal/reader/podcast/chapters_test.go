@@ -0,0 +1,47 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package podcast // import "miniflux.app/v2/internal/reader/podcast"
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseChapters(t *testing.T) {
+	data := `{
+		"version": "1.2.0",
+		"chapters": [
+			{"startTime": 120.5, "title": "Second", "url": "/links", "img": "javascript:alert(1)"},
+			{"startTime": 0, "title": " Intro ", "img": "https://example.org/intro.png"},
+			{"startTime": 60, "title": "Hidden", "toc": false}
+		]
+	}`
+
+	chapters, err := ParseChapters("https://example.org/episode/chapters.json", strings.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(chapters) != 2 {
+		t.Fatalf(`Unexpected number of chapters, got %d`, len(chapters))
+	}
+
+	if chapters[0].Title != "Intro" || chapters[0].StartTime != 0 || chapters[0].ImageURL != "https://example.org/intro.png" {
+		t.Errorf(`Unexpected first chapter: %+v`, chapters[0])
+	}
+
+	if chapters[1].StartTime != 120.5 || chapters[1].URL != "https://example.org/links" {
+		t.Errorf(`Unexpected second chapter: %+v`, chapters[1])
+	}
+
+	if chapters[1].ImageURL != "" {
+		t.Errorf(`Non HTTP image URLs should be ignored, got %q`, chapters[1].ImageURL)
+	}
+}
+
+func TestParseInvalidChapters(t *testing.T) {
+	if _, err := ParseChapters("https://example.org/", strings.NewReader("<html>")); err == nil {
+		t.Fatal(`Parsing an invalid document should return an error`)
+	}
+}
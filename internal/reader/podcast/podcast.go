@@ -0,0 +1,217 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package podcast // import "miniflux.app/v2/internal/reader/podcast"
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/urllib"
+)
+
+// Specs: https://podcastindex.org/namespace/1.0
+type PodcastChannelElement struct {
+	PodcastFunding []PodcastFundingElement `xml:"https://podcastindex.org/namespace/1.0 funding"`
+	PodcastPersons []PodcastPersonElement  `xml:"https://podcastindex.org/namespace/1.0 person"`
+}
+
+// Funding returns the donation links of the podcast.
+func (p *PodcastChannelElement) Funding(baseURL string) []model.PodcastFunding {
+	var funding []model.PodcastFunding
+	for _, element := range p.PodcastFunding {
+		fundingURL := absoluteURL(baseURL, element.URL)
+		if fundingURL == "" {
+			continue
+		}
+		funding = append(funding, model.PodcastFunding{
+			URL:   fundingURL,
+			Title: strings.TrimSpace(element.Title),
+		})
+	}
+	return funding
+}
+
+type PodcastItemElement struct {
+	PodcastTranscripts         []PodcastTranscriptElement         `xml:"https://podcastindex.org/namespace/1.0 transcript"`
+	PodcastChapters            PodcastChaptersElement             `xml:"https://podcastindex.org/namespace/1.0 chapters"`
+	PodcastPersons             []PodcastPersonElement             `xml:"https://podcastindex.org/namespace/1.0 person"`
+	PodcastSoundbites          []PodcastSoundbiteElement          `xml:"https://podcastindex.org/namespace/1.0 soundbite"`
+	PodcastAlternateEnclosures []PodcastAlternateEnclosureElement `xml:"https://podcastindex.org/namespace/1.0 alternateEnclosure"`
+}
+
+// Transcripts returns the transcripts of the episode.
+func (p *PodcastItemElement) Transcripts(baseURL string) model.PodcastTranscripts {
+	var transcripts model.PodcastTranscripts
+	for _, element := range p.PodcastTranscripts {
+		transcriptURL := absoluteURL(baseURL, element.URL)
+		if transcriptURL == "" {
+			continue
+		}
+		transcripts = append(transcripts, model.PodcastTranscript{
+			URL:      transcriptURL,
+			MimeType: strings.TrimSpace(element.Type),
+			Language: strings.TrimSpace(element.Language),
+			Rel:      strings.TrimSpace(element.Rel),
+		})
+	}
+	return transcripts
+}
+
+// ChaptersURL returns the URL of the JSON chapters file.
+// Other chapter formats are not supported.
+func (p *PodcastItemElement) ChaptersURL(baseURL string) string {
+	if !strings.HasPrefix(strings.TrimSpace(p.PodcastChapters.Type), "application/json") {
+		return ""
+	}
+	return absoluteURL(baseURL, p.PodcastChapters.URL)
+}
+
+// Soundbites returns the highlights of the episode.
+func (p *PodcastItemElement) Soundbites() []model.PodcastSoundbite {
+	var soundbites []model.PodcastSoundbite
+	for _, element := range p.PodcastSoundbites {
+		startTime, err := strconv.ParseFloat(strings.TrimSpace(element.StartTime), 64)
+		if err != nil || startTime < 0 {
+			continue
+		}
+		duration, err := strconv.ParseFloat(strings.TrimSpace(element.Duration), 64)
+		if err != nil || duration <= 0 {
+			continue
+		}
+		soundbites = append(soundbites, model.PodcastSoundbite{
+			StartTime: startTime,
+			Duration:  duration,
+			Title:     strings.TrimSpace(element.Title),
+		})
+	}
+	return soundbites
+}
+
+// AlternateEnclosures returns the other versions of the media file, for example a different bitrate or format.
+// Only the first HTTP source of each alternate enclosure is kept.
+func (p *PodcastItemElement) AlternateEnclosures(baseURL string) model.EnclosureList {
+	enclosures := make(model.EnclosureList, 0, len(p.PodcastAlternateEnclosures))
+	for _, element := range p.PodcastAlternateEnclosures {
+		for _, source := range element.Sources {
+			sourceURL := absoluteURL(baseURL, source.URI)
+			if sourceURL == "" {
+				continue
+			}
+
+			mimeType := strings.TrimSpace(source.ContentType)
+			if mimeType == "" {
+				mimeType = strings.TrimSpace(element.Type)
+			}
+
+			size, _ := strconv.ParseInt(strings.TrimSpace(element.Length), 10, 64)
+			enclosures = append(enclosures, &model.Enclosure{
+				URL:       sourceURL,
+				MimeType:  mimeType,
+				Size:      size,
+				Alternate: true,
+			})
+			break
+		}
+	}
+	return enclosures
+}
+
+// Persons returns the people involved in the episode, or in the podcast when the episode does not list anyone.
+func Persons(baseURL string, itemPersons, channelPersons []PodcastPersonElement) []model.PodcastPerson {
+	elements := itemPersons
+	if len(elements) == 0 {
+		elements = channelPersons
+	}
+
+	var persons []model.PodcastPerson
+	for _, element := range elements {
+		name := strings.TrimSpace(element.Name)
+		if name == "" {
+			continue
+		}
+
+		// The default values are defined by the specification.
+		role := strings.ToLower(strings.TrimSpace(element.Role))
+		if role == "" {
+			role = "host"
+		}
+		group := strings.ToLower(strings.TrimSpace(element.Group))
+		if group == "" {
+			group = "cast"
+		}
+
+		persons = append(persons, model.PodcastPerson{
+			Name:     name,
+			Role:     role,
+			Group:    group,
+			ImageURL: absoluteURL(baseURL, element.Image),
+			URL:      absoluteURL(baseURL, element.Href),
+		})
+	}
+	return persons
+}
+
+type PodcastFundingElement struct {
+	URL   string `xml:"url,attr"`
+	Title string `xml:",chardata"`
+}
+
+type PodcastPersonElement struct {
+	Name  string `xml:",chardata"`
+	Role  string `xml:"role,attr"`
+	Group string `xml:"group,attr"`
+	Image string `xml:"img,attr"`
+	Href  string `xml:"href,attr"`
+}
+
+type PodcastTranscriptElement struct {
+	URL      string `xml:"url,attr"`
+	Type     string `xml:"type,attr"`
+	Language string `xml:"language,attr"`
+	Rel      string `xml:"rel,attr"`
+}
+
+type PodcastChaptersElement struct {
+	URL  string `xml:"url,attr"`
+	Type string `xml:"type,attr"`
+}
+
+type PodcastSoundbiteElement struct {
+	StartTime string `xml:"startTime,attr"`
+	Duration  string `xml:"duration,attr"`
+	Title     string `xml:",chardata"`
+}
+
+type PodcastAlternateEnclosureElement struct {
+	Type    string                 `xml:"type,attr"`
+	Length  string                 `xml:"length,attr"`
+	Sources []PodcastSourceElement `xml:"https://podcastindex.org/namespace/1.0 source"`
+}
+
+type PodcastSourceElement struct {
+	URI         string `xml:"uri,attr"`
+	ContentType string `xml:"contentType,attr"`
+}
+
+// absoluteURL returns an empty string when the link is not a valid HTTP URL.
+func absoluteURL(baseURL, link string) string {
+	link = strings.TrimSpace(link)
+	if link == "" {
+		return ""
+	}
+
+	absoluteLink, err := urllib.AbsoluteURL(baseURL, link)
+	if err != nil {
+		return ""
+	}
+
+	parsedURL, err := url.Parse(absoluteLink)
+	if err != nil || (parsedURL.Scheme != "http" && parsedURL.Scheme != "https") {
+		return ""
+	}
+
+	return absoluteLink
+}
@@ -13,6 +13,7 @@ import (
 	"time"
 
 	"miniflux.app/v2/internal/config"
+	"miniflux.app/v2/internal/locale"
 	"miniflux.app/v2/internal/metric"
 	"miniflux.app/v2/internal/model"
 	"miniflux.app/v2/internal/reader/fetcher"
@@ -36,8 +37,19 @@ var (
 )
 
 // ProcessFeedEntries downloads original web page for entries and apply filters.
-func ProcessFeedEntries(store *storage.Storage, feed *model.Feed, user *model.User, forceRefresh bool) {
+// It returns an error when the website does not allow the crawler to download the pages.
+func ProcessFeedEntries(store *storage.Storage, feed *model.Feed, user *model.User, forceRefresh bool) *locale.LocalizedErrorWrapper {
 	var filteredEntries model.Entries
+	var crawlerErr *locale.LocalizedErrorWrapper
+
+	rules, err := store.EntryRules(user.ID)
+	if err != nil {
+		slog.Error("Unable to fetch the entry rules, the rules will not be applied",
+			slog.Int64("user_id", user.ID),
+			slog.Int64("feed_id", feed.ID),
+			slog.Any("error", err),
+		)
+	}
 
 	// Process older entries first
 	for i := len(feed.Entries) - 1; i >= 0; i-- {
@@ -103,6 +115,9 @@ func ProcessFeedEntries(store *storage.Storage, feed *model.Feed, user *model.Us
 					slog.String("feed_url", feed.FeedURL),
 					slog.Any("error", scraperErr),
 				)
+				if errors.Is(scraperErr, fetcher.ErrDisallowedByRobotsTxt) {
+					crawlerErr = locale.NewLocalizedErrorWrapper(scraperErr, "error.robots_txt_disallowed")
+				}
 			} else if content != "" {
 				// We replace the entry content only if the scraper doesn't return any error.
 				entry.Content = minifyEntryContent(content)
@@ -115,10 +130,45 @@ func ProcessFeedEntries(store *storage.Storage, feed *model.Feed, user *model.Us
 		entry.Content = sanitizer.Sanitize(websiteURL, entry.Content)
 
 		updateEntryReadingTime(store, feed, entry, entryIsNew, user)
+
+		applyEntryRules(rules, feed, entry, entryIsNew)
+
 		filteredEntries = append(filteredEntries, entry)
 	}
 
 	feed.Entries = filteredEntries
+	return crawlerErr
+}
+
+// applyEntryRules records the actions of the rules matching the entry, the actions requiring
+// the entry to be stored are applied later on. Only the tags are updated for the existing entries.
+// A new entry is only notified when it matches a notify rule, if such a rule targets its feed.
+func applyEntryRules(rules model.EntryRules, feed *model.Feed, entry *model.Entry, entryIsNew bool) {
+	entry.FilterNotifications = entryIsNew && rules.HasAction(feed, model.EntryRuleActionNotify)
+
+	actions := rules.Evaluate(feed, entry)
+	if len(actions) == 0 {
+		return
+	}
+
+	slog.Debug("Applying entry rules",
+		slog.Int64("user_id", feed.UserID),
+		slog.String("entry_url", entry.URL),
+		slog.Int64("feed_id", feed.ID),
+		slog.Any("actions", actions),
+	)
+
+	for _, tag := range actions.Values(model.EntryRuleActionAddTag) {
+		if !slices.Contains(entry.Tags, tag) {
+			entry.Tags = append(entry.Tags, tag)
+		}
+	}
+
+	if !entryIsNew {
+		return
+	}
+
+	entry.RuleActions = actions
 }
 
 func isBlockedEntry(feed *model.Feed, entry *model.Entry) bool {
@@ -14,6 +14,7 @@ import (
 	"miniflux.app/v2/internal/crypto"
 	"miniflux.app/v2/internal/model"
 	"miniflux.app/v2/internal/reader/date"
+	"miniflux.app/v2/internal/reader/podcast"
 	"miniflux.app/v2/internal/reader/sanitizer"
 	"miniflux.app/v2/internal/urllib"
 )
@@ -49,6 +50,17 @@ func (r *RSSAdapter) BuildFeed(baseURL string) *model.Feed {
 		}
 	}
 
+	// Find the WebSub hub from the Atom links.
+	for _, atomLink := range r.rss.Channel.AtomLinks.Links {
+		atomLinkHref := strings.TrimSpace(atomLink.Href)
+		if atomLinkHref != "" && strings.EqualFold(atomLink.Rel, "hub") {
+			if absoluteHubURL, err := urllib.AbsoluteURL(feed.FeedURL, atomLinkHref); err == nil {
+				feed.HubURL = absoluteHubURL
+				break
+			}
+		}
+	}
+
 	// Fallback to the site URL if the title is empty.
 	if feed.Title == "" {
 		feed.Title = feed.SiteURL
@@ -73,6 +85,11 @@ func (r *RSSAdapter) BuildFeed(baseURL string) *model.Feed {
 		entry.Date = findEntryDate(&item)
 		entry.Content = findEntryContent(&item)
 		entry.Enclosures = findEntryEnclosures(&item, feed.SiteURL)
+		entry.Podcast = model.PodcastEpisode{
+			Persons:    podcast.Persons(feed.SiteURL, item.PodcastPersons, r.rss.Channel.PodcastPersons),
+			Funding:    r.rss.Channel.Funding(feed.SiteURL),
+			Soundbites: item.Soundbites(),
+		}
 
 		// Populate the entry URL.
 		entryURL := findEntryURL(&item)
@@ -288,6 +305,7 @@ func findEntryEnclosures(rssItem *RSSItem, siteURL string) model.EnclosureList {
 		}
 	}
 
+	hasPodcastEnclosure := false
 	for _, enclosure := range rssItem.Enclosures {
 		enclosureURL := enclosure.URL
 
@@ -310,11 +328,27 @@ func findEntryEnclosures(rssItem *RSSItem, siteURL string) model.EnclosureList {
 		if _, found := duplicates[enclosureURL]; !found {
 			duplicates[enclosureURL] = true
 
-			enclosures = append(enclosures, &model.Enclosure{
+			mediaEnclosure := &model.Enclosure{
 				URL:      enclosureURL,
 				MimeType: enclosure.Type,
 				Size:     enclosure.Size(),
-			})
+			}
+
+			// Podcasting 2.0 chapters and transcripts describe the main media file.
+			if !hasPodcastEnclosure {
+				hasPodcastEnclosure = true
+				mediaEnclosure.ChaptersURL = rssItem.ChaptersURL(siteURL)
+				mediaEnclosure.Transcripts = rssItem.Transcripts(siteURL)
+			}
+
+			enclosures = append(enclosures, mediaEnclosure)
+		}
+	}
+
+	for _, alternateEnclosure := range rssItem.AlternateEnclosures(siteURL) {
+		if _, found := duplicates[alternateEnclosure.URL]; !found {
+			duplicates[alternateEnclosure.URL] = true
+			enclosures = append(enclosures, alternateEnclosure)
 		}
 	}
 
@@ -433,6 +433,27 @@ func TestParseFeedURLWithAtomLink(t *testing.T) {
 	}
 }
 
+func TestParseFeedHubURLWithAtomLink(t *testing.T) {
+	data := `<?xml version="1.0" encoding="utf-8"?>
+		<rss xmlns:atom="http://www.w3.org/2005/Atom" version="2.0">
+		<channel>
+			<title>Example</title>
+			<link>https://example.org/</link>
+			<atom:link href="https://example.org/rss" type="application/rss+xml" rel="self"></atom:link>
+			<atom:link href="https://hub.example.org/" rel="hub"></atom:link>
+		</channel>
+		</rss>`
+
+	feed, err := Parse("https://example.org/", bytes.NewReader([]byte(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if feed.HubURL != "https://hub.example.org/" {
+		t.Errorf("Incorrect hub URL, got: %s", feed.HubURL)
+	}
+}
+
 func TestParseFeedWithWebmaster(t *testing.T) {
 	data := `<?xml version="1.0" encoding="utf-8"?>
 		<rss version="2.0">
@@ -2058,3 +2079,79 @@ func TestParseFeedWithIncorrectTTLValue(t *testing.T) {
 		t.Errorf("Incorrect TTL, got: %d", feed.TTL)
 	}
 }
+
+func TestParseFeedWithPodcastNamespace(t *testing.T) {
+	data := `<?xml version="1.0" encoding="UTF-8"?>
+		<rss version="2.0" xmlns:podcast="https://podcastindex.org/namespace/1.0">
+		<channel>
+			<title>Podcast Example</title>
+			<link>https://example.org/</link>
+			<podcast:funding url="https://example.org/donate">Support the show</podcast:funding>
+			<podcast:person role="host" img="/alice.jpg">Alice</podcast:person>
+			<item>
+				<title>Episode 1</title>
+				<guid>https://example.org/episode-1</guid>
+				<enclosure url="https://example.org/episode-1.mp3" length="1000" type="audio/mpeg"/>
+				<podcast:transcript url="/episode-1.vtt" type="text/vtt" language="en" rel="captions"/>
+				<podcast:transcript url="javascript:alert(1)" type="text/html"/>
+				<podcast:chapters url="/episode-1.json" type="application/json+chapters"/>
+				<podcast:soundbite startTime="73.0" duration="60.0">Best moment</podcast:soundbite>
+				<podcast:soundbite startTime="invalid" duration="60.0"/>
+				<podcast:alternateEnclosure type="audio/opus" length="500">
+					<podcast:source uri="ipfs://QmX"/>
+					<podcast:source uri="https://example.org/episode-1.opus"/>
+				</podcast:alternateEnclosure>
+			</item>
+			<item>
+				<title>Episode 2</title>
+				<guid>https://example.org/episode-2</guid>
+				<podcast:person role="guest" group="cast" href="https://bob.example.org/">Bob</podcast:person>
+			</item>
+		</channel>
+		</rss>`
+
+	feed, err := Parse("https://example.org/", bytes.NewReader([]byte(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	episode := feed.Entries[0]
+	if len(episode.Enclosures) != 2 {
+		t.Fatalf(`Unexpected number of enclosures, got %d`, len(episode.Enclosures))
+	}
+
+	mainEnclosure := episode.Enclosures[0]
+	if mainEnclosure.ChaptersURL != "https://example.org/episode-1.json" {
+		t.Errorf(`Unexpected chapters URL, got %q`, mainEnclosure.ChaptersURL)
+	}
+
+	if len(mainEnclosure.Transcripts) != 1 {
+		t.Fatalf(`Unexpected number of transcripts, got %d`, len(mainEnclosure.Transcripts))
+	}
+
+	if transcript := mainEnclosure.Transcripts[0]; transcript.URL != "https://example.org/episode-1.vtt" || transcript.MimeType != "text/vtt" || transcript.Language != "en" || transcript.Rel != "captions" {
+		t.Errorf(`Unexpected transcript: %+v`, transcript)
+	}
+
+	alternateEnclosure := episode.Enclosures[1]
+	if !alternateEnclosure.Alternate || alternateEnclosure.URL != "https://example.org/episode-1.opus" || alternateEnclosure.MimeType != "audio/opus" || alternateEnclosure.Size != 500 {
+		t.Errorf(`Unexpected alternate enclosure: %+v`, alternateEnclosure)
+	}
+
+	if len(episode.Podcast.Soundbites) != 1 || episode.Podcast.Soundbites[0].StartTime != 73 || episode.Podcast.Soundbites[0].Title != "Best moment" {
+		t.Errorf(`Unexpected soundbites: %+v`, episode.Podcast.Soundbites)
+	}
+
+	if len(episode.Podcast.Funding) != 1 || episode.Podcast.Funding[0].URL != "https://example.org/donate" || episode.Podcast.Funding[0].Title != "Support the show" {
+		t.Errorf(`Unexpected funding: %+v`, episode.Podcast.Funding)
+	}
+
+	if len(episode.Podcast.Persons) != 1 || episode.Podcast.Persons[0].Name != "Alice" || episode.Podcast.Persons[0].Group != "cast" || episode.Podcast.Persons[0].ImageURL != "https://example.org/alice.jpg" {
+		t.Errorf(`Episode persons should fallback to the channel persons, got %+v`, episode.Podcast.Persons)
+	}
+
+	persons := feed.Entries[1].Podcast.Persons
+	if len(persons) != 1 || persons[0].Name != "Bob" || persons[0].Role != "guest" || persons[0].URL != "https://bob.example.org/" {
+		t.Errorf(`Unexpected episode persons: %+v`, persons)
+	}
+}
@@ -0,0 +1,140 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package scraper // import "miniflux.app/v2/internal/reader/scraper"
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+	"strings"
+	"time"
+
+	"miniflux.app/v2/internal/crypto"
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/reader/date"
+	"miniflux.app/v2/internal/reader/sanitizer"
+	"miniflux.app/v2/internal/urllib"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+var ErrNoPageItems = errors.New("scraper: no item found in the page")
+
+// ParsePage generates a feed from a HTML page without feed, using the page watcher CSS selectors.
+func ParsePage(pageURL string, page io.Reader, rules *model.PageWatcherRules) (*model.Feed, error) {
+	document, err := goquery.NewDocumentFromReader(page)
+	if err != nil {
+		return nil, err
+	}
+
+	feed := &model.Feed{
+		Title:   normalizeText(document.Find("head title").First().Text()),
+		FeedURL: pageURL,
+		SiteURL: pageURL,
+	}
+
+	if feed.Title == "" {
+		feed.Title = pageURL
+	}
+
+	document.Find(rules.ItemSelector).Each(func(i int, item *goquery.Selection) {
+		entry := model.NewEntry()
+		entry.URL = findItemLink(pageURL, item, rules.LinkSelector)
+
+		if rules.TitleSelector != "" {
+			entry.Title = normalizeText(item.Find(rules.TitleSelector).First().Text())
+		}
+
+		if rules.ContentSelector != "" {
+			entry.Content = selectionOuterHTML(item.Find(rules.ContentSelector))
+		} else {
+			entry.Content = selectionOuterHTML(item)
+		}
+
+		// Fallback to the beginning of the content if the title is empty.
+		if entry.Title == "" {
+			entry.Title = sanitizer.TruncateHTML(entry.Content, 100)
+		}
+
+		if entry.Title == "" {
+			entry.Title = entry.URL
+		}
+
+		if rules.DateSelector != "" {
+			entry.Date = findItemDate(item.Find(rules.DateSelector).First())
+		}
+
+		if entry.Date.IsZero() {
+			entry.Date = time.Now()
+		}
+
+		// Items without dedicated page share the URL of the watched page.
+		if entry.URL != pageURL {
+			entry.Hash = crypto.Hash(entry.URL)
+		} else {
+			entry.Hash = crypto.Hash(entry.Title + entry.Content)
+		}
+
+		feed.Entries = append(feed.Entries, entry)
+	})
+
+	if len(feed.Entries) == 0 {
+		return nil, ErrNoPageItems
+	}
+
+	return feed, nil
+}
+
+// findItemLink returns the absolute URL of the first link matching the selector, or the page URL.
+func findItemLink(pageURL string, item *goquery.Selection, selector string) string {
+	links := item
+	if selector != "" {
+		links = item.Find(selector)
+	}
+
+	var href string
+	links.EachWithBreak(func(i int, s *goquery.Selection) bool {
+		if !s.Is("a[href]") {
+			s = s.Find("a[href]")
+		}
+		href = strings.TrimSpace(s.First().AttrOr("href", ""))
+		return href == ""
+	})
+
+	if href == "" {
+		return pageURL
+	}
+
+	if absoluteURL, err := urllib.AbsoluteURL(pageURL, href); err == nil {
+		return absoluteURL
+	}
+
+	return pageURL
+}
+
+// findItemDate parses the datetime attribute of the element, or its text.
+func findItemDate(s *goquery.Selection) time.Time {
+	for _, value := range []string{s.AttrOr("datetime", ""), s.AttrOr("content", ""), s.Text()} {
+		value = normalizeText(value)
+		if value == "" {
+			continue
+		}
+
+		parsedDate, err := date.Parse(value)
+		if err == nil {
+			return parsedDate
+		}
+
+		slog.Debug("Unable to parse date from HTML page",
+			slog.String("date", value),
+			slog.Any("error", err),
+		)
+	}
+
+	return time.Time{}
+}
+
+func normalizeText(value string) string {
+	return strings.Join(strings.Fields(value), " ")
+}
@@ -0,0 +1,124 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package scraper // import "miniflux.app/v2/internal/reader/scraper"
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"miniflux.app/v2/internal/model"
+)
+
+const testPage = `<!DOCTYPE html>
+<html>
+<head><title> Company News </title></head>
+<body>
+	<ul class="news">
+		<li class="item">
+			<h2><a href="/news/1">First   release</a></h2>
+			<time datetime="2024-03-01T10:00:00Z">March 1st</time>
+			<p class="summary">Version 1.0 is out.</p>
+		</li>
+		<li class="item">
+			<h2><a href="https://example.com/news/2">Second release</a></h2>
+			<span class="date">2024-04-01</span>
+			<p class="summary">Version 2.0 is out.</p>
+		</li>
+		<li class="item">
+			<p class="summary">An announcement without link.</p>
+		</li>
+	</ul>
+</body>
+</html>`
+
+func TestParsePage(t *testing.T) {
+	rules := &model.PageWatcherRules{
+		ItemSelector:    "li.item",
+		TitleSelector:   "h2",
+		DateSelector:    "time, .date",
+		ContentSelector: ".summary",
+	}
+
+	feed, err := ParsePage("https://example.org/news", strings.NewReader(testPage), rules)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if feed.Title != "Company News" {
+		t.Errorf(`Unexpected feed title, got %q`, feed.Title)
+	}
+
+	if feed.SiteURL != "https://example.org/news" || feed.FeedURL != "https://example.org/news" {
+		t.Errorf(`Unexpected feed URLs, got %q and %q`, feed.SiteURL, feed.FeedURL)
+	}
+
+	if len(feed.Entries) != 3 {
+		t.Fatalf(`Unexpected number of entries, got %d`, len(feed.Entries))
+	}
+
+	first := feed.Entries[0]
+	if first.Title != "First release" {
+		t.Errorf(`Unexpected entry title, got %q`, first.Title)
+	}
+
+	if first.URL != "https://example.org/news/1" {
+		t.Errorf(`Unexpected entry URL, got %q`, first.URL)
+	}
+
+	if !first.Date.Equal(time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)) {
+		t.Errorf(`Unexpected entry date, got %v`, first.Date)
+	}
+
+	if first.Content != `<p class="summary">Version 1.0 is out.</p>` {
+		t.Errorf(`Unexpected entry content, got %q`, first.Content)
+	}
+
+	second := feed.Entries[1]
+	if second.URL != "https://example.com/news/2" {
+		t.Errorf(`Unexpected entry URL, got %q`, second.URL)
+	}
+
+	if second.Date.Year() != 2024 || second.Date.Month() != time.April {
+		t.Errorf(`Unexpected entry date, got %v`, second.Date)
+	}
+
+	third := feed.Entries[2]
+	if third.URL != "https://example.org/news" {
+		t.Errorf(`Items without link should use the page URL, got %q`, third.URL)
+	}
+
+	if third.Title == "" {
+		t.Error(`Items without title should use the content as title`)
+	}
+
+	if third.Hash == "" || third.Hash == first.Hash || third.Hash == second.Hash {
+		t.Errorf(`Unexpected entry hashes %q, %q and %q`, first.Hash, second.Hash, third.Hash)
+	}
+}
+
+func TestParsePageWithLinkSelector(t *testing.T) {
+	rules := &model.PageWatcherRules{
+		ItemSelector: "li.item",
+		LinkSelector: "h2",
+	}
+
+	feed, err := ParsePage("https://example.org/news", strings.NewReader(testPage), rules)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if feed.Entries[1].URL != "https://example.com/news/2" {
+		t.Errorf(`Unexpected entry URL, got %q`, feed.Entries[1].URL)
+	}
+}
+
+func TestParsePageWithoutItems(t *testing.T) {
+	rules := &model.PageWatcherRules{ItemSelector: "article"}
+
+	if _, err := ParsePage("https://example.org/news", strings.NewReader(testPage), rules); !errors.Is(err, ErrNoPageItems) {
+		t.Errorf(`Expected ErrNoPageItems, got %v`, err)
+	}
+}
@@ -19,6 +19,11 @@ import (
 )
 
 func ScrapeWebsite(requestBuilder *fetcher.RequestBuilder, websiteURL, rules string) (string, error) {
+	if err := requestBuilder.CheckRobotsTxt(websiteURL); err != nil {
+		slog.Warn("Unable to scrape website", slog.String("website_url", websiteURL), slog.Any("error", err))
+		return "", err
+	}
+
 	responseHandler := fetcher.NewResponseHandler(requestBuilder.ExecuteRequest(websiteURL))
 	defer responseHandler.Close()
 
@@ -76,14 +81,19 @@ func findContentUsingCustomRules(page io.Reader, rules string) (string, error) {
 		return "", err
 	}
 
+	return selectionOuterHTML(document.Find(rules)), nil
+}
+
+// selectionOuterHTML concatenates the HTML of all the elements of the selection.
+func selectionOuterHTML(selection *goquery.Selection) string {
 	contents := ""
-	document.Find(rules).Each(func(i int, s *goquery.Selection) {
+	selection.Each(func(i int, s *goquery.Selection) {
 		if content, err := goquery.OuterHtml(s); err == nil {
 			contents += content
 		}
 	})
 
-	return contents, nil
+	return contents
 }
 
 func getPredefinedScraperRules(websiteURL string) string {
@@ -0,0 +1,112 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package websub // import "miniflux.app/v2/internal/reader/websub"
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"miniflux.app/v2/internal/version"
+)
+
+const (
+	defaultClientTimeout = 10 * time.Second
+
+	ModeSubscribe   = "subscribe"
+	ModeUnsubscribe = "unsubscribe"
+	ModeDenied      = "denied"
+)
+
+// CallbackURL returns the URL receiving the intent verifications and the content distribution of a feed.
+func CallbackURL(baseURL string, feedID int64) string {
+	return strings.TrimSuffix(baseURL, "/") + "/websub/" + strconv.FormatInt(feedID, 10)
+}
+
+// Subscribe asks the hub to deliver the updates of the topic to the callback.
+// The hub verifies the intent asynchronously, or before answering the request.
+func Subscribe(hubURL, topicURL, callbackURL, secret string, leaseSeconds int) error {
+	values := url.Values{}
+	values.Set("hub.mode", ModeSubscribe)
+	values.Set("hub.topic", topicURL)
+	values.Set("hub.callback", callbackURL)
+	values.Set("hub.secret", secret)
+	if leaseSeconds > 0 {
+		values.Set("hub.lease_seconds", strconv.Itoa(leaseSeconds))
+	}
+	return sendRequest(hubURL, values)
+}
+
+// Unsubscribe asks the hub to stop delivering the updates of the topic to the callback.
+func Unsubscribe(hubURL, topicURL, callbackURL string) error {
+	values := url.Values{}
+	values.Set("hub.mode", ModeUnsubscribe)
+	values.Set("hub.topic", topicURL)
+	values.Set("hub.callback", callbackURL)
+	return sendRequest(hubURL, values)
+}
+
+func sendRequest(hubURL string, values url.Values) error {
+	request, err := http.NewRequest(http.MethodPost, hubURL, strings.NewReader(values.Encode()))
+	if err != nil {
+		return fmt.Errorf("websub: unable to create request: %v", err)
+	}
+
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	request.Header.Set("User-Agent", "Miniflux/"+version.Version)
+
+	httpClient := &http.Client{Timeout: defaultClientTimeout}
+	response, err := httpClient.Do(request)
+	if err != nil {
+		return fmt.Errorf("websub: unable to send %s request to hub %s: %v", values.Get("hub.mode"), hubURL, err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return fmt.Errorf("websub: incorrect response status code %d for hub %s", response.StatusCode, hubURL)
+	}
+
+	return nil
+}
+
+// VerifySignature checks the X-Hub-Signature header of a content distribution request.
+// The header has the form "method=signature" where the method is one of sha1, sha256, sha384 or sha512.
+func VerifySignature(secret, header string, body []byte) bool {
+	method, signature, found := strings.Cut(header, "=")
+	if !found || secret == "" {
+		return false
+	}
+
+	var newHash func() hash.Hash
+	switch strings.ToLower(method) {
+	case "sha1":
+		newHash = sha1.New
+	case "sha256":
+		newHash = sha256.New
+	case "sha384":
+		newHash = sha512.New384
+	case "sha512":
+		newHash = sha512.New
+	default:
+		return false
+	}
+
+	expected, err := hex.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+
+	mac := hmac.New(newHash, []byte(secret))
+	mac.Write(body)
+	return hmac.Equal(mac.Sum(nil), expected)
+}
@@ -0,0 +1,81 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package websub // import "miniflux.app/v2/internal/reader/websub"
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCallbackURL(t *testing.T) {
+	if callbackURL := CallbackURL("https://example.org/miniflux/", 42); callbackURL != "https://example.org/miniflux/websub/42" {
+		t.Errorf(`Unexpected callback URL, got %q`, callbackURL)
+	}
+}
+
+func TestSubscribe(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Fatal(err)
+		}
+
+		for key, expected := range map[string]string{
+			"hub.mode":          "subscribe",
+			"hub.topic":         "https://example.org/feed",
+			"hub.callback":      "https://reader.example.org/websub/1",
+			"hub.secret":        "secret",
+			"hub.lease_seconds": "3600",
+		} {
+			if value := r.PostForm.Get(key); value != expected {
+				t.Errorf(`Unexpected %s, got %q instead of %q`, key, value, expected)
+			}
+		}
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	if err := Subscribe(server.URL, "https://example.org/feed", "https://reader.example.org/websub/1", "secret", 3600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSubscribeRefusedByHub(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	if err := Subscribe(server.URL, "https://example.org/feed", "https://reader.example.org/websub/1", "secret", 0); err == nil {
+		t.Error(`A refused subscription should generate an error`)
+	}
+}
+
+func TestVerifySignature(t *testing.T) {
+	body := []byte("<feed></feed>")
+	mac := hmac.New(sha256.New, []byte("secret"))
+	mac.Write(body)
+	header := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+
+	if !VerifySignature("secret", header, body) {
+		t.Error(`A valid signature should be accepted`)
+	}
+
+	if VerifySignature("other secret", header, body) {
+		t.Error(`A signature made with another secret should be refused`)
+	}
+
+	if VerifySignature("secret", header, []byte("<feed>altered</feed>")) {
+		t.Error(`A signature of another body should be refused`)
+	}
+
+	for _, header := range []string{"", "sha256", "md5=abcd", "sha256=not-hex"} {
+		if VerifySignature("secret", header, body) {
+			t.Errorf(`The signature header %q should be refused`, header)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package similarity
+
+import (
+	"fmt"
+
+	"miniflux.app/v2/internal/similarity/algorithm"
+	"miniflux.app/v2/internal/similarity/minhash"
+	"miniflux.app/v2/internal/similarity/simhash"
+	"miniflux.app/v2/internal/similarity/v1"
+	"miniflux.app/v2/internal/similarity/v2"
+)
+
+// Comparator scores the similarity of two texts, from 0 to 1.
+type Comparator interface {
+	Compare(article1, article2 string) (float64, error)
+}
+
+// ComparatorFactory creates a Comparator, the corpus is nil when no document frequencies are available.
+type ComparatorFactory func(corpus v2.Corpus) Comparator
+
+var algorithms = map[string]ComparatorFactory{
+	algorithm.V1: func(corpus v2.Corpus) Comparator {
+		return v1.NewComparator(0)
+	},
+	algorithm.V2: func(corpus v2.Corpus) Comparator {
+		if corpus == nil {
+			return v2.NewComparator()
+		}
+		return v2.NewCorpusComparator(corpus)
+	},
+	algorithm.MinHash: func(corpus v2.Corpus) Comparator {
+		return minhash.NewComparator()
+	},
+	algorithm.SimHash: func(corpus v2.Corpus) Comparator {
+		return simhash.NewComparator()
+	},
+}
+
+// Algorithms returns the sorted names of the registered algorithms.
+func Algorithms() []string {
+	return algorithm.Names()
+}
+
+// NewComparator returns a Comparator for the given algorithm.
+func NewComparator(algorithm string, corpus v2.Corpus) (Comparator, error) {
+	factory, found := algorithms[algorithm]
+	if !found {
+		return nil, fmt.Errorf("similarity: unknown algorithm %q, expected one of %v", algorithm, Algorithms())
+	}
+	return factory(corpus), nil
+}
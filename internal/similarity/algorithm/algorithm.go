@@ -0,0 +1,24 @@
+// Package algorithm lists the similarity algorithms without depending on their implementations,
+// so the configuration parser can validate them.
+package algorithm
+
+import "slices"
+
+const (
+	V1      = "v1"
+	V2      = "v2"
+	MinHash = "minhash"
+	SimHash = "simhash"
+)
+
+var names = []string{MinHash, SimHash, V1, V2}
+
+// Names returns the sorted names of the similarity algorithms.
+func Names() []string {
+	return slices.Clone(names)
+}
+
+// IsValid returns true if the given name is a similarity algorithm.
+func IsValid(name string) bool {
+	return slices.Contains(names, name)
+}
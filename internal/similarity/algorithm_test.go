@@ -0,0 +1,49 @@
+package similarity
+
+import (
+	"testing"
+)
+
+func TestAlgorithms(t *testing.T) {
+	expected := []string{"minhash", "simhash", "v1", "v2"}
+	algorithms := Algorithms()
+	if len(algorithms) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, algorithms)
+	}
+	for i := range expected {
+		if algorithms[i] != expected[i] {
+			t.Fatalf("Expected %v, got %v", expected, algorithms)
+		}
+	}
+}
+
+func TestAlgorithmsAreRegistered(t *testing.T) {
+	for _, name := range Algorithms() {
+		if _, found := algorithms[name]; !found {
+			t.Errorf("Algorithm %q has no comparator", name)
+		}
+	}
+	if len(algorithms) != len(Algorithms()) {
+		t.Errorf("Expected %d registered comparators, got %d", len(Algorithms()), len(algorithms))
+	}
+}
+
+func TestNewComparator(t *testing.T) {
+	for _, algorithm := range []string{"minhash", "simhash", "v2"} {
+		comparator, err := NewComparator(algorithm, nil)
+		if err != nil {
+			t.Fatalf("Unexpected error for %s: %v", algorithm, err)
+		}
+		score, err := comparator.Compare("The same text", "The same text")
+		if err != nil {
+			t.Fatalf("Unexpected error for %s: %v", algorithm, err)
+		}
+		if score < 0.99 {
+			t.Errorf("Expected %s to score identical texts 1, got %f", algorithm, score)
+		}
+	}
+
+	if _, err := NewComparator("unknown", nil); err == nil {
+		t.Error("Expected an error for an unknown algorithm")
+	}
+}
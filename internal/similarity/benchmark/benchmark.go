@@ -0,0 +1,183 @@
+package benchmark
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"io"
+	"miniflux.app/v2/internal/similarity"
+	"miniflux.app/v2/internal/similarity/v2"
+	"sort"
+	"time"
+)
+
+//go:embed fixtures.json
+var builtinFixtures []byte
+
+// Pair is a labelled pair of articles.
+type Pair struct {
+	Name     string `json:"name"`
+	Article1 string `json:"article1"`
+	Article2 string `json:"article2"`
+	Similar  bool   `json:"similar"`
+}
+
+// Result holds how an algorithm scored the labelled pairs.
+type Result struct {
+	Algorithm string
+
+	// Precision, Recall and F1 are measured at the given threshold.
+	Precision float64
+	Recall    float64
+	F1        float64
+
+	// MeanSimilar and MeanDissimilar are the average scores of each label.
+	MeanSimilar    float64
+	MeanDissimilar float64
+
+	// BestThreshold is the threshold giving the highest F1 score on these pairs.
+	BestThreshold float64
+	BestF1        float64
+
+	Duration time.Duration
+}
+
+// BuiltinPairs returns the labelled pairs bundled with Miniflux.
+func BuiltinPairs() ([]*Pair, error) {
+	var pairs []*Pair
+	if err := json.Unmarshal(builtinFixtures, &pairs); err != nil {
+		return nil, fmt.Errorf("benchmark: unable to parse builtin fixtures: %v", err)
+	}
+	return pairs, nil
+}
+
+// ParsePairs reads labelled pairs from a JSON array.
+func ParsePairs(r io.Reader) ([]*Pair, error) {
+	var pairs []*Pair
+	if err := json.NewDecoder(r).Decode(&pairs); err != nil {
+		return nil, fmt.Errorf("benchmark: unable to parse fixtures: %v", err)
+	}
+	if len(pairs) == 0 {
+		return nil, fmt.Errorf("benchmark: no labelled pairs found")
+	}
+	return pairs, nil
+}
+
+// Run scores the pairs with each of the given algorithms.
+// Algorithms supporting a corpus use the document frequencies of the fixture articles.
+func Run(algorithms []string, pairs []*Pair, threshold float64) ([]*Result, error) {
+	corpus := newCorpus(pairs)
+	results := make([]*Result, 0, len(algorithms))
+	for _, algorithm := range algorithms {
+		comparator, err := similarity.NewComparator(algorithm, corpus)
+		if err != nil {
+			return nil, err
+		}
+
+		start := time.Now()
+		scores := make([]float64, len(pairs))
+		for i, pair := range pairs {
+			scores[i], err = comparator.Compare(pair.Article1, pair.Article2)
+			if err != nil {
+				return nil, fmt.Errorf("benchmark: unable to compare %q with %s: %v", pair.Name, algorithm, err)
+			}
+		}
+
+		result := &Result{Algorithm: algorithm, Duration: time.Since(start)}
+		result.Precision, result.Recall, result.F1 = evaluate(pairs, scores, threshold)
+		result.MeanSimilar, result.MeanDissimilar = means(pairs, scores)
+		result.BestThreshold, result.BestF1 = bestThreshold(pairs, scores)
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// evaluate returns the precision, recall and F1 score of the predictions made at the threshold.
+func evaluate(pairs []*Pair, scores []float64, threshold float64) (precision, recall, f1 float64) {
+	var truePositives, falsePositives, falseNegatives int
+	for i, pair := range pairs {
+		predicted := scores[i] >= threshold
+		switch {
+		case predicted && pair.Similar:
+			truePositives++
+		case predicted && !pair.Similar:
+			falsePositives++
+		case !predicted && pair.Similar:
+			falseNegatives++
+		}
+	}
+	if truePositives > 0 {
+		precision = float64(truePositives) / float64(truePositives+falsePositives)
+		recall = float64(truePositives) / float64(truePositives+falseNegatives)
+		f1 = 2 * precision * recall / (precision + recall)
+	}
+	return precision, recall, f1
+}
+
+func means(pairs []*Pair, scores []float64) (similar, dissimilar float64) {
+	var similarCount, dissimilarCount int
+	for i, pair := range pairs {
+		if pair.Similar {
+			similar += scores[i]
+			similarCount++
+		} else {
+			dissimilar += scores[i]
+			dissimilarCount++
+		}
+	}
+	if similarCount > 0 {
+		similar /= float64(similarCount)
+	}
+	if dissimilarCount > 0 {
+		dissimilar /= float64(dissimilarCount)
+	}
+	return similar, dissimilar
+}
+
+// bestThreshold tries each score as a threshold and keeps the one with the highest F1 score.
+func bestThreshold(pairs []*Pair, scores []float64) (threshold, f1 float64) {
+	candidates := append([]float64(nil), scores...)
+	sort.Float64s(candidates)
+	for _, candidate := range candidates {
+		if _, _, score := evaluate(pairs, scores, candidate); score > f1 {
+			threshold, f1 = candidate, score
+		}
+	}
+	return threshold, f1
+}
+
+// corpus is an in-memory v2.Corpus made of the distinct fixture articles.
+type corpus struct {
+	documents   int
+	frequencies map[string]int
+}
+
+func newCorpus(pairs []*Pair) v2.Corpus {
+	c := &corpus{frequencies: make(map[string]int)}
+	seen := make(map[string]bool)
+	for _, pair := range pairs {
+		for _, article := range []string{pair.Article1, pair.Article2} {
+			if seen[article] {
+				continue
+			}
+			seen[article] = true
+			c.documents++
+			for _, term := range v2.Terms(article) {
+				c.frequencies[term]++
+			}
+		}
+	}
+	return c
+}
+
+func (c *corpus) DocumentCount() int {
+	return c.documents
+}
+
+func (c *corpus) DocumentFrequencies(terms []string) (map[string]int, error) {
+	frequencies := make(map[string]int, len(terms))
+	for _, term := range terms {
+		frequencies[term] = c.frequencies[term]
+	}
+	return frequencies, nil
+}
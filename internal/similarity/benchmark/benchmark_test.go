@@ -0,0 +1,83 @@
+package benchmark
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBuiltinPairs(t *testing.T) {
+	pairs, err := BuiltinPairs()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	var similar, dissimilar int
+	for _, pair := range pairs {
+		if pair.Similar {
+			similar++
+		} else {
+			dissimilar++
+		}
+	}
+	if similar == 0 || dissimilar == 0 {
+		t.Errorf("Expected both similar and dissimilar pairs, got %d and %d", similar, dissimilar)
+	}
+}
+
+func TestParsePairs(t *testing.T) {
+	pairs, err := ParsePairs(strings.NewReader(`[{"name": "test", "article1": "a", "article2": "b", "similar": true}]`))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(pairs) != 1 || pairs[0].Name != "test" || !pairs[0].Similar {
+		t.Errorf("Unexpected pairs: %+v", pairs)
+	}
+
+	if _, err := ParsePairs(strings.NewReader(`[]`)); err == nil {
+		t.Error("Expected an error without pairs")
+	}
+}
+
+func TestRun(t *testing.T) {
+	pairs, err := BuiltinPairs()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	results, err := Run([]string{"v2", "minhash", "simhash"}, pairs, 0.4)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(results) != 3 {
+		t.Fatalf("Expected 3 results, got %d", len(results))
+	}
+	for _, result := range results {
+		if result.MeanSimilar <= result.MeanDissimilar {
+			t.Errorf("Expected %s to score similar pairs higher, got %f and %f", result.Algorithm, result.MeanSimilar, result.MeanDissimilar)
+		}
+		if result.BestF1 < result.F1 {
+			t.Errorf("Expected the best F1 of %s to be at least the F1 at the threshold", result.Algorithm)
+		}
+	}
+}
+
+func TestRunWithUnknownAlgorithm(t *testing.T) {
+	if _, err := Run([]string{"unknown"}, []*Pair{{Article1: "a", Article2: "b"}}, 0.4); err == nil {
+		t.Error("Expected an error for an unknown algorithm")
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	pairs := []*Pair{{Similar: true}, {Similar: true}, {Similar: false}, {Similar: false}}
+	scores := []float64{0.9, 0.3, 0.6, 0.1}
+
+	precision, recall, f1 := evaluate(pairs, scores, 0.5)
+	if precision != 0.5 || recall != 0.5 || f1 != 0.5 {
+		t.Errorf("Unexpected precision %f, recall %f or F1 %f", precision, recall, f1)
+	}
+
+	threshold, best := bestThreshold(pairs, scores)
+	if threshold != 0.3 || best != 0.8 {
+		t.Errorf("Unexpected best threshold %f with F1 %f", threshold, best)
+	}
+}
@@ -0,0 +1,88 @@
+package similarity
+
+import (
+	"log/slog"
+	"sync"
+
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/similarity/story"
+	"miniflux.app/v2/internal/similarity/v2"
+	"miniflux.app/v2/internal/storage"
+)
+
+// storedCorpus is a v2.Corpus backed by the user's document frequency table.
+// Frequencies are cached for the lifetime of the corpus.
+type storedCorpus struct {
+	store         *storage.Storage
+	userID        int64
+	mu            sync.Mutex
+	documentCount int
+	frequencies   map[string]int
+}
+
+// NewStoredCorpus returns the persistent similarity corpus of the given user.
+func NewStoredCorpus(store *storage.Storage, userID int64) (v2.Corpus, error) {
+	count, err := store.SimilarityDocumentCount(userID)
+	if err != nil {
+		return nil, err
+	}
+	return &storedCorpus{
+		store:         store,
+		userID:        userID,
+		documentCount: count,
+		frequencies:   make(map[string]int),
+	}, nil
+}
+
+// DocumentCount returns the number of documents in the corpus.
+func (c *storedCorpus) DocumentCount() int {
+	return c.documentCount
+}
+
+// DocumentFrequencies returns the number of documents containing each of the given terms.
+func (c *storedCorpus) DocumentFrequencies(terms []string) (map[string]int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	missing := make([]string, 0)
+	for _, term := range terms {
+		if _, found := c.frequencies[term]; !found {
+			missing = append(missing, term)
+		}
+	}
+
+	if len(missing) > 0 {
+		frequencies, err := c.store.SimilarityTermFrequencies(c.userID, missing)
+		if err != nil {
+			return nil, err
+		}
+		for _, term := range missing {
+			c.frequencies[term] = frequencies[term]
+		}
+	}
+
+	result := make(map[string]int, len(terms))
+	for _, term := range terms {
+		result[term] = c.frequencies[term]
+	}
+	return result, nil
+}
+
+// AddDocument adds the given entry to the user's similarity corpus.
+func AddDocument(store *storage.Storage, userID int64, entry *model.Entry) error {
+	return store.AddSimilarityDocument(userID, entry.ID, v2.Terms(story.FromEntry(entry).Content))
+}
+
+// AddDocuments adds the given entries to the user's similarity corpus.
+func AddDocuments(store *storage.Storage, userID int64, entries model.Entries) error {
+	for _, entry := range entries {
+		if err := AddDocument(store, userID, entry); err != nil {
+			return err
+		}
+	}
+	slog.Debug("Added entries to the similarity corpus",
+		slog.Int64("user_id", userID),
+		slog.Int("entries", len(entries)),
+	)
+	return nil
+}
@@ -0,0 +1,45 @@
+package similarity
+
+import (
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/storage"
+)
+
+// ApplyDuplicateAction marks as read or removes, according to the user preferences,
+// the given entries covering a story the user already read. It returns the number of affected entries.
+//
+// Duplicates are looked up in the similar pairs stored during feed refreshes, so nothing is found
+// when SIMILARITY_WINDOW_HOURS is 0, nor below the SIMILARITY_THRESHOLD of the server.
+func ApplyDuplicateAction(store *storage.Storage, user *model.User, entries model.Entries) (int, error) {
+	var status string
+	switch user.DuplicateEntriesAction {
+	case model.DuplicateEntriesActionRead:
+		status = model.EntryStatusRead
+	case model.DuplicateEntriesActionRemove:
+		status = model.EntryStatusRemoved
+	default:
+		return 0, nil
+	}
+
+	if len(entries) == 0 {
+		return 0, nil
+	}
+
+	entryIDs := make([]int64, 0, len(entries))
+	for _, entry := range entries {
+		entryIDs = append(entryIDs, entry.ID)
+	}
+
+	duplicateIDs, err := store.DuplicateEntryIDs(user.ID, entryIDs, user.DuplicateEntriesThreshold, user.DuplicateEntriesCategoryIDs)
+	if err != nil {
+		return 0, err
+	}
+	if len(duplicateIDs) == 0 {
+		return 0, nil
+	}
+
+	if err := store.SetEntriesStatus(user.ID, duplicateIDs, status); err != nil {
+		return 0, err
+	}
+	return len(duplicateIDs), nil
+}
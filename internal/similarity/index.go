@@ -0,0 +1,99 @@
+package similarity
+
+import (
+	"context"
+	"time"
+
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/similarity/minhash"
+	"miniflux.app/v2/internal/similarity/story"
+	"miniflux.app/v2/internal/similarity/v2"
+	"miniflux.app/v2/internal/storage"
+)
+
+// Index finds the entries likely to be similar to a given entry, so that only plausible pairs are compared.
+type Index interface {
+	// Add indexes a story so it can be found as a candidate of newer stories.
+	Add(stor *story.Story) error
+	// Candidates returns the indexed entries older than the given story that may be similar to it.
+	Candidates(stor *story.Story) (model.Entries, error)
+}
+
+// storedIndex is a MinHash locality-sensitive hashing Index backed by the entry signatures table.
+type storedIndex struct {
+	store      *storage.Storage
+	userID     int64
+	window     time.Duration
+	windowSize int
+}
+
+// NewStoredIndex returns the persistent similarity index of the given user.
+// When window is positive, only candidates published within the window are returned, at most windowSize of them.
+func NewStoredIndex(store *storage.Storage, userID int64, window time.Duration, windowSize int) Index {
+	return &storedIndex{store: store, userID: userID, window: window, windowSize: windowSize}
+}
+
+// Add stores the bucket of each signature band of the story.
+func (i *storedIndex) Add(stor *story.Story) error {
+	return i.store.UpdateEntrySignature(i.userID, stor.ID, buckets(stor))
+}
+
+// Candidates returns the entries sharing at least one bucket with the given story.
+func (i *storedIndex) Candidates(stor *story.Story) (model.Entries, error) {
+	entryIDs, err := i.store.SimilarityCandidates(i.userID, stor.ID, buckets(stor))
+	if err != nil {
+		return nil, err
+	}
+	if len(entryIDs) == 0 {
+		return nil, nil
+	}
+
+	builder := i.store.NewEntryQueryBuilder(i.userID)
+	builder.WithEntryIDs(entryIDs)
+	builder.WithoutStatus(model.EntryStatusRemoved)
+	if i.window > 0 {
+		builder.AfterPublishedDate(time.Now().Add(-i.window))
+		builder.WithSorting("e.published_at", "DESC")
+		builder.WithLimit(i.windowSize)
+	}
+	return builder.GetEntries()
+}
+
+func buckets(stor *story.Story) []int64 {
+	return minhash.NewTermSignature(v2.Terms(stor.Content)).Buckets()
+}
+
+// IndexEntries adds the given entries to the user's similarity index.
+func IndexEntries(index Index, entries model.Entries) error {
+	for _, entry := range entries {
+		if err := index.Add(story.FromEntry(entry)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// BackfillIndex adds to the user's similarity corpus and index the entries created before they were maintained.
+// It returns the number of entries indexed, and stops when the context is cancelled.
+func BackfillIndex(ctx context.Context, store *storage.Storage, userID int64) (int, error) {
+	index := NewStoredIndex(store, userID, 0, 0)
+	builder := store.NewEntryQueryBuilder(userID)
+	builder.WithoutStatus(model.EntryStatusRemoved)
+	builder.WithoutSimilaritySignature()
+
+	count := 0
+	err := builder.EntryProcessor(func(entry *model.Entry) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := AddDocument(store, userID, entry); err != nil {
+			return err
+		}
+		if err := index.Add(story.FromEntry(entry)); err != nil {
+			return err
+		}
+		count++
+		return nil
+	})
+	return count, err
+}
@@ -0,0 +1,148 @@
+package minhash
+
+import (
+	"hash/fnv"
+	"math"
+	"strings"
+	"unicode"
+)
+
+const (
+	// NumHashes is the number of hash functions, which is also the length of a signature.
+	NumHashes = 128
+
+	// shingleSize is the number of consecutive words in a shingle.
+	shingleSize = 2
+
+	// BandRows is the number of hashes per LSH band.
+	// With 64 bands of 2 rows, pairs with a Jaccard similarity of 0.2 collide in at least one band 93% of the time.
+	BandRows = 2
+)
+
+// seeds holds one seed per hash function, generated deterministically so signatures can be stored.
+var seeds = func() [NumHashes]uint64 {
+	var s [NumHashes]uint64
+	state := uint64(0x6d696e6966687578)
+	for i := range s {
+		state += 0x9e3779b97f4a7c15
+		s[i] = mix(state)
+	}
+	return s
+}()
+
+// Signature is the MinHash signature of a text.
+type Signature []uint64
+
+type Comparator interface {
+	Compare(article1, article2 string) (float64, error)
+}
+
+type compare struct{}
+
+// NewComparator returns a Comparator estimating the Jaccard similarity of word shingles.
+func NewComparator() Comparator {
+	return &compare{}
+}
+
+// Compare compares two articles and returns the estimated Jaccard similarity of their shingles.
+func (c *compare) Compare(article1, article2 string) (float64, error) {
+	return Similarity(NewSignature(article1), NewSignature(article2)), nil
+}
+
+// NewSignature returns the MinHash signature of a text, or nil when the text has no words.
+func NewSignature(text string) Signature {
+	return signature(Shingles(text))
+}
+
+// NewTermSignature returns the MinHash signature of a set of terms, or nil when there are no terms.
+func NewTermSignature(terms []string) Signature {
+	shingles := make([]uint64, 0, len(terms))
+	for _, term := range terms {
+		h := fnv.New64a()
+		h.Write([]byte(term))
+		shingles = append(shingles, h.Sum64())
+	}
+	return signature(shingles)
+}
+
+func signature(shingles []uint64) Signature {
+	if len(shingles) == 0 {
+		return nil
+	}
+
+	signature := make(Signature, NumHashes)
+	for i := range signature {
+		signature[i] = math.MaxUint64
+	}
+	for _, shingle := range shingles {
+		for i, seed := range seeds {
+			if h := mix(shingle ^ seed); h < signature[i] {
+				signature[i] = h
+			}
+		}
+	}
+	return signature
+}
+
+// Buckets returns the locality-sensitive hashing bucket of each band of the signature.
+// The band index is part of the bucket, so two signatures share a bucket only if the same band matches.
+func (s Signature) Buckets() []int64 {
+	buckets := make([]int64, 0, len(s)/BandRows)
+	for band := 0; band+BandRows <= len(s); band += BandRows {
+		bucket := uint64(band)
+		for _, h := range s[band : band+BandRows] {
+			bucket = mix(bucket ^ h)
+		}
+		buckets = append(buckets, int64(bucket))
+	}
+	return buckets
+}
+
+// Similarity returns the fraction of matching hashes, an estimate of the Jaccard similarity.
+func Similarity(a, b Signature) float64 {
+	if len(a) == 0 || len(a) != len(b) {
+		return 0
+	}
+	matches := 0
+	for i := range a {
+		if a[i] == b[i] {
+			matches++
+		}
+	}
+	return float64(matches) / float64(len(a))
+}
+
+// Shingles returns the distinct hashed shingles of a text.
+// Texts shorter than a shingle produce a single shingle made of all their words.
+func Shingles(text string) []uint64 {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+	if len(words) == 0 {
+		return nil
+	}
+
+	size := min(shingleSize, len(words))
+	seen := make(map[uint64]bool)
+	shingles := make([]uint64, 0, len(words)-size+1)
+	for i := 0; i+size <= len(words); i++ {
+		h := fnv.New64a()
+		h.Write([]byte(strings.Join(words[i:i+size], " ")))
+		shingle := h.Sum64()
+		if !seen[shingle] {
+			seen[shingle] = true
+			shingles = append(shingles, shingle)
+		}
+	}
+	return shingles
+}
+
+// mix is the splitmix64 finalizer, used to derive independent hash functions from a single shingle hash.
+func mix(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
@@ -0,0 +1,101 @@
+package minhash
+
+import (
+	"testing"
+)
+
+func TestCompareIdenticalArticles(t *testing.T) {
+	comparator := NewComparator()
+	text := "The city council approved the new budget after a long vote on Monday."
+
+	similarity, err := comparator.Compare(text, text)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if similarity != 1 {
+		t.Errorf("Expected identical articles to score 1, got %f", similarity)
+	}
+}
+
+func TestCompareRelatedArticlesScoreHigherThanUnrelated(t *testing.T) {
+	comparator := NewComparator()
+	article := "The city council approved the new budget after a long vote on Monday evening."
+	related := "On Monday evening the city council approved the new budget after a long vote."
+	unrelated := "A rare comet will be visible from the northern hemisphere later this week."
+
+	relatedScore, _ := comparator.Compare(article, related)
+	unrelatedScore, _ := comparator.Compare(article, unrelated)
+	if relatedScore <= unrelatedScore {
+		t.Errorf("Expected related articles to score higher, got %f and %f", relatedScore, unrelatedScore)
+	}
+	if unrelatedScore > 0.1 {
+		t.Errorf("Expected unrelated articles to score close to 0, got %f", unrelatedScore)
+	}
+}
+
+func TestCompareEmptyArticle(t *testing.T) {
+	comparator := NewComparator()
+
+	similarity, err := comparator.Compare("", "")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if similarity != 0 {
+		t.Errorf("Expected empty articles to score 0, got %f", similarity)
+	}
+}
+
+func TestSignatureIsDeterministic(t *testing.T) {
+	a := NewSignature("Some short text")
+	b := NewSignature("some SHORT text!")
+
+	if len(a) != NumHashes {
+		t.Fatalf("Expected a signature of %d hashes, got %d", NumHashes, len(a))
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			t.Fatalf("Expected signatures to ignore case and punctuation")
+		}
+	}
+}
+
+func TestShinglesOfShortText(t *testing.T) {
+	if shingles := Shingles("two words"); len(shingles) != 1 {
+		t.Errorf("Expected a single shingle, got %d", len(shingles))
+	}
+	if shingles := Shingles("one two three one two three"); len(shingles) != 3 {
+		t.Errorf("Expected 3 distinct shingles, got %d", len(shingles))
+	}
+}
+
+func TestBuckets(t *testing.T) {
+	signature := NewTermSignature([]string{"council", "budget", "vote", "transit"})
+
+	buckets := signature.Buckets()
+	if len(buckets) != NumHashes/BandRows {
+		t.Fatalf("Expected %d buckets, got %d", NumHashes/BandRows, len(buckets))
+	}
+
+	same := NewTermSignature([]string{"transit", "vote", "budget", "council"}).Buckets()
+	for i := range buckets {
+		if buckets[i] != same[i] {
+			t.Fatalf("Expected the buckets of the same terms to be identical")
+		}
+	}
+
+	other := make(map[int64]bool)
+	for _, bucket := range NewTermSignature([]string{"comet", "astronomers", "hemisphere", "binoculars"}).Buckets() {
+		other[bucket] = true
+	}
+	for _, bucket := range buckets {
+		if other[bucket] {
+			t.Errorf("Expected disjoint terms not to share any bucket")
+		}
+	}
+}
+
+func TestBucketsOfEmptySignature(t *testing.T) {
+	if buckets := NewTermSignature(nil).Buckets(); len(buckets) != 0 {
+		t.Errorf("Expected no buckets, got %d", len(buckets))
+	}
+}
@@ -1,6 +1,8 @@
 package pool
 
 import (
+	"context"
+	"sort"
 	"sync"
 )
 
@@ -8,7 +10,7 @@ import (
 type Task[T any, R any] struct {
 	ID       int
 	Data     T
-	Function func(T) (R, error)
+	Function func(context.Context, T) (R, error)
 }
 
 // Result defines the structure for the result returned by the thread pool.
@@ -17,120 +19,124 @@ type Result[R any] struct {
 	Value R
 }
 
-// Worker represents a single worker in the thread pool.
-type Worker[T any, R any] struct {
-	ID         int
-	TaskQueue  chan Task[T, R]
-	ResultChan chan Result[R]
-	ErrChan    chan error
-	WorkerPool chan chan Task[T, R]
-	QuitChan   chan bool
-}
+// ProgressFunc is called each time a task completes, with the number of completed and submitted tasks.
+type ProgressFunc func(completed, submitted int)
 
-// ThreadPool represents the thread pool.
+// ThreadPool runs tasks on a fixed number of workers.
+// The first task error cancels the pool, the remaining tasks are skipped.
 type ThreadPool[T any, R any] struct {
-	TaskQueue  chan Task[T, R]
-	ResultChan chan Result[R]
-	ErrChan    chan error
-	WorkerPool chan chan Task[T, R]
-	Workers    []Worker[T, R]
-	QuitChan   chan bool
+	numWorkers int
+	progress   ProgressFunc
+	tasks      chan Task[T, R]
+	ctx        context.Context
+	cancel     context.CancelFunc
 	wg         sync.WaitGroup
+
+	mu        sync.Mutex
+	results   []Result[R]
+	err       error
+	submitted int
+	completed int
+}
+
+// NewThreadPool creates a new thread pool bound to the given context.
+func NewThreadPool[T any, R any](ctx context.Context, numWorkers int) *ThreadPool[T, R] {
+	if numWorkers < 1 {
+		numWorkers = 1
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	return &ThreadPool[T, R]{
+		numWorkers: numWorkers,
+		tasks:      make(chan Task[T, R]),
+		ctx:        ctx,
+		cancel:     cancel,
+	}
 }
 
-// NewWorker creates a new worker.
-func NewWorker[T any, R any](id int, workerPool chan chan Task[T, R], resultChan chan Result[R], errChan chan error) Worker[T, R] {
-	return Worker[T, R]{
-		ID:         id,
-		TaskQueue:  make(chan Task[T, R]),
-		ResultChan: resultChan,
-		ErrChan:    errChan,
-		WorkerPool: workerPool,
-		QuitChan:   make(chan bool),
+// OnProgress registers a function called each time a task completes.
+// It must be called before Start, and may be called concurrently by the workers.
+func (tp *ThreadPool[T, R]) OnProgress(progress ProgressFunc) {
+	tp.progress = progress
+}
+
+// Start starts the workers.
+func (tp *ThreadPool[T, R]) Start() {
+	for i := 0; i < tp.numWorkers; i++ {
+		tp.wg.Add(1)
+		go tp.work()
 	}
 }
 
-// Start starts the worker.
-func (w Worker[T, R]) Start(wg *sync.WaitGroup) {
-	go func() {
-		defer wg.Done()
-		for {
-			// Add this worker's task queue to the worker pool.
-			w.WorkerPool <- w.TaskQueue
-
-			select {
-			case task := <-w.TaskQueue:
-				result, err := task.Function(task.Data)
-				if err != nil {
-					w.ErrChan <- err
-				} else {
-					w.ResultChan <- Result[R]{ID: task.ID, Value: result}
-				}
-			case <-w.QuitChan:
-				return
+func (tp *ThreadPool[T, R]) work() {
+	defer tp.wg.Done()
+	for task := range tp.tasks {
+		if tp.ctx.Err() != nil {
+			continue
+		}
+
+		value, err := task.Function(tp.ctx, task.Data)
+
+		tp.mu.Lock()
+		if err != nil {
+			if tp.err == nil {
+				tp.err = err
+				tp.cancel()
 			}
+			tp.mu.Unlock()
+			continue
 		}
-	}()
-}
+		tp.results = append(tp.results, Result[R]{ID: task.ID, Value: value})
+		tp.completed++
+		completed, submitted := tp.completed, tp.submitted
+		tp.mu.Unlock()
 
-// Stop stops the worker.
-func (w Worker[T, R]) Stop() {
-	go func() {
-		w.QuitChan <- true
-	}()
+		if tp.progress != nil {
+			tp.progress(completed, submitted)
+		}
+	}
 }
 
-// NewThreadPool creates a new thread pool.
-func NewThreadPool[T any, R any](numWorkers int, buffer int) *ThreadPool[T, R] {
-	taskQueue := make(chan Task[T, R], buffer) // Buffered channel to hold a large queue of tasks
-	resultChan := make(chan Result[R], buffer)
-	errChan := make(chan error, buffer)
-	workerPool := make(chan chan Task[T, R], numWorkers)
-	quitChan := make(chan bool)
-
-	workers := make([]Worker[T, R], numWorkers)
-	for i := 0; i < numWorkers; i++ {
-		workers[i] = NewWorker(i, workerPool, resultChan, errChan)
+// Submit queues a task, blocking until a worker is available.
+// It returns an error once the pool is cancelled, either by a failed task or by its context.
+func (tp *ThreadPool[T, R]) Submit(task Task[T, R]) error {
+	if tp.ctx.Err() != nil {
+		return tp.failure()
 	}
 
-	return &ThreadPool[T, R]{
-		TaskQueue:  taskQueue,
-		ResultChan: resultChan,
-		ErrChan:    errChan,
-		WorkerPool: workerPool,
-		Workers:    workers,
-		QuitChan:   quitChan,
+	tp.mu.Lock()
+	tp.submitted++
+	tp.mu.Unlock()
+
+	select {
+	case tp.tasks <- task:
+		return nil
+	case <-tp.ctx.Done():
+		return tp.failure()
 	}
 }
 
-// Start starts the thread pool.
-func (tp *ThreadPool[T, R]) Start() {
-	for _, worker := range tp.Workers {
-		tp.wg.Add(1)
-		worker.Start(&tp.wg)
+// Wait stops accepting tasks, waits for the running ones and returns the results ordered by task ID.
+// The error is the first task error, or the context error when the pool was cancelled.
+func (tp *ThreadPool[T, R]) Wait() ([]Result[R], error) {
+	close(tp.tasks)
+	tp.wg.Wait()
+	defer tp.cancel()
+
+	if err := tp.failure(); err != nil {
+		return nil, err
 	}
 
-	go func() {
-		for {
-			select {
-			case task := <-tp.TaskQueue:
-				// Get a worker's task queue from the worker pool.
-				taskQueue := <-tp.WorkerPool
-				taskQueue <- task
-			case <-tp.QuitChan:
-				for _, worker := range tp.Workers {
-					worker.Stop()
-				}
-				return
-			}
-		}
-	}()
+	sort.Slice(tp.results, func(i, j int) bool {
+		return tp.results[i].ID < tp.results[j].ID
+	})
+	return tp.results, nil
 }
 
-// Stop stops the thread pool.
-func (tp *ThreadPool[T, R]) Stop() {
-	tp.QuitChan <- true
-	tp.wg.Wait()
-	close(tp.ResultChan)
-	close(tp.ErrChan)
+func (tp *ThreadPool[T, R]) failure() error {
+	tp.mu.Lock()
+	defer tp.mu.Unlock()
+	if tp.err != nil {
+		return tp.err
+	}
+	return tp.ctx.Err()
 }
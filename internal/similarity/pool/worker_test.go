@@ -1,82 +1,126 @@
 package pool
 
 import (
-	"sync"
+	"context"
+	"errors"
+	"sync/atomic"
 	"testing"
 	"time"
 )
 
 // Example task function for testing
-func testTaskFunction(data int) (int, error) {
-	time.Sleep(10 * time.Millisecond) // Simulate some work
-	result := data * data             // Example task: square the number
+func testTaskFunction(ctx context.Context, data int) (int, error) {
+	time.Sleep(time.Millisecond) // Simulate some work
+	result := data * data        // Example task: square the number
 	return result, nil
 }
 
 func TestThreadPool(t *testing.T) {
 	numWorkers := 10
 	numTasks := 300
-	buffer := 200
 
-	threadPool := NewThreadPool[int, int](numWorkers, buffer)
+	threadPool := NewThreadPool[int, int](context.Background(), numWorkers)
 	threadPool.Start()
 
-	var taskWG sync.WaitGroup
-	taskWG.Add(numTasks)
-
-	results := make(map[int]int)
-	resultsMutex := sync.Mutex{}
-	errors := make([]error, 0)
-
-	// Create tasks and add them to the task queue.
 	for i := 1; i <= numTasks; i++ {
 		task := Task[int, int]{
 			ID:       i,
 			Data:     i,
 			Function: testTaskFunction,
 		}
-		threadPool.TaskQueue <- task
+		if err := threadPool.Submit(task); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
 	}
 
-	// Collect results.
-	go func() {
-		for result := range threadPool.ResultChan {
-			resultsMutex.Lock()
-			results[result.ID] = result.Value
-			resultsMutex.Unlock()
-			taskWG.Done()
-		}
-	}()
-
-	// Collect errors.
-	go func() {
-		for err := range threadPool.ErrChan {
-			resultsMutex.Lock()
-			errors = append(errors, err)
-			resultsMutex.Unlock()
-			taskWG.Done()
+	results, err := threadPool.Wait()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(results) != numTasks {
+		t.Fatalf("Expected %d results, but got %d", numTasks, len(results))
+	}
+
+	for i, result := range results {
+		expected := (i + 1) * (i + 1)
+		if result.ID != i+1 || result.Value != expected {
+			t.Errorf("Result %d: expected task %d with %d, but got task %d with %d", i, i+1, expected, result.ID, result.Value)
 		}
-	}()
+	}
+}
 
-	// Wait for all tasks to complete.
-	taskWG.Wait()
+func TestThreadPoolProgress(t *testing.T) {
+	threadPool := NewThreadPool[int, int](context.Background(), 4)
 
-	// Stop the thread pool.
-	threadPool.Stop()
+	var calls, lastCompleted atomic.Int64
+	threadPool.OnProgress(func(completed, submitted int) {
+		calls.Add(1)
+		if completed > submitted {
+			t.Errorf("Completed %d tasks out of %d submitted", completed, submitted)
+		}
+		if int64(completed) > lastCompleted.Load() {
+			lastCompleted.Store(int64(completed))
+		}
+	})
+	threadPool.Start()
 
-	// Check results
-	if len(errors) != 0 {
-		t.Fatalf("Expected no errors, but got %d errors: %v", len(errors), errors)
+	for i := 1; i <= 20; i++ {
+		threadPool.Submit(Task[int, int]{ID: i, Data: i, Function: testTaskFunction})
+	}
+	if _, err := threadPool.Wait(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
 	}
 
-	if len(results) != numTasks {
-		t.Fatalf("Expected %d results, but got %d", numTasks, len(results))
+	if calls.Load() != 20 || lastCompleted.Load() != 20 {
+		t.Errorf("Expected 20 progress calls up to 20 completed tasks, got %d calls up to %d", calls.Load(), lastCompleted.Load())
 	}
+}
 
-	for i := 1; i <= numTasks; i++ {
-		expected := i * i
-		if results[i] != expected {
-			t.Errorf("Task %d: expected result %d, but got %d", i, expected, results[i])
+func TestThreadPoolError(t *testing.T) {
+	expectedErr := errors.New("task failure")
+	var executed atomic.Int64
+
+	threadPool := NewThreadPool[int, int](context.Background(), 2)
+	threadPool.Start()
+
+	for i := 1; i <= 100; i++ {
+		err := threadPool.Submit(Task[int, int]{ID: i, Data: i, Function: func(ctx context.Context, data int) (int, error) {
+			executed.Add(1)
+			if data == 5 {
+				return 0, expectedErr
+			}
+			return testTaskFunction(ctx, data)
+		}})
+		if err != nil {
+			if !errors.Is(err, expectedErr) {
+				t.Fatalf("Expected the task error, got %v", err)
+			}
+			break
 		}
 	}
+
+	if _, err := threadPool.Wait(); !errors.Is(err, expectedErr) {
+		t.Fatalf("Expected the task error, got %v", err)
+	}
+	if executed.Load() == 100 {
+		t.Errorf("Expected the remaining tasks to be skipped after the failure")
+	}
+}
+
+func TestThreadPoolCancellation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	threadPool := NewThreadPool[int, int](ctx, 2)
+	threadPool.Start()
+
+	threadPool.Submit(Task[int, int]{ID: 1, Data: 1, Function: testTaskFunction})
+	cancel()
+
+	if err := threadPool.Submit(Task[int, int]{ID: 2, Data: 2, Function: testTaskFunction}); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected submit to fail once cancelled, got %v", err)
+	}
+	if _, err := threadPool.Wait(); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected the context error, got %v", err)
+	}
 }
@@ -0,0 +1,27 @@
+package similarity
+
+import (
+	"miniflux.app/v2/internal/similarity/v2"
+	"miniflux.app/v2/internal/storage"
+)
+
+// SemanticQuery returns the distinct terms of a search text with their weights in the user's corpus,
+// as expected by the semantic search of the entry query builder.
+func SemanticQuery(store *storage.Storage, userID int64, text string) ([]string, []float64, error) {
+	corpus, err := NewStoredCorpus(store, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	terms := v2.Terms(text)
+	weights, err := v2.QueryWeights(corpus, terms)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	values := make([]float64, len(terms))
+	for i, term := range terms {
+		values[i] = weights[term]
+	}
+	return terms, values, nil
+}
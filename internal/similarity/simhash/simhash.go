@@ -0,0 +1,76 @@
+package simhash
+
+import (
+	"hash/fnv"
+	"math/bits"
+	"strings"
+	"unicode"
+)
+
+type Comparator interface {
+	Compare(article1, article2 string) (float64, error)
+}
+
+type compare struct{}
+
+// NewComparator returns a Comparator based on the hamming distance of 64-bit SimHash fingerprints.
+func NewComparator() Comparator {
+	return &compare{}
+}
+
+// Compare compares two articles and returns the similarity of their fingerprints.
+// Unrelated texts differ on about half of the bits, so the score is rescaled for them to get 0.
+func (c *compare) Compare(article1, article2 string) (float64, error) {
+	words1 := words(article1)
+	words2 := words(article2)
+	if len(words1) == 0 || len(words2) == 0 {
+		return 0, nil
+	}
+
+	distance := bits.OnesCount64(fingerprint(words1) ^ fingerprint(words2))
+	return max(0, 1-2*float64(distance)/64), nil
+}
+
+// Fingerprint returns the SimHash fingerprint of a text.
+func Fingerprint(text string) uint64 {
+	return fingerprint(words(text))
+}
+
+// fingerprint sums the hashes of the words, weighted by their frequency, bit by bit.
+func fingerprint(words []string) uint64 {
+	var weights [64]int
+	for _, word := range words {
+		h := fnv.New64a()
+		h.Write([]byte(word))
+		hash := h.Sum64()
+		for i := range weights {
+			if hash&(1<<i) != 0 {
+				weights[i]++
+			} else {
+				weights[i]--
+			}
+		}
+	}
+
+	var fingerprint uint64
+	for i, weight := range weights {
+		if weight > 0 {
+			fingerprint |= 1 << i
+		}
+	}
+	return fingerprint
+}
+
+// words splits a text into lower case words, without punctuation and stop words.
+func words(text string) []string {
+	fields := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+	filtered := make([]string, 0, len(fields))
+	for _, field := range fields {
+		if !stopWords[field] {
+			filtered = append(filtered, field)
+		}
+	}
+	return filtered
+}
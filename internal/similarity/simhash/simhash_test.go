@@ -0,0 +1,49 @@
+package simhash
+
+import (
+	"testing"
+)
+
+func TestCompareIdenticalArticles(t *testing.T) {
+	comparator := NewComparator()
+	text := "The city council approved the new budget after a long vote on Monday."
+
+	similarity, err := comparator.Compare(text, text)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if similarity != 1 {
+		t.Errorf("Expected identical articles to score 1, got %f", similarity)
+	}
+}
+
+func TestCompareRelatedArticlesScoreHigherThanUnrelated(t *testing.T) {
+	comparator := NewComparator()
+	article := "The city council approved the new budget after a long vote on Monday evening downtown."
+	related := "On Monday evening downtown, the city council approved a new budget after a long vote."
+	unrelated := "A rare comet will be visible from the northern hemisphere later this week, astronomers say."
+
+	relatedScore, _ := comparator.Compare(article, related)
+	unrelatedScore, _ := comparator.Compare(article, unrelated)
+	if relatedScore <= unrelatedScore {
+		t.Errorf("Expected related articles to score higher, got %f and %f", relatedScore, unrelatedScore)
+	}
+}
+
+func TestCompareEmptyArticle(t *testing.T) {
+	comparator := NewComparator()
+
+	similarity, err := comparator.Compare("", "Some text")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if similarity != 0 {
+		t.Errorf("Expected an empty article to score 0, got %f", similarity)
+	}
+}
+
+func TestFingerprintIgnoresCaseAndPunctuation(t *testing.T) {
+	if Fingerprint("Hello, World!") != Fingerprint("hello world") {
+		t.Errorf("Expected fingerprints to ignore case and punctuation")
+	}
+}
@@ -1,6 +1,7 @@
 package similarity
 
 import (
+	"context"
 	"fmt"
 	"log/slog"
 	"miniflux.app/v2/internal/model"
@@ -12,42 +13,76 @@ import (
 )
 
 type calculateSimilarity struct {
+	algorithm string
 	threshold float64
+	corpus    v2.Corpus
+	index     Index
 }
 
 type Similarity interface {
-	Calculate(builder *storage.EntryQueryBuilder) ([]*story.Story, error)
+	Calculate(ctx context.Context, builder *storage.EntryQueryBuilder, workers int, progress ProgressFunc) ([]*story.Story, error)
+	CalculateEntries(entries model.Entries) ([]*story.Story, error)
 }
 
-// NewSimilarity returns a new similarity.
-func NewSimilarity(threshold float64) Similarity {
-	return &calculateSimilarity{threshold: threshold}
+// NewSimilarity returns a new similarity comparing entries with the given algorithm.
+// When a corpus is given, algorithms supporting it weight terms with its inverse document frequencies.
+// When an index is given, entries are only compared with the candidates it returns.
+func NewSimilarity(algorithm string, threshold float64, corpus v2.Corpus, index Index) Similarity {
+	return &calculateSimilarity{algorithm: algorithm, threshold: threshold, corpus: corpus, index: index}
 }
 
+// compare appends to the story the candidates scoring at least the threshold.
+func (s *calculateSimilarity) compare(comp Comparator, story1, story2 *story.Story) error {
+	sim, err := comp.Compare(story1.Content, story2.Content)
+	if err != nil {
+		return err
+	}
+	if sim >= s.threshold {
+		story1.Similar = append(story1.Similar, &story.Similar{
+			Source:     story2,
+			Similarity: sim,
+		})
+	}
+	return nil
+}
+
+// ProgressFunc is called with the number of entries compared so far out of the total.
+type ProgressFunc func(completed, total int)
+
 // processor - Process a given loop of all stories minus the offset
-func (s *calculateSimilarity) processor(builder *storage.EntryQueryBuilder, offset int) func(story1 *story.Story) (*story.Story, error) {
-	return func(story1 *story.Story) (*story.Story, error) {
+func (s *calculateSimilarity) processor(builder *storage.EntryQueryBuilder, offset int) func(ctx context.Context, story1 *story.Story) (*story.Story, error) {
+	return func(ctx context.Context, story1 *story.Story) (*story.Story, error) {
 		subStart := time.Now()
 		iterations := 0
-		builder.WithOffset(offset)
-		comp := v2.NewComparator()
-		err := builder.EntryProcessor(func(entry2 *model.Entry) error {
-			story2 := story.FromEntry(entry2)
-			sim, err := comp.Compare(story1.Content, story2.Content)
+		comp, err := NewComparator(s.algorithm, s.corpus)
+		if err != nil {
+			return nil, err
+		}
+		if s.index != nil {
+			candidates, err := s.index.Candidates(story1)
 			if err != nil {
-				return err
+				return nil, err
 			}
-			if sim >= s.threshold {
-				story1.Similar = append(story1.Similar, &story.Similar{
-					Source:     story2,
-					Similarity: sim,
-				})
+			for _, entry2 := range candidates {
+				if err := ctx.Err(); err != nil {
+					return nil, err
+				}
+				if err := s.compare(comp, story1, story.FromEntry(entry2)); err != nil {
+					return nil, err
+				}
+				iterations++
+			}
+		} else {
+			err = builder.Clone().WithOffset(offset).EntryProcessor(func(entry2 *model.Entry) error {
+				if err := ctx.Err(); err != nil {
+					return err
+				}
+				iterations++
+				return s.compare(comp, story1, story.FromEntry(entry2))
+			})
+			if err != nil {
+				return nil, err
 			}
-			iterations++
-			return nil
-		})
-		if err != nil {
-			return nil, err
 		}
 		slog.Debug("Processed stories",
 			slog.Int("offset", offset),
@@ -57,12 +92,14 @@ func (s *calculateSimilarity) processor(builder *storage.EntryQueryBuilder, offs
 	}
 }
 
-// Calculate calculates the similarity between entries.
-func (s *calculateSimilarity) Calculate(builder *storage.EntryQueryBuilder) ([]*story.Story, error) {
-	threadPool := pool.NewThreadPool[*story.Story, *story.Story](5, 200)
-	threadPool.Start()
+// Calculate calculates the similarity between entries on the given number of workers.
+// It stops at the first error, or when the context is cancelled.
+func (s *calculateSimilarity) Calculate(ctx context.Context, builder *storage.EntryQueryBuilder, workers int, progress ProgressFunc) ([]*story.Story, error) {
+	if _, err := NewComparator(s.algorithm, s.corpus); err != nil {
+		return nil, err
+	}
+
 	builder.WithSorting("id", "asc")
-	offset := 1
 	count, err := builder.CountEntries()
 	if err != nil {
 		return nil, err
@@ -72,41 +109,182 @@ func (s *calculateSimilarity) Calculate(builder *storage.EntryQueryBuilder) ([]*
 	}
 	slog.Debug("Total entries", slog.Int("count", count))
 
+	threadPool := pool.NewThreadPool[*story.Story, *story.Story](ctx, workers)
+	if progress != nil {
+		threadPool.OnProgress(func(completed, submitted int) {
+			progress(completed, count)
+		})
+	}
+	threadPool.Start()
+
+	offset := 1
 	err = builder.EntryProcessor(func(entry1 *model.Entry) error {
-		thisBuilder := builder
 		slog.Debug("Processing entry",
 			slog.Int64("EntryID", entry1.ID),
 			slog.Int("Offset", offset))
-		threadPool.TaskQueue <- pool.Task[*story.Story, *story.Story]{
+		err := threadPool.Submit(pool.Task[*story.Story, *story.Story]{
 			ID:       offset,
 			Data:     story.FromEntry(entry1),
-			Function: s.processor(thisBuilder, offset),
-		}
+			Function: s.processor(builder, offset),
+		})
 		offset++
-		return nil
+		return err
 	})
+
+	// Wait for the submitted tasks even when the submission failed, so that no worker outlives the call.
+	results, poolErr := threadPool.Wait()
+	if poolErr != nil {
+		return nil, poolErr
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	stories := []*story.Story{}
+	for _, result := range results {
+		if len(result.Value.Similar) > 0 {
+			stories = append(stories, result.Value)
+		}
+	}
+	return stories, nil
+}
+
+// CalculateEntries calculates the similarity between the given entries and their candidates in the index.
+// Candidates are older than the compared entry, so that each pair is scored once.
+func (s *calculateSimilarity) CalculateEntries(entries model.Entries) ([]*story.Story, error) {
+	if s.index == nil {
+		return nil, fmt.Errorf("similarity: an index is required to calculate the similarity of new entries")
+	}
+
+	comp, err := NewComparator(s.algorithm, s.corpus)
 	if err != nil {
 		return nil, err
 	}
 
-	// Collect results.
 	stories := []*story.Story{}
-	go func() {
-		for result := range threadPool.ResultChan {
-			if len(result.Value.Similar) > 0 {
-				stories = append(stories, result.Value)
+	for _, entry := range entries {
+		story1 := story.FromEntry(entry)
+		candidates, err := s.index.Candidates(story1)
+		if err != nil {
+			return nil, err
+		}
+		for _, candidate := range candidates {
+			if err := s.compare(comp, story1, story.FromEntry(candidate)); err != nil {
+				return nil, err
 			}
 		}
-	}()
+		if len(story1.Similar) > 0 {
+			stories = append(stories, story1)
+		}
+	}
+	return stories, nil
+}
+
+// RefreshEntries indexes the given entries and scores them against their candidates published within the window, then stores the similar pairs.
+func RefreshEntries(store *storage.Storage, userID int64, entries model.Entries, algorithm string, threshold float64, window time.Duration, windowSize int) error {
+	corpus, err := NewStoredCorpus(store, userID)
+	if err != nil {
+		return err
+	}
+
+	index := NewStoredIndex(store, userID, window, windowSize)
+	stories, err := scoreNewEntries(index, corpus, entries, algorithm, threshold)
+	if err != nil {
+		return err
+	}
+	return SaveStories(store, userID, stories)
+}
+
+// scoreNewEntries indexes the given entries, then scores each of them against its older candidates in the index.
+func scoreNewEntries(index Index, corpus v2.Corpus, entries model.Entries, algorithm string, threshold float64) ([]*story.Story, error) {
+	if err := IndexEntries(index, entries); err != nil {
+		return nil, err
+	}
+	return NewSimilarity(algorithm, threshold, corpus, index).CalculateEntries(entries)
+}
 
-	// Collect errors.
-	go func() {
-		for err := range threadPool.ErrChan {
-			fmt.Printf("Error: %v\n", err)
+// SaveStories stores the similar pairs of the given stories, skipping pairs already known in either direction.
+// Entries of new pairs are merged into the same story cluster.
+func SaveStories(store *storage.Storage, userID int64, stories []*story.Story) error {
+	for _, stor := range stories {
+		sims, err := store.FindSimilarEntries(stor.ID)
+		if err != nil {
+			return err
+		}
+		for _, similar := range newSimilarEntries(stor, sims) {
+			slog.Debug("Found new similar",
+				slog.Int64("entryID", similar.EntryID),
+				slog.Int64("similarEntryID", similar.SimilarEntryID),
+				slog.Float64("similarity", similar.Similarity),
+			)
+			if err := store.CreateSimilarEntry(similar); err != nil {
+				return err
+			}
+			if err := store.MergeEntryClusters(userID, similar.EntryID, similar.SimilarEntryID); err != nil {
+				return err
+			}
 		}
-	}()
+	}
+	return nil
+}
 
-	// Stop the thread pool after processing.
-	threadPool.Stop()
-	return stories, nil
+// newSimilarEntries returns the similar pairs of the given story which are not already known in either direction.
+func newSimilarEntries(stor *story.Story, known []*model.EntrySimilar) []*model.EntrySimilar {
+	var pairs []*model.EntrySimilar
+	for _, similar := range stor.Similar {
+		found := false
+		for _, sim := range known {
+			if sim.EntryID == similar.Source.ID || sim.SimilarEntryID == similar.Source.ID {
+				found = true
+				break
+			}
+		}
+		if found {
+			slog.Debug("Found existing similar story", slog.Int64("storyID", stor.ID))
+			continue
+		}
+		pairs = append(pairs, &model.EntrySimilar{
+			EntryID:        stor.ID,
+			SimilarEntryID: similar.Source.ID,
+			Similarity:     similar.Similarity,
+		})
+	}
+	return pairs
+}
+
+// RebuildClusters recomputes all the story clusters of the given user from the stored similar pairs.
+func RebuildClusters(store *storage.Storage, userID int64) error {
+	pairs, err := store.SimilarEntryPairs(userID)
+	if err != nil {
+		return err
+	}
+
+	stories := make(map[int64]*story.Story)
+	getStory := func(id int64) *story.Story {
+		if _, found := stories[id]; !found {
+			stories[id] = &story.Story{ID: id}
+		}
+		return stories[id]
+	}
+
+	for _, pair := range pairs {
+		stor := getStory(pair.EntryID)
+		stor.Similar = append(stor.Similar, &story.Similar{
+			Source:     getStory(pair.SimilarEntryID),
+			Similarity: pair.Similarity,
+		})
+	}
+
+	list := make([]*story.Story, 0, len(stories))
+	for _, stor := range stories {
+		list = append(list, stor)
+	}
+
+	clusters := story.Clusters(list)
+	slog.Debug("Rebuilding story clusters",
+		slog.Int64("user_id", userID),
+		slog.Int("pairs", len(pairs)),
+		slog.Int("clustered_entries", len(clusters)),
+	)
+	return store.ReplaceEntryClusters(userID, clusters)
 }
@@ -1,28 +1,49 @@
 package similarity
 
 import (
+	"context"
+	"errors"
+
 	"github.com/stretchr/testify/require"
 	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/similarity/story"
 	"testing"
 )
 
-func TestCalculateSimilarity(t *testing.T) {
-	// Initialize a new similarity object
-	s := NewSimilarity(0.2)
+// memoryIndex is an Index returning every older entry as a candidate.
+type memoryIndex struct {
+	entries model.Entries
+}
+
+func (i *memoryIndex) Add(stor *story.Story) error {
+	i.entries = append(i.entries, &model.Entry{ID: stor.ID, Title: stor.Title, URL: stor.Link, Content: stor.Description})
+	return nil
+}
+
+func (i *memoryIndex) Candidates(stor *story.Story) (model.Entries, error) {
+	candidates := model.Entries{}
+	for _, entry := range i.entries {
+		if entry.ID < stor.ID {
+			candidates = append(candidates, entry)
+		}
+	}
+	return candidates, nil
+}
 
+func TestCalculateEntries(t *testing.T) {
 	// Define your test cases here
 	testCases := []struct {
 		name     string
 		input    []*model.Entry
 		expected []*model.EntrySimilar
 	}{
-		//{
-		//	name:     "Test 1",
-		//	input:    []*model.Entry{},      // Add your test entries here
-		//	expected: model.EntrySimilars{}, // Add your expected result here
-		//},
 		{
-			name: "Test 2",
+			name:     "No entries",
+			input:    []*model.Entry{},
+			expected: []*model.EntrySimilar{},
+		},
+		{
+			name: "Similar entries",
 			input: []*model.Entry{
 				{
 					ID:      1,
@@ -42,35 +63,173 @@ func TestCalculateSimilarity(t *testing.T) {
 					URL:     "https://example.com/story3",
 					Content: "This story is about something completely different.",
 				},
-			}, // Add your test entries here
+			},
 			expected: []*model.EntrySimilar{
 				{
-					EntryID:        1,
-					SimilarEntryID: 2,
-					Similarity:     0.26042439905730275,
+					EntryID:        2,
+					SimilarEntryID: 1,
 				},
-			}, // Add your expected result here
+			},
 		},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			output, err := s.CalculateSimilarity(tc.input)
+			index := &memoryIndex{}
+			require.NoError(t, IndexEntries(index, tc.input))
+
+			stories, err := NewSimilarity("v2", 0.7, nil, index).CalculateEntries(tc.input)
 			require.NoError(t, err)
-			if !compareEntrySimilars(output, tc.expected) {
-				t.Errorf("Expected %v, but got %v", tc.expected, output)
+			if !compareEntrySimilars(entrySimilars(stories), tc.expected) {
+				t.Errorf("Expected %v, but got %v", tc.expected, entrySimilars(stories))
 			}
 		})
 	}
 }
 
+func TestCalculateEntriesWithoutIndex(t *testing.T) {
+	_, err := NewSimilarity("v2", 0.5, nil, nil).CalculateEntries(model.Entries{{ID: 1}})
+	require.Error(t, err)
+}
+
+func TestCalculateEntriesWithUnknownAlgorithm(t *testing.T) {
+	_, err := NewSimilarity("unknown", 0.5, nil, &memoryIndex{}).CalculateEntries(model.Entries{{ID: 1}})
+	require.Error(t, err)
+}
+
+// failingIndex is an Index failing to return candidates.
+type failingIndex struct {
+	memoryIndex
+}
+
+func (i *failingIndex) Candidates(stor *story.Story) (model.Entries, error) {
+	return nil, errors.New("index unavailable")
+}
+
+func TestScoreNewEntriesAgainstExistingEntries(t *testing.T) {
+	index := &memoryIndex{}
+	require.NoError(t, IndexEntries(index, model.Entries{
+		{ID: 1, Title: "Storm hits the coast", Content: "A violent storm hit the northern coast last night, flooding roads and cutting power to thousands of homes."},
+		{ID: 2, Title: "Local team wins", Content: "The local football team won the championship after a thrilling final match on Sunday."},
+	}))
+
+	newEntries := model.Entries{
+		{ID: 3, Title: "Storm floods the coast", Content: "A violent storm hit the northern coast last night, flooding roads and cutting power to thousands of houses."},
+		{ID: 4, Title: "New library opens", Content: "The city opened a new public library with a large collection of children books."},
+	}
+
+	stories, err := scoreNewEntries(index, nil, newEntries, "v2", 0.7)
+	require.NoError(t, err)
+	require.Equal(t, []*model.EntrySimilar{{EntryID: 3, SimilarEntryID: 1}}, entrySimilars(stories))
+	require.Len(t, index.entries, 4, "the new entries should be indexed for the next refreshes")
+}
+
+func TestScoreNewEntriesWithinTheSameBatch(t *testing.T) {
+	newEntries := model.Entries{
+		{ID: 1, Title: "Storm hits the coast", Content: "A violent storm hit the northern coast last night, flooding roads and cutting power to thousands of homes."},
+		{ID: 2, Title: "Storm floods the coast", Content: "A violent storm hit the northern coast last night, flooding roads and cutting power to thousands of houses."},
+	}
+
+	stories, err := scoreNewEntries(&memoryIndex{}, nil, newEntries, "v2", 0.7)
+	require.NoError(t, err)
+	require.Equal(t, []*model.EntrySimilar{{EntryID: 2, SimilarEntryID: 1}}, entrySimilars(stories), "each pair should be scored once, from the newer entry")
+}
+
+func TestCalculateEntriesOnlyComparesIndexedCandidates(t *testing.T) {
+	entries := model.Entries{
+		{ID: 1, Content: "A violent storm hit the northern coast last night, flooding roads and cutting power to thousands of homes."},
+		{ID: 2, Content: "A violent storm hit the northern coast last night, flooding roads and cutting power to thousands of homes."},
+	}
+
+	stories, err := NewSimilarity("v2", 0.5, nil, &memoryIndex{}).CalculateEntries(entries)
+	require.NoError(t, err)
+	require.Empty(t, stories, "entries outside of the index window should not be compared")
+}
+
+func TestCalculateEntriesRespectsThreshold(t *testing.T) {
+	index := &memoryIndex{}
+	entries := model.Entries{
+		{ID: 1, Content: "A violent storm hit the northern coast last night, flooding roads and cutting power to thousands of homes."},
+		{ID: 2, Content: "A storm hit the coast, flooding roads and cutting power to homes in the north of the country."},
+	}
+	require.NoError(t, IndexEntries(index, entries))
+
+	stories, err := NewSimilarity("v2", 0, nil, index).CalculateEntries(entries[1:])
+	require.NoError(t, err)
+	require.Len(t, stories, 1)
+	score := stories[0].Similar[0].Similarity
+
+	stories, err = NewSimilarity("v2", score, nil, index).CalculateEntries(entries[1:])
+	require.NoError(t, err)
+	require.Len(t, stories, 1, "a score equal to the threshold should be kept")
+
+	stories, err = NewSimilarity("v2", score+0.01, nil, index).CalculateEntries(entries[1:])
+	require.NoError(t, err)
+	require.Empty(t, stories, "a score below the threshold should be dropped")
+}
+
+func TestCalculateEntriesWithIndexError(t *testing.T) {
+	_, err := NewSimilarity("v2", 0.5, nil, &failingIndex{}).CalculateEntries(model.Entries{{ID: 1}})
+	require.Error(t, err)
+}
+
+func TestProcessorWithIndexStopsWhenCancelled(t *testing.T) {
+	index := &memoryIndex{}
+	index.Add(&story.Story{ID: 1, Description: "An older story"})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	process := NewSimilarity("v2", 0, nil, index).(*calculateSimilarity).processor(nil, 0)
+	_, err := process(ctx, &story.Story{ID: 2, Description: "A newer story"})
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func TestNewSimilarEntries(t *testing.T) {
+	stor := &story.Story{ID: 3, Similar: []*story.Similar{
+		{Source: &story.Story{ID: 1}, Similarity: 0.9},
+		{Source: &story.Story{ID: 2}, Similarity: 0.8},
+	}}
+
+	pairs := newSimilarEntries(stor, nil)
+	require.Equal(t, []*model.EntrySimilar{
+		{EntryID: 3, SimilarEntryID: 1, Similarity: 0.9},
+		{EntryID: 3, SimilarEntryID: 2, Similarity: 0.8},
+	}, pairs)
+}
+
+func TestNewSimilarEntriesSkipsKnownPairs(t *testing.T) {
+	stor := &story.Story{ID: 3, Similar: []*story.Similar{
+		{Source: &story.Story{ID: 1}, Similarity: 0.9},
+		{Source: &story.Story{ID: 2}, Similarity: 0.8},
+		{Source: &story.Story{ID: 4}, Similarity: 0.7},
+	}}
+	known := []*model.EntrySimilar{
+		{EntryID: 3, SimilarEntryID: 1, Similarity: 0.9},
+		{EntryID: 4, SimilarEntryID: 3, Similarity: 0.7},
+	}
+
+	pairs := newSimilarEntries(stor, known)
+	require.Equal(t, []*model.EntrySimilar{{EntryID: 3, SimilarEntryID: 2, Similarity: 0.8}}, pairs, "pairs known in either direction should not be stored again")
+}
+
+func entrySimilars(stories []*story.Story) []*model.EntrySimilar {
+	similars := []*model.EntrySimilar{}
+	for _, stor := range stories {
+		for _, similar := range stor.Similar {
+			similars = append(similars, &model.EntrySimilar{EntryID: stor.ID, SimilarEntryID: similar.Source.ID})
+		}
+	}
+	return similars
+}
+
 // compareEntrySimilars is a helper function to compare two slices of EntrySimilar
 func compareEntrySimilars(a, b []*model.EntrySimilar) bool {
 	if len(a) != len(b) {
 		return false
 	}
 	for i := range a {
-		if a[i].EntryID != b[i].EntryID || a[i].SimilarEntryID != b[i].SimilarEntryID || a[i].Similarity != b[i].Similarity {
+		if a[i].EntryID != b[i].EntryID || a[i].SimilarEntryID != b[i].SimilarEntryID {
 			return false
 		}
 	}
@@ -0,0 +1,48 @@
+package story
+
+// Clusters groups stories into connected components using their similar links.
+// It returns a map of story ID to cluster ID, the cluster ID being the lowest story ID of the component.
+// Stories without any similar story are not part of a cluster.
+func Clusters(stories []*Story) map[int64]int64 {
+	parents := make(map[int64]int64)
+
+	var find func(id int64) int64
+	find = func(id int64) int64 {
+		parent, found := parents[id]
+		if !found {
+			parents[id] = id
+			return id
+		}
+		if parent == id {
+			return id
+		}
+		root := find(parent)
+		parents[id] = root
+		return root
+	}
+
+	union := func(a, b int64) {
+		rootA, rootB := find(a), find(b)
+		switch {
+		case rootA < rootB:
+			parents[rootB] = rootA
+		case rootB < rootA:
+			parents[rootA] = rootB
+		}
+	}
+
+	for _, s := range stories {
+		for _, similar := range s.Similar {
+			if similar.Source == nil || similar.Source.ID == s.ID {
+				continue
+			}
+			union(s.ID, similar.Source.ID)
+		}
+	}
+
+	clusters := make(map[int64]int64, len(parents))
+	for id := range parents {
+		clusters[id] = find(id)
+	}
+	return clusters
+}
@@ -0,0 +1,40 @@
+package story
+
+import (
+	"testing"
+)
+
+func TestClusters(t *testing.T) {
+	s1 := &Story{ID: 1}
+	s2 := &Story{ID: 2}
+	s3 := &Story{ID: 3}
+	s4 := &Story{ID: 4}
+	s5 := &Story{ID: 5}
+	s6 := &Story{ID: 6}
+
+	// 1-3 and 3-5 are connected through 3, 2-6 is a separate story, 4 has no similar story.
+	s3.Similar = []*Similar{{Source: s1, Similarity: 0.8}}
+	s5.Similar = []*Similar{{Source: s3, Similarity: 0.6}}
+	s6.Similar = []*Similar{{Source: s2, Similarity: 0.9}, {Source: s6, Similarity: 1}}
+
+	clusters := Clusters([]*Story{s1, s2, s3, s4, s5, s6})
+
+	expected := map[int64]int64{1: 1, 3: 1, 5: 1, 2: 2, 6: 2}
+	if len(clusters) != len(expected) {
+		t.Fatalf("Expected %d clustered stories, got %d: %v", len(expected), len(clusters), clusters)
+	}
+	for id, clusterID := range expected {
+		if clusters[id] != clusterID {
+			t.Errorf("Story %d: expected cluster %d, got %d", id, clusterID, clusters[id])
+		}
+	}
+	if _, found := clusters[4]; found {
+		t.Errorf("Story 4 should not be part of a cluster")
+	}
+}
+
+func TestClustersWithoutSimilarStories(t *testing.T) {
+	if clusters := Clusters([]*Story{{ID: 1}, {ID: 2}}); len(clusters) != 0 {
+		t.Errorf("Expected no cluster, got %v", clusters)
+	}
+}
@@ -12,6 +12,7 @@ import (
 )
 
 type Comparator interface {
+	Compare(article1, article2 string) (float64, error)
 	CalculateSimilarity(items model.Entries) ([]*model.EntrySimilar, error)
 }
 
@@ -25,8 +26,34 @@ func NewComparator(threshold float64) Comparator {
 }
 
 // Compare compares two articles and returns a compare score.
+// With only two documents IDF weighting is meaningless, so raw term frequencies are compared.
 func (s *compare) Compare(article1, article2 string) (float64, error) {
-	panic("implement me")
+	tf1 := make(map[string]float64)
+	for _, token := range s.tokenize(article1) {
+		tf1[token]++
+	}
+	tf2 := make(map[string]float64)
+	for _, token := range s.tokenize(article2) {
+		tf2[token]++
+	}
+
+	uniqueTokens := make([]string, 0, len(tf1)+len(tf2))
+	for token := range tf1 {
+		uniqueTokens = append(uniqueTokens, token)
+	}
+	for token := range tf2 {
+		if _, found := tf1[token]; !found {
+			uniqueTokens = append(uniqueTokens, token)
+		}
+	}
+
+	vecA := make([]float64, len(uniqueTokens))
+	vecB := make([]float64, len(uniqueTokens))
+	for i, token := range uniqueTokens {
+		vecA[i] = tf1[token]
+		vecB[i] = tf2[token]
+	}
+	return s.cosineSimilarity(vecA, vecB), nil
 }
 
 // CalculateSimilarity calculates the compare between a list of entries.
@@ -40,9 +67,6 @@ func (s *compare) CalculateSimilarity(items model.Entries) ([]*model.EntrySimila
 		slog.Debug("Group", slog.Int("group", i+1), slog.Int("stories", len(stories)))
 		for _, stor := range stories {
 			for _, similar := range stor.Similar {
-				if stor.ID == similar.Source.ID {
-					continue
-				}
 				entrySimilars = append(entrySimilars, &model.EntrySimilar{
 					EntryID:        stor.ID,
 					SimilarEntryID: similar.Source.ID,
@@ -78,30 +102,29 @@ func (s *compare) tokenize(text string) []string {
 	return tokens
 }
 
-func (s *compare) computeTFIDF(stories []*story.Story) (map[string]map[string]float64, []string) {
-	tf := make(map[string]map[string]float64)
+// computeTFIDF returns the TF-IDF vector of each story, indexed like the stories slice.
+func (s *compare) computeTFIDF(stories []*story.Story) ([]map[string]float64, []string) {
+	tf := make([]map[string]float64, len(stories))
 	df := make(map[string]int)
-	tokensPerStory := make(map[string][]string)
-	for _, stor := range stories {
-		tokens := s.tokenize(stor.Content)
-		tokensPerStory[stor.Link] = tokens
-		tf[stor.Link] = make(map[string]float64)
-		for _, token := range tokens {
-			tf[stor.Link][token]++
+	for i, stor := range stories {
+		tf[i] = make(map[string]float64)
+		for _, token := range s.tokenize(stor.Content) {
+			tf[i][token]++
 		}
-		for token := range tf[stor.Link] {
+		for token := range tf[i] {
 			df[token]++
 		}
 	}
+	// Terms present in every story keep a weight of 1 instead of vanishing.
 	idf := make(map[string]float64)
 	for token, count := range df {
-		idf[token] = math.Log(float64(len(stories)) / float64(count))
+		idf[token] = math.Log(float64(len(stories))/float64(count)) + 1
 	}
-	tfidf := make(map[string]map[string]float64)
-	for link, tfs := range tf {
-		tfidf[link] = make(map[string]float64)
+	tfidf := make([]map[string]float64, len(stories))
+	for i, tfs := range tf {
+		tfidf[i] = make(map[string]float64)
 		for token, freq := range tfs {
-			tfidf[link][token] = freq * idf[token]
+			tfidf[i][token] = freq * idf[token]
 		}
 	}
 	uniqueTokens := make([]string, 0, len(df))
@@ -112,34 +135,39 @@ func (s *compare) computeTFIDF(stories []*story.Story) (map[string]map[string]fl
 }
 
 func (s *compare) cosineSimilarity(vecA, vecB []float64) float64 {
-	return floats.Dot(vecA, vecB) / (math.Sqrt(floats.Dot(vecA, vecA)) * math.Sqrt(floats.Dot(vecB, vecB)))
+	magnitude := math.Sqrt(floats.Dot(vecA, vecA) * floats.Dot(vecB, vecB))
+	if magnitude == 0 {
+		return 0
+	}
+	// Rounding may push identical vectors marginally above 1.
+	return math.Min(floats.Dot(vecA, vecB)/magnitude, 1)
 }
 
-func (s *compare) compareStories(storyA, storyB *story.Story, tfidf map[string]map[string]float64, uniqueTokens []string) float64 {
+func (s *compare) compareStories(vectorA, vectorB map[string]float64, uniqueTokens []string) float64 {
 	vecA := make([]float64, len(uniqueTokens))
 	vecB := make([]float64, len(uniqueTokens))
 	for i, token := range uniqueTokens {
-		vecA[i] = tfidf[storyA.Link][token]
-		vecB[i] = tfidf[storyB.Link][token]
+		vecA[i] = vectorA[token]
+		vecB[i] = vectorB[token]
 	}
 	return s.cosineSimilarity(vecA, vecB)
 }
 
-func (s *compare) groupSimilarStories(stories []*story.Story, tfidf map[string]map[string]float64, uniqueTokens []string, threshold float64) [][]*story.Story {
+func (s *compare) groupSimilarStories(stories []*story.Story, tfidf []map[string]float64, uniqueTokens []string, threshold float64) [][]*story.Story {
 	groups := [][]*story.Story{}
-	visited := make(map[string]bool)
+	visited := make([]bool, len(stories))
 
-	for _, store := range stories {
-		if visited[store.Link] {
+	for i, store := range stories {
+		if visited[i] {
 			continue
 		}
 		group := []*story.Story{store}
-		visited[store.Link] = true
-		for _, otherStory := range stories {
-			if visited[otherStory.Link] {
+		visited[i] = true
+		for j, otherStory := range stories {
+			if visited[j] {
 				continue
 			}
-			similarity := s.compareStories(store, otherStory, tfidf, uniqueTokens)
+			similarity := s.compareStories(tfidf[i], tfidf[j], uniqueTokens)
 			//slog.Debug("Comparing stories vs threshold",
 			//	slog.String("story1", story.Title),
 			//	slog.String("story2", otherStory.Title),
@@ -151,7 +179,7 @@ func (s *compare) groupSimilarStories(stories []*story.Story, tfidf map[string]m
 					Similarity: similarity,
 				})
 				group = append(group, otherStory)
-				visited[otherStory.Link] = true
+				visited[j] = true
 			}
 		}
 		if len(group) > 1 { // Only add groups with multiple stories
@@ -50,8 +50,8 @@ func TestCalculateSimilarity_MultipleEntriesNoSimilarities(t *testing.T) {
 func TestCalculateSimilarity_MultipleEntriesWithSimilarities(t *testing.T) {
 	comparator := NewComparator(0.5)
 	entries := []*model.Entry{
-		createEntry("Similar story one"),
-		createEntry("Similar story two"),
+		createEntry("City council approves the new budget after a long vote"),
+		createEntry("City council approves new budget after long vote"),
 		createEntry("Unique story"),
 	}
 
@@ -9,14 +9,63 @@ type Comparator interface {
 	Compare(article1, article2 string) (float64, error)
 }
 
+// Corpus provides the document frequencies used to weight terms.
+type Corpus interface {
+	// DocumentCount returns the number of documents in the corpus.
+	DocumentCount() int
+	// DocumentFrequencies returns the number of documents containing each of the given terms.
+	DocumentFrequencies(terms []string) (map[string]int, error)
+}
+
 // TextComparer is the struct that holds the methods to compare texts
-type compare struct{}
+type compare struct {
+	corpus Corpus
+}
 
 // NewComparator creates a new instance of Comparator
 func NewComparator() Comparator {
 	return &compare{}
 }
 
+// NewCorpusComparator creates a new instance of Comparator weighting terms with the IDF of the given corpus
+func NewCorpusComparator(corpus Corpus) Comparator {
+	return &compare{corpus: corpus}
+}
+
+// Terms returns the distinct terms of a text, as indexed in a corpus
+func Terms(text string) []string {
+	c := &compare{}
+	seen := make(map[string]bool)
+	terms := make([]string, 0)
+	for _, token := range c.tokenizeAndRemoveStopWords(text) {
+		if !seen[token] {
+			seen[token] = true
+			terms = append(terms, token)
+		}
+	}
+	return terms
+}
+
+// QueryWeights returns the TF-IDF weights of the given distinct terms in the corpus, normalized to a unit vector.
+func QueryWeights(corpus Corpus, terms []string) (map[string]float64, error) {
+	weights, err := (&compare{corpus: corpus}).corpusIDF(terms)
+	if err != nil {
+		return nil, err
+	}
+
+	var norm float64
+	for _, weight := range weights {
+		norm += weight * weight
+	}
+	norm = math.Sqrt(norm)
+	if norm > 0 {
+		for term := range weights {
+			weights[term] /= norm
+		}
+	}
+	return weights, nil
+}
+
 // Compare compares two articles and returns their similarity score
 func (c *compare) Compare(article1, article2 string) (float64, error) {
 	// Tokenize and remove stop words
@@ -24,8 +73,12 @@ func (c *compare) Compare(article1, article2 string) (float64, error) {
 	tokens2 := c.tokenizeAndRemoveStopWords(article2)
 
 	// Calculate TF-IDF vectors
-	tfidf1 := c.calculateTFIDF(tokens1)
-	tfidf2 := c.calculateTFIDF(tokens2)
+	idf, err := c.corpusIDF(append(tokens1, tokens2...))
+	if err != nil {
+		return 0, err
+	}
+	tfidf1 := c.calculateTFIDF(tokens1, idf)
+	tfidf2 := c.calculateTFIDF(tokens2, idf)
 
 	// Calculate and return cosine similarity
 	similarity := c.cosineSimilarity(tfidf1, tfidf2)
@@ -62,39 +115,35 @@ func (tc *compare) termFrequency(text string) map[string]float64 {
 	return tf
 }
 
-// inverseDocumentFrequency computes the inverse document frequency for words across documents
-func (tc *compare) inverseDocumentFrequency(texts []string) map[string]float64 {
+// corpusIDF computes the smoothed inverse document frequency of the given tokens.
+// Without corpus every term gets the same weight.
+func (c *compare) corpusIDF(tokens []string) (map[string]float64, error) {
 	idf := make(map[string]float64)
-	totalDocuments := float64(len(texts))
-	wordDocCount := make(map[string]float64)
-
-	for _, text := range texts {
-		words := tc.tokenize(text)
-		wordSet := make(map[string]struct{})
-		for _, word := range words {
-			wordSet[word] = struct{}{}
-		}
-		for word := range wordSet {
-			wordDocCount[word]++
+	if c.corpus == nil {
+		for _, token := range tokens {
+			idf[token] = 1
 		}
+		return idf, nil
 	}
 
-	for word, count := range wordDocCount {
-		idf[word] = math.Log(totalDocuments / (1 + count))
+	frequencies, err := c.corpus.DocumentFrequencies(tokens)
+	if err != nil {
+		return nil, err
 	}
 
-	return idf
+	totalDocuments := float64(c.corpus.DocumentCount())
+	for _, token := range tokens {
+		idf[token] = math.Log((1+totalDocuments)/(1+float64(frequencies[token]))) + 1
+	}
+	return idf, nil
 }
 
 // calculateTFIDF calculates the TF-IDF vector for a given text
-func (c *compare) calculateTFIDF(tokens []string) map[string]float64 {
-	idf := c.inverseDocumentFrequency([]string{strings.Join(tokens, " ")}) // Simplified; in practice, use a corpus-wide IDF
+func (c *compare) calculateTFIDF(tokens []string, idf map[string]float64) map[string]float64 {
+	tf := c.termFrequency(strings.Join(tokens, " "))
 	tfidf := make(map[string]float64)
-	for _, token := range tokens {
-		tfVal, ok := c.termFrequency(strings.Join(tokens, " "))[token]
-		if ok {
-			tfidf[token] = tfVal * idf[token]
-		}
+	for token, tfVal := range tf {
+		tfidf[token] = tfVal * idf[token]
 	}
 	return tfidf
 }
@@ -71,3 +71,85 @@ func TestArticleComparator(t *testing.T) {
 		})
 	}
 }
+
+type mapCorpus struct {
+	documents   int
+	frequencies map[string]int
+}
+
+func (m *mapCorpus) DocumentCount() int {
+	return m.documents
+}
+
+func (m *mapCorpus) DocumentFrequencies(terms []string) (map[string]int, error) {
+	return m.frequencies, nil
+}
+
+func TestCorpusComparatorDownweightsCommonTerms(t *testing.T) {
+	corpus := &mapCorpus{
+		documents: 100,
+		frequencies: map[string]int{
+			"breaking": 95,
+			"news":     98,
+			"volcano":  2,
+			"eruption": 3,
+			"election": 10,
+			"results":  20,
+		},
+	}
+
+	article1 := "breaking news volcano eruption"
+	article2 := "breaking news election results"
+	article3 := "volcano eruption"
+
+	plain, err := NewComparator().Compare(article1, article2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	weighted, err := NewCorpusComparator(corpus).Compare(article1, article2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if weighted >= plain {
+		t.Errorf("Expected common terms to weigh less with a corpus, got %v with corpus and %v without", weighted, plain)
+	}
+
+	related, err := NewCorpusComparator(corpus).Compare(article1, article3)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if related <= weighted {
+		t.Errorf("Expected rare shared terms to weigh more than common ones, got %v and %v", related, weighted)
+	}
+}
+
+func TestTerms(t *testing.T) {
+	terms := Terms("the volcano and the volcano eruption")
+	if len(terms) != 2 || terms[0] != "volcano" || terms[1] != "eruption" {
+		t.Errorf("Unexpected terms: %v", terms)
+	}
+}
+
+func TestQueryWeights(t *testing.T) {
+	corpus := &mapCorpus{
+		documents:   100,
+		frequencies: map[string]int{"news": 98, "volcano": 2},
+	}
+
+	weights, err := QueryWeights(corpus, []string{"news", "volcano"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if weights["volcano"] <= weights["news"] {
+		t.Errorf("Expected rare terms to weigh more, got %v and %v", weights["volcano"], weights["news"])
+	}
+
+	norm := weights["news"]*weights["news"] + weights["volcano"]*weights["volcano"]
+	if math.Abs(norm-1) > 1e-9 {
+		t.Errorf("Expected a unit vector, got a squared norm of %v", norm)
+	}
+}
@@ -59,6 +59,12 @@ func (b *BatchBuilder) WithoutDisabledFeeds() *BatchBuilder {
 	return b
 }
 
+// WithoutWebSubFeeds excludes the feeds receiving their updates from an active WebSub subscription.
+func (b *BatchBuilder) WithoutWebSubFeeds() *BatchBuilder {
+	b.conditions = append(b.conditions, "NOT EXISTS (SELECT 1 FROM websub_subscriptions ws WHERE ws.feed_id=feeds.id AND ws.state='active' AND ws.lease_expires_at > now())")
+	return b
+}
+
 func (b *BatchBuilder) FetchJobs() (jobs model.JobList, err error) {
 	query := `SELECT id, user_id FROM feeds`
 
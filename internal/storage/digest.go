@@ -0,0 +1,81 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package storage // import "miniflux.app/v2/internal/storage"
+
+import (
+	"fmt"
+	"time"
+
+	"miniflux.app/v2/internal/model"
+)
+
+// DigestsDue returns the users waiting for a digest email, with the start of the period it covers.
+// The digests are sent on the hour, so that the hourly scheduler does not delay them a bit more every time.
+func (s *Storage) DigestsDue() ([]*model.DigestSchedule, error) {
+	query := `
+		SELECT
+			id,
+			digest_sent_at,
+			COALESCE(digest_sent_at, date_trunc('hour', now()) - CASE WHEN digest_frequency='weekly' THEN interval '7 days' ELSE interval '1 day' END)
+		FROM
+			users
+		WHERE
+			digest_email <> ''
+		AND
+			(
+				(digest_frequency='daily' AND (digest_sent_at IS NULL OR digest_sent_at <= now() - interval '1 day')) OR
+				(digest_frequency='weekly' AND (digest_sent_at IS NULL OR digest_sent_at <= now() - interval '7 days'))
+			)
+		ORDER BY
+			id ASC
+	`
+
+	rows, err := s.db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf(`store: unable to fetch the digests due: %v`, err)
+	}
+	defer rows.Close()
+
+	var schedules []*model.DigestSchedule
+	for rows.Next() {
+		var schedule model.DigestSchedule
+		if err := rows.Scan(&schedule.UserID, &schedule.SentAt, &schedule.Since); err != nil {
+			return nil, fmt.Errorf(`store: unable to fetch the digest schedule row: %v`, err)
+		}
+		schedules = append(schedules, &schedule)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf(`store: unable to fetch the digests due: %v`, err)
+	}
+
+	return schedules, nil
+}
+
+// ClaimDigest records the date of the digest about to be sent, unless another digest was recorded in the meantime.
+// It returns false when the digest has already been claimed.
+func (s *Storage) ClaimDigest(schedule *model.DigestSchedule, sentAt time.Time) (bool, error) {
+	query := `UPDATE users SET digest_sent_at=$1 WHERE id=$2 AND digest_sent_at IS NOT DISTINCT FROM $3`
+	result, err := s.db.Exec(query, sentAt, schedule.UserID, schedule.SentAt)
+	if err != nil {
+		return false, fmt.Errorf(`store: unable to claim the digest of user #%d: %v`, schedule.UserID, err)
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf(`store: unable to claim the digest of user #%d: %v`, schedule.UserID, err)
+	}
+
+	return count > 0, nil
+}
+
+// ReleaseDigest restores the date of the previous digest, when a claimed digest could not be sent.
+func (s *Storage) ReleaseDigest(schedule *model.DigestSchedule, sentAt time.Time) error {
+	query := `UPDATE users SET digest_sent_at=$1 WHERE id=$2 AND digest_sent_at=$3`
+	if _, err := s.db.Exec(query, schedule.SentAt, schedule.UserID, sentAt); err != nil {
+		return fmt.Errorf(`store: unable to release the digest of user #%d: %v`, schedule.UserID, err)
+	}
+
+	return nil
+}
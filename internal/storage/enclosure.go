@@ -23,7 +23,11 @@ func (s *Storage) GetEnclosures(entryID int64) (model.EnclosureList, error) {
 			url,
 			size,
 			mime_type,
-		    media_progression
+			media_progression,
+			alternate,
+			chapters_url,
+			chapters,
+			transcripts
 		FROM
 			enclosures
 		WHERE
@@ -48,6 +52,10 @@ func (s *Storage) GetEnclosures(entryID int64) (model.EnclosureList, error) {
 			&enclosure.Size,
 			&enclosure.MimeType,
 			&enclosure.MediaProgression,
+			&enclosure.Alternate,
+			&enclosure.ChaptersURL,
+			&enclosure.Chapters,
+			&enclosure.Transcripts,
 		)
 
 		if err != nil {
@@ -69,7 +77,11 @@ func (s *Storage) GetEnclosure(enclosureID int64) (*model.Enclosure, error) {
 			url,
 			size,
 			mime_type,
-		    media_progression
+			media_progression,
+			alternate,
+			chapters_url,
+			chapters,
+			transcripts
 		FROM
 			enclosures
 		WHERE
@@ -88,6 +100,10 @@ func (s *Storage) GetEnclosure(enclosureID int64) (*model.Enclosure, error) {
 		&enclosure.Size,
 		&enclosure.MimeType,
 		&enclosure.MediaProgression,
+		&enclosure.Alternate,
+		&enclosure.ChaptersURL,
+		&enclosure.Chapters,
+		&enclosure.Transcripts,
 	)
 
 	if err != nil {
@@ -105,10 +121,15 @@ func (s *Storage) createEnclosure(tx *sql.Tx, enclosure *model.Enclosure) error
 
 	query := `
 		INSERT INTO enclosures
-			(url, size, mime_type, entry_id, user_id, media_progression)
+			(url, size, mime_type, entry_id, user_id, media_progression, alternate, chapters_url, transcripts)
 		VALUES
-			($1, $2, $3, $4, $5, $6)
-		ON CONFLICT (user_id, entry_id, md5(url)) DO NOTHING
+			($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (user_id, entry_id, md5(url)) DO UPDATE SET
+			alternate=EXCLUDED.alternate,
+			chapters_url=EXCLUDED.chapters_url,
+			chapters=CASE WHEN EXCLUDED.chapters_url=enclosures.chapters_url THEN enclosures.chapters ELSE '[]' END,
+			chapters_checked_at=CASE WHEN EXCLUDED.chapters_url=enclosures.chapters_url THEN enclosures.chapters_checked_at END,
+			transcripts=EXCLUDED.transcripts
 		RETURNING
 			id
 	`
@@ -120,6 +141,9 @@ func (s *Storage) createEnclosure(tx *sql.Tx, enclosure *model.Enclosure) error
 		enclosure.EntryID,
 		enclosure.UserID,
 		enclosure.MediaProgression,
+		enclosure.Alternate,
+		enclosure.ChaptersURL,
+		enclosure.Transcripts,
 	).Scan(&enclosure.ID); err != nil && err != sql.ErrNoRows {
 		return fmt.Errorf(`store: unable to create enclosure: %w`, err)
 	}
@@ -127,6 +151,53 @@ func (s *Storage) createEnclosure(tx *sql.Tx, enclosure *model.Enclosure) error
 	return nil
 }
 
+// EnclosuresWithUncheckedChapters returns the enclosures of a feed whose chapters file was never downloaded, the most recent first.
+func (s *Storage) EnclosuresWithUncheckedChapters(userID, feedID int64, limit int) (model.EnclosureList, error) {
+	query := `
+		SELECT
+			en.id,
+			en.chapters_url
+		FROM
+			enclosures en
+		JOIN
+			entries e ON e.id=en.entry_id
+		WHERE
+			e.user_id=$1 AND e.feed_id=$2 AND en.chapters_url <> '' AND en.chapters_checked_at IS NULL
+		ORDER BY
+			en.id DESC
+		LIMIT $3
+	`
+	rows, err := s.db.Query(query, userID, feedID, limit)
+	if err != nil {
+		return nil, fmt.Errorf(`store: unable to fetch enclosures with unchecked chapters: %v`, err)
+	}
+	defer rows.Close()
+
+	enclosures := make(model.EnclosureList, 0)
+	for rows.Next() {
+		enclosure := model.Enclosure{UserID: userID}
+		if err := rows.Scan(&enclosure.ID, &enclosure.ChaptersURL); err != nil {
+			return nil, fmt.Errorf(`store: unable to fetch enclosure row: %v`, err)
+		}
+		enclosures = append(enclosures, &enclosure)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf(`store: unable to fetch enclosures with unchecked chapters: %v`, err)
+	}
+
+	return enclosures, nil
+}
+
+// UpdateEnclosureChapters stores the chapters downloaded for an enclosure, the chapters file is not downloaded again.
+func (s *Storage) UpdateEnclosureChapters(enclosureID int64, chapters model.PodcastChapters) error {
+	query := `UPDATE enclosures SET chapters=$2, chapters_checked_at=now() WHERE id=$1`
+	if _, err := s.db.Exec(query, enclosureID, chapters); err != nil {
+		return fmt.Errorf(`store: unable to update chapters of enclosure #%d: %v`, enclosureID, err)
+	}
+	return nil
+}
+
 func (s *Storage) updateEnclosures(tx *sql.Tx, entry *model.Entry) error {
 	if len(entry.Enclosures) == 0 {
 		return nil
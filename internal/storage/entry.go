@@ -107,7 +107,8 @@ func (s *Storage) createEntry(tx *sql.Tx, entry *model.Entry) error {
 				reading_time,
 				changed_at,
 				document_vectors,
-				tags
+				tags,
+				podcast
 			)
 		VALUES
 			(
@@ -123,7 +124,8 @@ func (s *Storage) createEntry(tx *sql.Tx, entry *model.Entry) error {
 				$10,
 				now(),
 				setweight(to_tsvector(left(coalesce($1, ''), 500000)), 'A') || setweight(to_tsvector(left(coalesce($6, ''), 500000)), 'B'),
-				$11
+				$11,
+				$12
 			)
 		RETURNING
 			id, status, created_at, changed_at
@@ -141,6 +143,7 @@ func (s *Storage) createEntry(tx *sql.Tx, entry *model.Entry) error {
 		entry.FeedID,
 		entry.ReadingTime,
 		pq.Array(removeEmpty(removeDuplicates(entry.Tags))),
+		entry.Podcast,
 	).Scan(
 		&entry.ID,
 		&entry.Status,
@@ -167,7 +170,13 @@ func (s *Storage) createEntry(tx *sql.Tx, entry *model.Entry) error {
 // updateEntry updates an entry when a feed is refreshed.
 // Note: we do not update the published date because some feeds do not contains any date,
 // it default to time.Now() which could change the order of items on the history page.
-func (s *Storage) updateEntry(tx *sql.Tx, entry *model.Entry) error {
+// It returns true when the entry must be checked for a substantial change once the transaction is committed.
+func (s *Storage) updateEntry(tx *sql.Tx, entry *model.Entry) (bool, error) {
+	checkChange, err := s.saveEntryRevision(tx, entry)
+	if err != nil {
+		return false, err
+	}
+
 	query := `
 		UPDATE
 			entries
@@ -179,13 +188,14 @@ func (s *Storage) updateEntry(tx *sql.Tx, entry *model.Entry) error {
 			author=$5,
 			reading_time=$6,
 			document_vectors = setweight(to_tsvector(left(coalesce($1, ''), 500000)), 'A') || setweight(to_tsvector(left(coalesce($4, ''), 500000)), 'B'),
-			tags=$10
+			tags=$10,
+			podcast=$11
 		WHERE
 			user_id=$7 AND feed_id=$8 AND hash=$9
 		RETURNING
 			id
 	`
-	err := tx.QueryRow(
+	err = tx.QueryRow(
 		query,
 		entry.Title,
 		entry.URL,
@@ -197,10 +207,11 @@ func (s *Storage) updateEntry(tx *sql.Tx, entry *model.Entry) error {
 		entry.FeedID,
 		entry.Hash,
 		pq.Array(removeEmpty(removeDuplicates(entry.Tags))),
+		entry.Podcast,
 	).Scan(&entry.ID)
 
 	if err != nil {
-		return fmt.Errorf(`store: unable to update entry %q: %v`, entry.URL, err)
+		return false, fmt.Errorf(`store: unable to update entry %q: %v`, entry.URL, err)
 	}
 
 	for _, enclosure := range entry.Enclosures {
@@ -208,7 +219,15 @@ func (s *Storage) updateEntry(tx *sql.Tx, entry *model.Entry) error {
 		enclosure.EntryID = entry.ID
 	}
 
-	return s.updateEnclosures(tx, entry)
+	return checkChange, s.updateEnclosures(tx, entry)
+}
+
+// EntryIDExists checks if a visible entry belongs to the given user.
+func (s *Storage) EntryIDExists(userID, entryID int64) bool {
+	var result bool
+	query := `SELECT true FROM entries WHERE user_id=$1 AND id=$2 AND status <> $3`
+	s.db.QueryRow(query, userID, entryID, model.EntryStatusRemoved).Scan(&result)
+	return result
 }
 
 // entryExists checks if an entry already exists based on its hash when refreshing a feed.
@@ -288,9 +307,10 @@ func (s *Storage) RefreshFeedEntries(userID, feedID int64, entries model.Entries
 			return nil, err
 		}
 
+		checkChange := false
 		if entryExists {
 			if updateExistingEntries {
-				err = s.updateEntry(tx, entry)
+				checkChange, err = s.updateEntry(tx, entry)
 			}
 		} else {
 			err = s.createEntry(tx, entry)
@@ -310,6 +330,12 @@ func (s *Storage) RefreshFeedEntries(userID, feedID int64, entries model.Entries
 			return nil, fmt.Errorf(`store: unable to commit transaction: %v`, err)
 		}
 
+		if checkChange {
+			if err := s.markUnreadOnSubstantialChange(entry); err != nil {
+				return nil, err
+			}
+		}
+
 		entryHashes = append(entryHashes, entry.Hash)
 	}
 
@@ -327,6 +353,7 @@ func (s *Storage) RefreshFeedEntries(userID, feedID int64, entries model.Entries
 }
 
 // ArchiveEntries changes the status of entries to "removed" after the given number of days.
+// Archived entries are removed from the similarity corpus.
 func (s *Storage) ArchiveEntries(status string, days, limit int) (int64, error) {
 	if days < 0 || limit <= 0 {
 		return 0, nil
@@ -347,23 +374,55 @@ func (s *Storage) ArchiveEntries(status string, days, limit int) (int64, error)
 					status=$2 AND
 					starred is false AND
 					share_code='' AND
+					user_tags='{}' AND
 					created_at < now () - $3::interval
 				ORDER BY
 					created_at ASC LIMIT $4
 				)
+		RETURNING
+			id
 	`
 
-	result, err := s.db.Exec(query, model.EntryStatusRemoved, status, fmt.Sprintf("%d days", days), limit)
+	tx, err := s.db.Begin()
 	if err != nil {
-		return 0, fmt.Errorf(`store: unable to archive %s entries: %v`, status, err)
+		return 0, fmt.Errorf(`store: unable to start transaction: %v`, err)
 	}
 
-	count, err := result.RowsAffected()
+	rows, err := tx.Query(query, model.EntryStatusRemoved, status, fmt.Sprintf("%d days", days), limit)
 	if err != nil {
-		return 0, fmt.Errorf(`store: unable to get the number of rows affected: %v`, err)
+		tx.Rollback()
+		return 0, fmt.Errorf(`store: unable to archive %s entries: %v`, status, err)
 	}
 
-	return count, nil
+	var entryIDs []int64
+	for rows.Next() {
+		var entryID int64
+		if err := rows.Scan(&entryID); err != nil {
+			rows.Close()
+			tx.Rollback()
+			return 0, fmt.Errorf(`store: unable to fetch archived entry row: %v`, err)
+		}
+		entryIDs = append(entryIDs, entryID)
+	}
+	rows.Close()
+
+	if len(entryIDs) > 0 {
+		if err := removeSimilarityDocuments(tx, entryIDs); err != nil {
+			tx.Rollback()
+			return 0, err
+		}
+
+		if err := removeEntrySignatures(tx, entryIDs); err != nil {
+			tx.Rollback()
+			return 0, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf(`store: unable to commit transaction: %v`, err)
+	}
+
+	return int64(len(entryIDs)), nil
 }
 
 // SetEntriesStatus update the status of the given list of entries.
@@ -412,7 +471,16 @@ func (s *Storage) SetEntriesStatusCount(userID int64, entryIDs []int64, status s
 
 // SetEntriesBookmarked update the bookmarked state for the given list of entries.
 func (s *Storage) SetEntriesBookmarkedState(userID int64, entryIDs []int64, starred bool) error {
-	query := `UPDATE entries SET starred=$1, changed_at=now() WHERE user_id=$2 AND id=ANY($3)`
+	query := `
+		UPDATE
+			entries
+		SET
+			starred=$1,
+			starred_at=CASE WHEN $1 THEN COALESCE(starred_at, now()) ELSE NULL END,
+			changed_at=now()
+		WHERE
+			user_id=$2 AND id=ANY($3)
+	`
 	result, err := s.db.Exec(query, starred, userID, pq.Array(entryIDs))
 	if err != nil {
 		return fmt.Errorf(`store: unable to update the bookmarked state %v: %v`, entryIDs, err)
@@ -432,7 +500,16 @@ func (s *Storage) SetEntriesBookmarkedState(userID int64, entryIDs []int64, star
 
 // ToggleBookmark toggles entry bookmark value.
 func (s *Storage) ToggleBookmark(userID int64, entryID int64) error {
-	query := `UPDATE entries SET starred = NOT starred, changed_at=now() WHERE user_id=$1 AND id=$2`
+	query := `
+		UPDATE
+			entries
+		SET
+			starred = NOT starred,
+			starred_at=CASE WHEN starred THEN NULL ELSE now() END,
+			changed_at=now()
+		WHERE
+			user_id=$1 AND id=$2
+	`
 	result, err := s.db.Exec(query, userID, entryID)
 	if err != nil {
 		return fmt.Errorf(`store: unable to toggle bookmark flag for entry #%d: %v`, entryID, err)
@@ -0,0 +1,199 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package storage // import "miniflux.app/v2/internal/storage"
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"miniflux.app/v2/internal/model"
+)
+
+const entryAnnotationColumns = `
+	a.id, a.user_id, a.entry_id, a.text, a.note, a.created_at, a.updated_at,
+	e.title, e.url, e.author
+`
+
+// EntryAnnotations returns the annotations of an entry, in creation order.
+func (s *Storage) EntryAnnotations(userID, entryID int64) (model.EntryAnnotations, error) {
+	return s.fetchEntryAnnotations(`
+		SELECT `+entryAnnotationColumns+`
+		FROM
+			entry_annotations a
+		JOIN
+			entries e ON e.id=a.entry_id
+		WHERE
+			a.user_id=$1 AND a.entry_id=$2
+		ORDER BY
+			a.id ASC
+	`, userID, entryID)
+}
+
+// UserEntryAnnotations returns all the annotations of a user, grouped by entry.
+func (s *Storage) UserEntryAnnotations(userID int64) (model.EntryAnnotations, error) {
+	return s.fetchEntryAnnotations(`
+		SELECT `+entryAnnotationColumns+`
+		FROM
+			entry_annotations a
+		JOIN
+			entries e ON e.id=a.entry_id
+		WHERE
+			a.user_id=$1
+		ORDER BY
+			e.published_at DESC, a.entry_id DESC, a.id ASC
+	`, userID)
+}
+
+func (s *Storage) fetchEntryAnnotations(query string, args ...any) (model.EntryAnnotations, error) {
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf(`store: unable to fetch entry annotations: %v`, err)
+	}
+	defer rows.Close()
+
+	annotations := make(model.EntryAnnotations, 0)
+	for rows.Next() {
+		var annotation model.EntryAnnotation
+		if err := rows.Scan(
+			&annotation.ID,
+			&annotation.UserID,
+			&annotation.EntryID,
+			&annotation.Text,
+			&annotation.Note,
+			&annotation.CreatedAt,
+			&annotation.UpdatedAt,
+			&annotation.EntryTitle,
+			&annotation.EntryURL,
+			&annotation.EntryAuthor,
+		); err != nil {
+			return nil, fmt.Errorf(`store: unable to fetch entry annotation row: %v`, err)
+		}
+		annotations = append(annotations, &annotation)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf(`store: unable to fetch entry annotations: %v`, err)
+	}
+
+	return annotations, nil
+}
+
+// EntryAnnotationByID returns an annotation of an entry.
+func (s *Storage) EntryAnnotationByID(userID, entryID, annotationID int64) (*model.EntryAnnotation, error) {
+	var annotation model.EntryAnnotation
+	err := s.db.QueryRow(`
+		SELECT `+entryAnnotationColumns+`
+		FROM
+			entry_annotations a
+		JOIN
+			entries e ON e.id=a.entry_id
+		WHERE
+			a.user_id=$1 AND a.entry_id=$2 AND a.id=$3
+	`, userID, entryID, annotationID).Scan(
+		&annotation.ID,
+		&annotation.UserID,
+		&annotation.EntryID,
+		&annotation.Text,
+		&annotation.Note,
+		&annotation.CreatedAt,
+		&annotation.UpdatedAt,
+		&annotation.EntryTitle,
+		&annotation.EntryURL,
+		&annotation.EntryAuthor,
+	)
+
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return nil, nil
+	case err != nil:
+		return nil, fmt.Errorf(`store: unable to fetch entry annotation #%d: %v`, annotationID, err)
+	}
+
+	return &annotation, nil
+}
+
+// CreateEntryAnnotation saves a new annotation on a visible entry of the user.
+// It returns nil when the entry does not exist.
+func (s *Storage) CreateEntryAnnotation(userID, entryID int64, request *model.EntryAnnotationRequest) (*model.EntryAnnotation, error) {
+	annotation := &model.EntryAnnotation{
+		UserID:  userID,
+		EntryID: entryID,
+		Text:    request.Text,
+		Note:    request.Note,
+	}
+
+	query := `
+		INSERT INTO entry_annotations
+			(user_id, entry_id, text, note)
+		SELECT
+			user_id, id, $3, $4
+		FROM
+			entries
+		WHERE
+			user_id=$1 AND id=$2 AND status <> $5
+		RETURNING
+			id, created_at, updated_at
+	`
+	err := s.db.QueryRow(
+		query,
+		userID,
+		entryID,
+		annotation.Text,
+		annotation.Note,
+		model.EntryStatusRemoved,
+	).Scan(&annotation.ID, &annotation.CreatedAt, &annotation.UpdatedAt)
+
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return nil, nil
+	case err != nil:
+		return nil, fmt.Errorf(`store: unable to create entry annotation: %v`, err)
+	}
+
+	return annotation, nil
+}
+
+// UpdateEntryAnnotation updates the text and the note of an annotation.
+func (s *Storage) UpdateEntryAnnotation(annotation *model.EntryAnnotation) error {
+	query := `
+		UPDATE
+			entry_annotations
+		SET
+			text=$1, note=$2, updated_at=now()
+		WHERE
+			id=$3 AND user_id=$4
+		RETURNING
+			updated_at
+	`
+	err := s.db.QueryRow(
+		query,
+		annotation.Text,
+		annotation.Note,
+		annotation.ID,
+		annotation.UserID,
+	).Scan(&annotation.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf(`store: unable to update entry annotation #%d: %v`, annotation.ID, err)
+	}
+
+	return nil
+}
+
+// RemoveEntryAnnotation deletes an annotation of an entry.
+// It returns false when the annotation does not exist.
+func (s *Storage) RemoveEntryAnnotation(userID, entryID, annotationID int64) (bool, error) {
+	query := `DELETE FROM entry_annotations WHERE id=$1 AND entry_id=$2 AND user_id=$3`
+	result, err := s.db.Exec(query, annotationID, entryID, userID)
+	if err != nil {
+		return false, fmt.Errorf(`store: unable to remove entry annotation #%d: %v`, annotationID, err)
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf(`store: unable to remove entry annotation #%d: %v`, annotationID, err)
+	}
+
+	return count > 0, nil
+}
@@ -0,0 +1,145 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package storage // import "miniflux.app/v2/internal/storage"
+
+import (
+	"database/sql"
+	"fmt"
+
+	"miniflux.app/v2/internal/model"
+)
+
+// SimilarEntryPairs returns all the similarity pairs of the given user.
+func (s *Storage) SimilarEntryPairs(userID int64) ([]*model.EntrySimilar, error) {
+	query := `
+		SELECT
+			es.entry_id, es.similar_entry_id, es.similarity
+		FROM
+			entry_similar es
+		JOIN
+			entries e ON e.id=es.entry_id
+		WHERE
+			e.user_id=$1
+	`
+	rows, err := s.db.Query(query, userID)
+	if err != nil {
+		return nil, fmt.Errorf(`store: unable to fetch similar entry pairs: %v`, err)
+	}
+	defer rows.Close()
+
+	pairs := make([]*model.EntrySimilar, 0)
+	for rows.Next() {
+		var pair model.EntrySimilar
+		if err := rows.Scan(&pair.EntryID, &pair.SimilarEntryID, &pair.Similarity); err != nil {
+			return nil, fmt.Errorf(`store: unable to scan similar entry pair: %v`, err)
+		}
+		pairs = append(pairs, &pair)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf(`store: unable to fetch similar entry pairs: %v`, err)
+	}
+
+	return pairs, nil
+}
+
+// MergeEntryClusters puts both entries into the same story cluster, merging their existing clusters if needed.
+// The cluster ID is the lowest entry ID ever seen in the cluster.
+func (s *Storage) MergeEntryClusters(userID, entryID, similarEntryID int64) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf(`store: unable to start transaction: %v`, err)
+	}
+
+	clusterA, err := entryClusterID(tx, entryID)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	clusterB, err := entryClusterID(tx, similarEntryID)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	root := min(clusterA, clusterB)
+
+	_, err = tx.Exec(
+		`UPDATE entry_clusters SET cluster_id=$1 WHERE user_id=$2 AND cluster_id IN ($3, $4)`,
+		root, userID, clusterA, clusterB,
+	)
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf(`store: unable to merge story clusters #%d and #%d: %v`, clusterA, clusterB, err)
+	}
+
+	query := `
+		INSERT INTO entry_clusters
+			(user_id, entry_id, cluster_id)
+		VALUES
+			($1, $2, $4),
+			($1, $3, $4)
+		ON CONFLICT (entry_id) DO UPDATE SET cluster_id=EXCLUDED.cluster_id
+	`
+	if _, err := tx.Exec(query, userID, entryID, similarEntryID, root); err != nil {
+		tx.Rollback()
+		return fmt.Errorf(`store: unable to add entries #%d and #%d to story cluster #%d: %v`, entryID, similarEntryID, root, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf(`store: unable to commit transaction: %v`, err)
+	}
+
+	return nil
+}
+
+// ReplaceEntryClusters replaces all the story clusters of the given user.
+// The clusters map associates each entry ID with its cluster ID.
+func (s *Storage) ReplaceEntryClusters(userID int64, clusters map[int64]int64) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf(`store: unable to start transaction: %v`, err)
+	}
+
+	if _, err := tx.Exec(`DELETE FROM entry_clusters WHERE user_id=$1`, userID); err != nil {
+		tx.Rollback()
+		return fmt.Errorf(`store: unable to remove story clusters: %v`, err)
+	}
+
+	query := `
+		INSERT INTO entry_clusters
+			(user_id, entry_id, cluster_id)
+		SELECT
+			$1, id, $3
+		FROM
+			entries
+		WHERE
+			id=$2 AND user_id=$1
+	`
+	for entryID, clusterID := range clusters {
+		if _, err := tx.Exec(query, userID, entryID, clusterID); err != nil {
+			tx.Rollback()
+			return fmt.Errorf(`store: unable to add entry #%d to story cluster #%d: %v`, entryID, clusterID, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf(`store: unable to commit transaction: %v`, err)
+	}
+
+	return nil
+}
+
+func entryClusterID(tx *sql.Tx, entryID int64) (int64, error) {
+	var clusterID int64
+	err := tx.QueryRow(`SELECT cluster_id FROM entry_clusters WHERE entry_id=$1`, entryID).Scan(&clusterID)
+	switch {
+	case err == sql.ErrNoRows:
+		return entryID, nil
+	case err != nil:
+		return 0, fmt.Errorf(`store: unable to fetch story cluster of entry #%d: %v`, entryID, err)
+	}
+	return clusterID, nil
+}
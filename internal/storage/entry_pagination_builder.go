@@ -9,6 +9,8 @@ import (
 	"strings"
 
 	"miniflux.app/v2/internal/model"
+
+	"github.com/lib/pq"
 )
 
 // EntryPaginationBuilder is a builder for entry prev/next queries.
@@ -29,6 +31,16 @@ func (e *EntryPaginationBuilder) WithSearchQuery(query string) {
 	}
 }
 
+// WithSemanticQuery restricts the entries to the ones sharing terms with a search query
+// and orders them by cosine similarity, like the semantic search of the entry query builder.
+func (e *EntryPaginationBuilder) WithSemanticQuery(terms []string, weights []float64) {
+	nArgs := len(e.args) + 1
+	e.conditions = append(e.conditions, semanticQueryCondition(nArgs, nArgs+1))
+	e.args = append(e.args, pq.Array(terms), pq.Array(weights))
+	e.order = semanticQueryScore(nArgs, nArgs+1)
+	e.direction = "desc"
+}
+
 // WithStarred adds starred to the condition.
 func (e *EntryPaginationBuilder) WithStarred() {
 	e.conditions = append(e.conditions, "e.starred is true")
@@ -67,6 +79,14 @@ func (e *EntryPaginationBuilder) WithTags(tags []string) {
 	}
 }
 
+// WithUserTags adds the user tags to the condition.
+func (e *EntryPaginationBuilder) WithUserTags(tags []string) {
+	for _, tag := range tags {
+		e.conditions = append(e.conditions, fmt.Sprintf("e.user_tags @> ARRAY[$%d]::text[]", len(e.args)+1))
+		e.args = append(e.args, tag)
+	}
+}
+
 // WithGloballyVisible adds global visibility to the condition.
 func (e *EntryPaginationBuilder) WithGloballyVisible() {
 	e.conditions = append(e.conditions, "not c.hide_globally")
@@ -112,13 +132,13 @@ func (e *EntryPaginationBuilder) getPrevNextID(tx *sql.Tx) (prevID int64, nextID
 		WITH entry_pagination AS (
 			SELECT
 				e.id,
-				lag(e.id) over (order by e.%[1]s asc, e.id desc) as prev_id,
-				lead(e.id) over (order by e.%[1]s asc, e.id desc) as next_id
+				lag(e.id) over (order by %[1]s asc, e.id desc) as prev_id,
+				lead(e.id) over (order by %[1]s asc, e.id desc) as next_id
 			FROM entries AS e
 			JOIN feeds AS f ON f.id=e.feed_id
 			JOIN categories c ON c.id = f.category_id
 			WHERE %[2]s
-			ORDER BY e.%[1]s asc, e.id desc
+			ORDER BY %[1]s asc, e.id desc
 		)
 		SELECT prev_id, next_id FROM entry_pagination AS ep WHERE %[3]s;
 	`
@@ -173,7 +193,7 @@ func NewEntryPaginationBuilder(store *Storage, userID, entryID int64, order, dir
 		args:       []interface{}{userID, "removed"},
 		conditions: []string{"e.user_id = $1", "e.status <> $2"},
 		entryID:    entryID,
-		order:      order,
+		order:      "e." + order,
 		direction:  direction,
 	}
 }
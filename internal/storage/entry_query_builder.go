@@ -24,6 +24,8 @@ type EntryQueryBuilder struct {
 	limit           int
 	offset          int
 	fetchEnclosures bool
+	similarityArg   int
+	collapseStories bool
 }
 
 // WithEnclosures fetches enclosures for each entry.
@@ -48,6 +50,83 @@ func (e *EntryQueryBuilder) WithSearchQuery(query string) *EntryQueryBuilder {
 	return e
 }
 
+// WithSemanticQuery restricts the result to entries sharing terms with a search query and sorts them by cosine similarity.
+// The weights are the normalized weights of the query terms, the stored term vectors of the entries are not weighted.
+// Both arguments are referenced by the condition, so that counting the entries without the sorting gets the same arguments.
+func (e *EntryQueryBuilder) WithSemanticQuery(terms []string, weights []float64) *EntryQueryBuilder {
+	nArgs := len(e.args) + 1
+	e.conditions = append(e.conditions, semanticQueryCondition(nArgs, nArgs+1))
+	e.args = append(e.args, pq.Array(terms), pq.Array(weights))
+	e.WithSorting(semanticQueryScore(nArgs, nArgs+1), "DESC")
+	return e
+}
+
+func semanticQueryCondition(termsArg, weightsArg int) string {
+	return fmt.Sprintf(
+		"e.id IN (SELECT entry_id FROM similarity_documents WHERE terms && $%d) AND cardinality($%d::float8[]) > 0",
+		termsArg, weightsArg,
+	)
+}
+
+func semanticQueryScore(termsArg, weightsArg int) string {
+	return fmt.Sprintf(`(
+		SELECT sum(q.weight) / sqrt(max(GREATEST(cardinality(sd.terms), 1)))
+		FROM similarity_documents sd, unnest($%d::text[], $%d::float8[]) AS q(term, weight)
+		WHERE sd.entry_id=e.id AND q.term=ANY(sd.terms)
+	)`, termsArg, weightsArg)
+}
+
+// WithSimilarTo restricts the result to entries similar to the given entry and sorts them by similarity score.
+func (e *EntryQueryBuilder) WithSimilarTo(entryID int64) *EntryQueryBuilder {
+	if entryID != 0 {
+		nArgs := len(e.args) + 1
+		e.conditions = append(e.conditions, fmt.Sprintf(`e.id IN (
+			SELECT similar_entry_id FROM entry_similar WHERE entry_id=$%d
+			UNION
+			SELECT entry_id FROM entry_similar WHERE similar_entry_id=$%d
+		)`, nArgs, nArgs))
+		e.args = append(e.args, entryID)
+		e.similarityArg = nArgs
+		e.WithSorting(e.buildSimilarityColumn(), "DESC")
+	}
+	return e
+}
+
+// WithStoryOf restricts the results to the entries of the story cluster containing the given entry.
+func (e *EntryQueryBuilder) WithStoryOf(entryID int64) *EntryQueryBuilder {
+	if entryID != 0 {
+		e.conditions = append(e.conditions, fmt.Sprintf(`e.id IN (
+			SELECT ec2.entry_id
+			FROM entry_clusters ec1
+			JOIN entry_clusters ec2 ON ec2.cluster_id=ec1.cluster_id AND ec2.user_id=ec1.user_id
+			WHERE ec1.entry_id=$%d
+		)`, len(e.args)+1))
+		e.args = append(e.args, entryID)
+	}
+	return e
+}
+
+// WithoutSimilaritySignature excludes the entries already part of the similarity index.
+func (e *EntryQueryBuilder) WithoutSimilaritySignature() *EntryQueryBuilder {
+	e.conditions = append(e.conditions, "NOT EXISTS (SELECT 1 FROM entry_signatures es WHERE es.entry_id=e.id)")
+	return e
+}
+
+// WithCollapsedStories keeps only the representative entry of each story cluster.
+// The representative is the oldest entry of the cluster having the same status,
+// the number of collapsed siblings is returned in Entry.StorySiblings.
+func (e *EntryQueryBuilder) WithCollapsedStories() *EntryQueryBuilder {
+	e.collapseStories = true
+	e.conditions = append(e.conditions, `NOT EXISTS (
+		SELECT 1
+		FROM entry_clusters ec1
+		JOIN entry_clusters ec2 ON ec2.cluster_id=ec1.cluster_id AND ec2.user_id=ec1.user_id AND ec2.entry_id < ec1.entry_id
+		JOIN entries e2 ON e2.id=ec2.entry_id
+		WHERE ec1.entry_id=e.id AND e2.status=e.status
+	)`)
+	return e
+}
+
 // WithStarred adds starred filter.
 func (e *EntryQueryBuilder) WithStarred(starred bool) *EntryQueryBuilder {
 	if starred {
@@ -72,6 +151,34 @@ func (e *EntryQueryBuilder) AfterChangedDate(date time.Time) *EntryQueryBuilder
 	return e
 }
 
+// AfterCreatedDate adds a condition > created_at
+func (e *EntryQueryBuilder) AfterCreatedDate(date time.Time) *EntryQueryBuilder {
+	e.conditions = append(e.conditions, fmt.Sprintf("e.created_at > $%d", len(e.args)+1))
+	e.args = append(e.args, date)
+	return e
+}
+
+// BeforeCreatedDate adds a condition <= created_at
+func (e *EntryQueryBuilder) BeforeCreatedDate(date time.Time) *EntryQueryBuilder {
+	e.conditions = append(e.conditions, fmt.Sprintf("e.created_at <= $%d", len(e.args)+1))
+	e.args = append(e.args, date)
+	return e
+}
+
+// AfterStarredDate adds a condition > starred_at
+func (e *EntryQueryBuilder) AfterStarredDate(date time.Time) *EntryQueryBuilder {
+	e.conditions = append(e.conditions, fmt.Sprintf("e.starred_at > $%d", len(e.args)+1))
+	e.args = append(e.args, date)
+	return e
+}
+
+// BeforeStarredDate adds a condition <= starred_at
+func (e *EntryQueryBuilder) BeforeStarredDate(date time.Time) *EntryQueryBuilder {
+	e.conditions = append(e.conditions, fmt.Sprintf("e.starred_at <= $%d", len(e.args)+1))
+	e.args = append(e.args, date)
+	return e
+}
+
 // BeforePublishedDate adds a condition < published_at
 func (e *EntryQueryBuilder) BeforePublishedDate(date time.Time) *EntryQueryBuilder {
 	e.conditions = append(e.conditions, fmt.Sprintf("e.published_at < $%d", len(e.args)+1))
@@ -167,6 +274,26 @@ func (e *EntryQueryBuilder) WithTags(tags []string) *EntryQueryBuilder {
 	return e
 }
 
+// WithUserTags filter by a list of user tags.
+func (e *EntryQueryBuilder) WithUserTags(tags []string) *EntryQueryBuilder {
+	for _, tag := range tags {
+		e.conditions = append(e.conditions, fmt.Sprintf("e.user_tags @> ARRAY[$%d]::text[]", len(e.args)+1))
+		e.args = append(e.args, tag)
+	}
+	return e
+}
+
+// WithLabel filter by category title or user tag, the Google Reader labels being both.
+func (e *EntryQueryBuilder) WithLabel(label string) *EntryQueryBuilder {
+	e.conditions = append(e.conditions, fmt.Sprintf(
+		"(f.category_id IN (SELECT id FROM categories WHERE user_id=e.user_id AND title=$%d) OR e.user_tags @> ARRAY[$%d]::text[])",
+		len(e.args)+1,
+		len(e.args)+1,
+	))
+	e.args = append(e.args, label)
+	return e
+}
+
 // WithoutStatus set the entry status that should not be returned.
 func (e *EntryQueryBuilder) WithoutStatus(status string) *EntryQueryBuilder {
 	if status != "" {
@@ -276,6 +403,8 @@ func (e *EntryQueryBuilder) EntryProcessor(proc func(entry *model.Entry) error)
 			e.created_at,
 			e.changed_at,
 			e.tags,
+			e.user_tags,
+			e.podcast,
 			(SELECT true FROM enclosures WHERE entry_id=e.id LIMIT 1) as has_enclosure,
 			f.title as feed_title,
 			f.feed_url,
@@ -293,7 +422,9 @@ func (e *EntryQueryBuilder) EntryProcessor(proc func(entry *model.Entry) error)
 			f.hide_globally,
 			f.no_media_player,
 			fi.icon_id,
-			u.timezone
+			u.timezone,
+			%s as similarity,
+			%s as story_siblings
 		FROM
 			entries e
 		LEFT JOIN
@@ -309,7 +440,7 @@ func (e *EntryQueryBuilder) EntryProcessor(proc func(entry *model.Entry) error)
 
 	condition := e.buildCondition()
 	sorting := e.buildSorting()
-	query = fmt.Sprintf(query, condition, sorting)
+	query = fmt.Sprintf(query, e.buildSimilarityColumn(), e.buildStorySiblingsColumn(), condition, sorting)
 
 	rows, err := e.store.db.Query(query, e.args...)
 	if err != nil {
@@ -342,6 +473,8 @@ func (e *EntryQueryBuilder) EntryProcessor(proc func(entry *model.Entry) error)
 			&entry.CreatedAt,
 			&entry.ChangedAt,
 			pq.Array(&entry.Tags),
+			pq.Array(&entry.UserTags),
+			&entry.Podcast,
 			&hasEnclosure,
 			&entry.Feed.Title,
 			&entry.Feed.FeedURL,
@@ -360,6 +493,8 @@ func (e *EntryQueryBuilder) EntryProcessor(proc func(entry *model.Entry) error)
 			&entry.Feed.NoMediaPlayer,
 			&iconID,
 			&tz,
+			&entry.Similarity,
+			&entry.StorySiblings,
 		)
 
 		if err != nil {
@@ -451,6 +586,32 @@ func (e *EntryQueryBuilder) buildCondition() string {
 	return strings.Join(e.conditions, " AND ")
 }
 
+func (e *EntryQueryBuilder) buildSimilarityColumn() string {
+	if e.similarityArg == 0 {
+		return "0::float8"
+	}
+
+	return fmt.Sprintf(`(
+		SELECT coalesce(max(es.similarity), 0)
+		FROM entry_similar es
+		WHERE (es.entry_id=$%[1]d AND es.similar_entry_id=e.id) OR (es.entry_id=e.id AND es.similar_entry_id=$%[1]d)
+	)`, e.similarityArg)
+}
+
+func (e *EntryQueryBuilder) buildStorySiblingsColumn() string {
+	if !e.collapseStories {
+		return "0"
+	}
+
+	return `(
+		SELECT count(*)
+		FROM entry_clusters ec1
+		JOIN entry_clusters ec2 ON ec2.cluster_id=ec1.cluster_id AND ec2.user_id=ec1.user_id AND ec2.entry_id <> ec1.entry_id
+		JOIN entries e2 ON e2.id=ec2.entry_id
+		WHERE ec1.entry_id=e.id AND e2.status=e.status
+	)`
+}
+
 func (e *EntryQueryBuilder) buildSorting() string {
 	var parts string
 
@@ -484,3 +645,12 @@ func NewAnonymousQueryBuilder(store *Storage) *EntryQueryBuilder {
 		store: store,
 	}
 }
+
+// Clone returns a copy of the builder that can be modified without affecting the original.
+func (e *EntryQueryBuilder) Clone() *EntryQueryBuilder {
+	clone := *e
+	clone.args = append([]interface{}(nil), e.args...)
+	clone.conditions = append([]string(nil), e.conditions...)
+	clone.sortExpressions = append([]string(nil), e.sortExpressions...)
+	return &clone
+}
@@ -0,0 +1,158 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package storage // import "miniflux.app/v2/internal/storage"
+
+import (
+	"database/sql"
+	"fmt"
+	"unicode/utf8"
+
+	"miniflux.app/v2/internal/htmldiff"
+	"miniflux.app/v2/internal/model"
+)
+
+const (
+	// maxEntryRevisions is the number of previous versions kept for each entry.
+	maxEntryRevisions = 20
+
+	// substantialChangeRatio is the proportion of changed words above which an entry is marked as unread again.
+	substantialChangeRatio = 0.2
+
+	// maxChangeRatioLength is the number of characters of each version compared to detect a substantial change.
+	maxChangeRatioLength = 100_000
+)
+
+// saveEntryRevision keeps the current title and content of an existing entry before the feed overwrites them.
+// The versions are compared by their hashes and copied by the database, the previous content is never loaded.
+// It returns true when the entry changed and its feed marks changed entries as unread.
+func (s *Storage) saveEntryRevision(tx *sql.Tx, entry *model.Entry) (bool, error) {
+	var entryID int64
+	var unchanged, markUnreadOnChange bool
+
+	err := tx.QueryRow(`
+		SELECT
+			e.id, e.title=$3 AND md5(e.content)=md5($4), f.mark_unread_on_change
+		FROM
+			entries e
+		JOIN
+			feeds f ON f.id=e.feed_id
+		WHERE
+			e.feed_id=$1 AND e.hash=$2
+		FOR UPDATE OF e
+	`, entry.FeedID, entry.Hash, entry.Title, entry.Content).Scan(&entryID, &unchanged, &markUnreadOnChange)
+	if err != nil {
+		return false, fmt.Errorf(`store: unable to fetch entry %q: %v`, entry.URL, err)
+	}
+
+	if unchanged {
+		return false, nil
+	}
+
+	_, err = tx.Exec(`
+		INSERT INTO entry_revisions
+			(user_id, entry_id, title, content)
+		SELECT
+			user_id, id, title, content
+		FROM
+			entries
+		WHERE
+			id=$1
+	`, entryID)
+	if err != nil {
+		return false, fmt.Errorf(`store: unable to create revision of entry #%d: %v`, entryID, err)
+	}
+
+	_, err = tx.Exec(`
+		DELETE FROM
+			entry_revisions
+		WHERE
+			entry_id=$1 AND id NOT IN (
+				SELECT id FROM entry_revisions WHERE entry_id=$1 ORDER BY id DESC LIMIT $2
+			)
+	`, entryID, maxEntryRevisions)
+	if err != nil {
+		return false, fmt.Errorf(`store: unable to remove old revisions of entry #%d: %v`, entryID, err)
+	}
+
+	return markUnreadOnChange, nil
+}
+
+// markUnreadOnSubstantialChange marks a read entry as unread again when its content changed substantially
+// since its last revision. It runs once the entry is updated, outside of the refresh transaction.
+func (s *Storage) markUnreadOnSubstantialChange(entry *model.Entry) error {
+	var previousContent string
+	err := s.db.QueryRow(
+		`SELECT left(content, $2) FROM entry_revisions WHERE entry_id=$1 ORDER BY id DESC LIMIT 1`,
+		entry.ID,
+		maxChangeRatioLength,
+	).Scan(&previousContent)
+	if err != nil {
+		return fmt.Errorf(`store: unable to fetch the last revision of entry #%d: %v`, entry.ID, err)
+	}
+
+	content := entry.Content
+	if utf8.RuneCountInString(content) > maxChangeRatioLength {
+		content = string([]rune(content)[:maxChangeRatioLength])
+	}
+
+	if htmldiff.ChangeRatio(previousContent, content) < substantialChangeRatio {
+		return nil
+	}
+
+	if _, err := s.db.Exec(`UPDATE entries SET status='unread' WHERE id=$1 AND status='read'`, entry.ID); err != nil {
+		return fmt.Errorf(`store: unable to mark entry #%d as unread: %v`, entry.ID, err)
+	}
+
+	return nil
+}
+
+// EntryRevisions returns the previous versions of an entry, the most recent first.
+func (s *Storage) EntryRevisions(userID, entryID int64) (model.EntryRevisions, error) {
+	rows, err := s.db.Query(`
+		SELECT
+			id, user_id, entry_id, title, content, created_at
+		FROM
+			entry_revisions
+		WHERE
+			user_id=$1 AND entry_id=$2
+		ORDER BY
+			id DESC
+	`, userID, entryID)
+	if err != nil {
+		return nil, fmt.Errorf(`store: unable to fetch revisions of entry #%d: %v`, entryID, err)
+	}
+	defer rows.Close()
+
+	revisions := make(model.EntryRevisions, 0)
+	for rows.Next() {
+		var revision model.EntryRevision
+		if err := rows.Scan(
+			&revision.ID,
+			&revision.UserID,
+			&revision.EntryID,
+			&revision.Title,
+			&revision.Content,
+			&revision.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf(`store: unable to fetch entry revision row: %v`, err)
+		}
+		revisions = append(revisions, &revision)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf(`store: unable to fetch revisions of entry #%d: %v`, entryID, err)
+	}
+
+	return revisions, nil
+}
+
+// CountEntryRevisions returns the number of previous versions of an entry.
+func (s *Storage) CountEntryRevisions(userID, entryID int64) (int, error) {
+	var count int
+	err := s.db.QueryRow(`SELECT count(*) FROM entry_revisions WHERE user_id=$1 AND entry_id=$2`, userID, entryID).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf(`store: unable to count revisions of entry #%d: %v`, entryID, err)
+	}
+	return count, nil
+}
@@ -0,0 +1,147 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package storage // import "miniflux.app/v2/internal/storage"
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"miniflux.app/v2/internal/model"
+)
+
+// EntryRules returns the rules of a user, in creation order.
+func (s *Storage) EntryRules(userID int64) (model.EntryRules, error) {
+	rows, err := s.db.Query(`
+		SELECT
+			id, user_id, title, disabled, match_all, conditions, actions, created_at
+		FROM
+			entry_rules
+		WHERE
+			user_id=$1
+		ORDER BY
+			id ASC
+	`, userID)
+	if err != nil {
+		return nil, fmt.Errorf(`store: unable to fetch entry rules: %v`, err)
+	}
+	defer rows.Close()
+
+	rules := make(model.EntryRules, 0)
+	for rows.Next() {
+		var rule model.EntryRule
+		if err := rows.Scan(
+			&rule.ID,
+			&rule.UserID,
+			&rule.Title,
+			&rule.Disabled,
+			&rule.MatchAll,
+			&rule.Conditions,
+			&rule.Actions,
+			&rule.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf(`store: unable to fetch entry rule row: %v`, err)
+		}
+		rules = append(rules, &rule)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf(`store: unable to fetch entry rules: %v`, err)
+	}
+
+	return rules, nil
+}
+
+// EntryRuleByID returns a rule of a user.
+func (s *Storage) EntryRuleByID(userID, ruleID int64) (*model.EntryRule, error) {
+	var rule model.EntryRule
+	err := s.db.QueryRow(`
+		SELECT
+			id, user_id, title, disabled, match_all, conditions, actions, created_at
+		FROM
+			entry_rules
+		WHERE
+			user_id=$1 AND id=$2
+	`, userID, ruleID).Scan(
+		&rule.ID,
+		&rule.UserID,
+		&rule.Title,
+		&rule.Disabled,
+		&rule.MatchAll,
+		&rule.Conditions,
+		&rule.Actions,
+		&rule.CreatedAt,
+	)
+
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return nil, nil
+	case err != nil:
+		return nil, fmt.Errorf(`store: unable to fetch entry rule #%d: %v`, ruleID, err)
+	}
+
+	return &rule, nil
+}
+
+// CreateEntryRule creates a new rule.
+func (s *Storage) CreateEntryRule(rule *model.EntryRule) error {
+	err := s.db.QueryRow(`
+		INSERT INTO entry_rules
+			(user_id, title, disabled, match_all, conditions, actions)
+		VALUES
+			($1, $2, $3, $4, $5, $6)
+		RETURNING
+			id, created_at
+	`,
+		rule.UserID,
+		rule.Title,
+		rule.Disabled,
+		rule.MatchAll,
+		rule.Conditions,
+		rule.Actions,
+	).Scan(&rule.ID, &rule.CreatedAt)
+	if err != nil {
+		return fmt.Errorf(`store: unable to create entry rule %q: %v`, rule.Title, err)
+	}
+
+	return nil
+}
+
+// UpdateEntryRule updates an existing rule.
+func (s *Storage) UpdateEntryRule(rule *model.EntryRule) error {
+	_, err := s.db.Exec(`
+		UPDATE
+			entry_rules
+		SET
+			title=$1,
+			disabled=$2,
+			match_all=$3,
+			conditions=$4,
+			actions=$5
+		WHERE
+			id=$6 AND user_id=$7
+	`,
+		rule.Title,
+		rule.Disabled,
+		rule.MatchAll,
+		rule.Conditions,
+		rule.Actions,
+		rule.ID,
+		rule.UserID,
+	)
+	if err != nil {
+		return fmt.Errorf(`store: unable to update entry rule #%d: %v`, rule.ID, err)
+	}
+
+	return nil
+}
+
+// RemoveEntryRule deletes a rule.
+func (s *Storage) RemoveEntryRule(userID, ruleID int64) error {
+	if _, err := s.db.Exec(`DELETE FROM entry_rules WHERE id=$1 AND user_id=$2`, ruleID, userID); err != nil {
+		return fmt.Errorf(`store: unable to remove entry rule #%d: %v`, ruleID, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,66 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package storage // import "miniflux.app/v2/internal/storage"
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/lib/pq"
+)
+
+// UpdateEntrySignature stores the locality-sensitive hashing buckets of an entry.
+func (s *Storage) UpdateEntrySignature(userID, entryID int64, buckets []int64) error {
+	query := `
+		INSERT INTO entry_signatures
+			(entry_id, user_id, buckets)
+		VALUES
+			($1, $2, $3)
+		ON CONFLICT (entry_id) DO UPDATE SET buckets=EXCLUDED.buckets
+	`
+	if _, err := s.db.Exec(query, entryID, userID, pq.Array(buckets)); err != nil {
+		return fmt.Errorf(`store: unable to update signature of entry #%d: %v`, entryID, err)
+	}
+	return nil
+}
+
+// SimilarityCandidates returns the IDs of the user's entries older than the given entry sharing at least one bucket with it.
+func (s *Storage) SimilarityCandidates(userID, entryID int64, buckets []int64) ([]int64, error) {
+	query := `
+		SELECT
+			entry_id
+		FROM
+			entry_signatures
+		WHERE
+			user_id=$1 AND entry_id < $2 AND buckets && $3
+	`
+	rows, err := s.db.Query(query, userID, entryID, pq.Array(buckets))
+	if err != nil {
+		return nil, fmt.Errorf(`store: unable to fetch similarity candidates of entry #%d: %v`, entryID, err)
+	}
+	defer rows.Close()
+
+	entryIDs := make([]int64, 0)
+	for rows.Next() {
+		var candidateID int64
+		if err := rows.Scan(&candidateID); err != nil {
+			return nil, fmt.Errorf(`store: unable to fetch similarity candidate row: %v`, err)
+		}
+		entryIDs = append(entryIDs, candidateID)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf(`store: unable to fetch similarity candidates: %v`, err)
+	}
+
+	return entryIDs, nil
+}
+
+// removeEntrySignatures removes the given entries from the similarity index.
+func removeEntrySignatures(tx *sql.Tx, entryIDs []int64) error {
+	if _, err := tx.Exec(`DELETE FROM entry_signatures WHERE entry_id=ANY($1)`, pq.Array(entryIDs)); err != nil {
+		return fmt.Errorf(`store: unable to remove entries from the similarity index: %v`, err)
+	}
+	return nil
+}
@@ -5,7 +5,10 @@ package storage // import "miniflux.app/v2/internal/storage"
 
 import (
 	"fmt"
+
 	"miniflux.app/v2/internal/model"
+
+	"github.com/lib/pq"
 )
 
 // CreateSimilarEntry add a new entry similar.
@@ -30,7 +33,26 @@ func (s *Storage) CreateSimilarEntry(similar *model.EntrySimilar) error {
 	return nil
 }
 
-// FindSimilarEntries returns a list of similar entries.
+// MarkSimilarEntriesAsRead marks as read the user's unread entries similar to the given entry.
+// It returns the number of entries marked as read that are visible in the unread list.
+func (s *Storage) MarkSimilarEntriesAsRead(userID, entryID int64) (int, error) {
+	builder := s.NewEntryQueryBuilder(userID)
+	builder.WithSimilarTo(entryID)
+	builder.WithStatus(model.EntryStatusUnread)
+
+	entryIDs, err := builder.GetEntryIDs()
+	if err != nil {
+		return 0, err
+	}
+
+	if len(entryIDs) == 0 {
+		return 0, nil
+	}
+
+	return s.SetEntriesStatusCount(userID, entryIDs, model.EntryStatusRead)
+}
+
+// FindSimilarEntries returns the similarity pairs involving the given entry, ordered by score.
 func (s *Storage) FindSimilarEntries(entryID int64) ([]*model.EntrySimilar, error) {
 	query := `
 		SELECT
@@ -38,22 +60,73 @@ func (s *Storage) FindSimilarEntries(entryID int64) ([]*model.EntrySimilar, erro
 		FROM
 			entry_similar
 		WHERE
-			entry_id=$1
+			entry_id=$1 OR similar_entry_id=$1
+		ORDER BY
+			similarity DESC
 	`
 	rows, err := s.db.Query(query, entryID)
 	if err != nil {
 		return nil, fmt.Errorf(`store: unable to fetch similar entries: %v`, err)
 	}
-	defer func() {
-		err = rows.Close()
-		if err != nil {
-			fmt.Printf(`store: unable to close similar entries rows: %v`, err)
+	defer rows.Close()
+
+	similars := make([]*model.EntrySimilar, 0)
+	for rows.Next() {
+		var similar model.EntrySimilar
+		if err := rows.Scan(&similar.EntryID, &similar.SimilarEntryID, &similar.Similarity); err != nil {
+			return nil, fmt.Errorf(`store: unable to scan similar entries: %v`, err)
 		}
-	}()
-	similar := make([]*model.EntrySimilar, 0)
-	err = rows.Scan(&similar)
+		similars = append(similars, &similar)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf(`store: unable to fetch similar entries: %v`, err)
+	}
+
+	return similars, nil
+}
+
+// DuplicateEntryIDs returns the given entries that are similar to an entry the user already read.
+// When categoryIDs is not empty, only the entries of feeds in those categories are returned.
+func (s *Storage) DuplicateEntryIDs(userID int64, entryIDs []int64, threshold float64, categoryIDs []int64) ([]int64, error) {
+	query := `
+		SELECT DISTINCT
+			e.id
+		FROM
+			entries e
+		JOIN
+			feeds f ON f.id=e.feed_id
+		JOIN
+			entry_similar es ON es.entry_id=e.id OR es.similar_entry_id=e.id
+		JOIN
+			entries r ON r.id=(CASE WHEN es.entry_id=e.id THEN es.similar_entry_id ELSE es.entry_id END)
+		WHERE
+			e.user_id=$1 AND
+			e.id=ANY($2) AND
+			e.status=$3 AND
+			es.similarity >= $4 AND
+			r.user_id=$1 AND
+			r.status=$5 AND
+			(cardinality($6::bigint[]) = 0 OR f.category_id=ANY($6))
+	`
+	rows, err := s.db.Query(query, userID, pq.Array(entryIDs), model.EntryStatusUnread, threshold, model.EntryStatusRead, pq.Array(categoryIDs))
 	if err != nil {
-		return nil, fmt.Errorf(`store: unable to scan similar entries: %v`, err)
+		return nil, fmt.Errorf(`store: unable to fetch duplicate entries: %v`, err)
+	}
+	defer rows.Close()
+
+	duplicateIDs := make([]int64, 0)
+	for rows.Next() {
+		var entryID int64
+		if err := rows.Scan(&entryID); err != nil {
+			return nil, fmt.Errorf(`store: unable to fetch duplicate entry row: %v`, err)
+		}
+		duplicateIDs = append(duplicateIDs, entryID)
 	}
-	return similar, nil
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf(`store: unable to fetch duplicate entries: %v`, err)
+	}
+
+	return duplicateIDs, nil
 }
@@ -239,10 +239,13 @@ func (s *Storage) CreateFeed(feed *model.Feed) error {
 			no_media_player,
 			apprise_service_urls,
 			disable_http2,
-			description
+			description,
+			page_watcher_rules,
+			json_mapping,
+			mark_unread_on_change
 		)
 		VALUES
-			($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24, $25, $26)
+			($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24, $25, $26, $27, $28, $29)
 		RETURNING
 			id
 	`
@@ -274,6 +277,9 @@ func (s *Storage) CreateFeed(feed *model.Feed) error {
 		feed.AppriseServiceURLs,
 		feed.DisableHTTP2,
 		feed.Description,
+		feed.PageWatcherRules,
+		feed.JSONMapping,
+		feed.MarkUnreadOnChange,
 	).Scan(&feed.ID)
 	if err != nil {
 		return fmt.Errorf(`store: unable to create feed %q: %v`, feed.FeedURL, err)
@@ -319,6 +325,7 @@ func (s *Storage) UpdateFeed(feed *model.Feed) (err error) {
 		UPDATE
 			feeds
 		SET
+			redirected_url=CASE WHEN feed_url=$1 THEN redirected_url ELSE '' END,
 			feed_url=$1,
 			site_url=$2,
 			title=$3,
@@ -347,9 +354,12 @@ func (s *Storage) UpdateFeed(feed *model.Feed) (err error) {
 			no_media_player=$26,
 			apprise_service_urls=$27,
 			disable_http2=$28,
-			description=$29
+			description=$29,
+			page_watcher_rules=$30,
+			json_mapping=$31,
+			mark_unread_on_change=$32
 		WHERE
-			id=$30 AND user_id=$31
+			id=$33 AND user_id=$34
 	`
 	_, err = s.db.Exec(query,
 		feed.FeedURL,
@@ -381,6 +391,9 @@ func (s *Storage) UpdateFeed(feed *model.Feed) (err error) {
 		feed.AppriseServiceURLs,
 		feed.DisableHTTP2,
 		feed.Description,
+		feed.PageWatcherRules,
+		feed.JSONMapping,
+		feed.MarkUnreadOnChange,
 		feed.ID,
 		feed.UserID,
 	)
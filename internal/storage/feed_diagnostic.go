@@ -0,0 +1,96 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package storage // import "miniflux.app/v2/internal/storage"
+
+import (
+	"fmt"
+
+	"miniflux.app/v2/internal/model"
+)
+
+// maxFeedDiagnostics is the number of refreshes kept for each feed.
+const maxFeedDiagnostics = 500
+
+// CreateFeedDiagnostic records a feed refresh and removes the oldest records.
+func (s *Storage) CreateFeedDiagnostic(diagnostic *model.FeedDiagnostic) error {
+	err := s.db.QueryRow(`
+		INSERT INTO feed_diagnostics
+			(user_id, feed_id, status_code, duration, response_size, not_modified, error_msg, new_entries)
+		VALUES
+			($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING
+			id, checked_at
+	`,
+		diagnostic.UserID,
+		diagnostic.FeedID,
+		diagnostic.StatusCode,
+		diagnostic.Duration,
+		diagnostic.ResponseSize,
+		diagnostic.NotModified,
+		diagnostic.ErrorMsg,
+		diagnostic.NewEntries,
+	).Scan(&diagnostic.ID, &diagnostic.CheckedAt)
+	if err != nil {
+		return fmt.Errorf(`store: unable to create diagnostic for feed #%d: %v`, diagnostic.FeedID, err)
+	}
+
+	_, err = s.db.Exec(`
+		DELETE FROM
+			feed_diagnostics
+		WHERE
+			feed_id=$1 AND id <= (
+				SELECT id FROM feed_diagnostics WHERE feed_id=$1 ORDER BY id DESC OFFSET $2 LIMIT 1
+			)
+	`, diagnostic.FeedID, maxFeedDiagnostics)
+	if err != nil {
+		return fmt.Errorf(`store: unable to remove old diagnostics of feed #%d: %v`, diagnostic.FeedID, err)
+	}
+
+	return nil
+}
+
+// FeedDiagnostics returns the most recent refreshes of a feed, the most recent first.
+func (s *Storage) FeedDiagnostics(userID, feedID int64, limit int) (model.FeedDiagnostics, error) {
+	rows, err := s.db.Query(`
+		SELECT
+			id, user_id, feed_id, checked_at, status_code, duration, response_size, not_modified, error_msg, new_entries
+		FROM
+			feed_diagnostics
+		WHERE
+			user_id=$1 AND feed_id=$2
+		ORDER BY
+			id DESC
+		LIMIT $3
+	`, userID, feedID, limit)
+	if err != nil {
+		return nil, fmt.Errorf(`store: unable to fetch diagnostics of feed #%d: %v`, feedID, err)
+	}
+	defer rows.Close()
+
+	diagnostics := make(model.FeedDiagnostics, 0)
+	for rows.Next() {
+		var diagnostic model.FeedDiagnostic
+		if err := rows.Scan(
+			&diagnostic.ID,
+			&diagnostic.UserID,
+			&diagnostic.FeedID,
+			&diagnostic.CheckedAt,
+			&diagnostic.StatusCode,
+			&diagnostic.Duration,
+			&diagnostic.ResponseSize,
+			&diagnostic.NotModified,
+			&diagnostic.ErrorMsg,
+			&diagnostic.NewEntries,
+		); err != nil {
+			return nil, fmt.Errorf(`store: unable to fetch feed diagnostic row: %v`, err)
+		}
+		diagnostics = append(diagnostics, &diagnostic)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf(`store: unable to fetch diagnostics of feed #%d: %v`, feedID, err)
+	}
+
+	return diagnostics, nil
+}
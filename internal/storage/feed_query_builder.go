@@ -165,7 +165,12 @@ func (f *FeedQueryBuilder) GetFeeds() (model.Feeds, error) {
 			fi.icon_id,
 			u.timezone,
 			f.apprise_service_urls,
-			f.disable_http2
+			f.disable_http2,
+			f.page_watcher_rules,
+			f.json_mapping,
+			f.mark_unread_on_change,
+			f.stale_since,
+			f.redirected_url
 		FROM
 			feeds f
 		LEFT JOIN
@@ -234,6 +239,11 @@ func (f *FeedQueryBuilder) GetFeeds() (model.Feeds, error) {
 			&tz,
 			&feed.AppriseServiceURLs,
 			&feed.DisableHTTP2,
+			&feed.PageWatcherRules,
+			&feed.JSONMapping,
+			&feed.MarkUnreadOnChange,
+			&feed.StaleSince,
+			&feed.RedirectedURL,
 		)
 
 		if err != nil {
@@ -0,0 +1,139 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package storage // import "miniflux.app/v2/internal/storage"
+
+import (
+	"fmt"
+
+	"miniflux.app/v2/internal/model"
+)
+
+// A suggestion dismissed by the user is shown again only when the feed state changes afterward.
+const feedSuggestionsQuery = `
+	SELECT
+		f.id, f.title, f.feed_url, f.site_url, 'moved', NULL::timestamp with time zone, f.redirected_url
+	FROM
+		feeds f
+	WHERE
+		f.user_id=$1 AND
+		f.redirected_url <> '' AND
+		(f.suggestions_dismissed_at IS NULL OR f.suggestions_dismissed_at < f.redirected_at)
+	UNION ALL
+	SELECT
+		f.id, f.title, f.feed_url, f.site_url, 'stale', f.stale_since, ''
+	FROM
+		feeds f
+	WHERE
+		f.user_id=$1 AND
+		f.stale_since IS NOT NULL AND
+		(f.suggestions_dismissed_at IS NULL OR f.suggestions_dismissed_at < f.stale_since)
+`
+
+// FlagStaleFeeds records the feeds without new entries for the given number of days
+// while being checked during that period, and unflags the feeds that got new entries.
+func (s *Storage) FlagStaleFeeds(days int) (int64, error) {
+	_, err := s.db.Exec(`
+		UPDATE
+			feeds f
+		SET
+			stale_since=NULL
+		WHERE
+			f.stale_since IS NOT NULL AND
+			EXISTS (SELECT 1 FROM entries e WHERE e.feed_id=f.id AND e.created_at > f.stale_since)
+	`)
+	if err != nil {
+		return 0, fmt.Errorf(`store: unable to unflag stale feeds: %v`, err)
+	}
+
+	result, err := s.db.Exec(`
+		UPDATE
+			feeds f
+		SET
+			stale_since=e.last_entry_at
+		FROM
+			(SELECT feed_id, max(created_at) AS last_entry_at FROM entries GROUP BY feed_id) e
+		WHERE
+			e.feed_id=f.id AND
+			f.stale_since IS NULL AND
+			f.disabled='f' AND
+			f.parsing_error_count=0 AND
+			e.last_entry_at < now() - make_interval(days => $1) AND
+			f.checked_at > e.last_entry_at + make_interval(days => $1)
+	`, days)
+	if err != nil {
+		return 0, fmt.Errorf(`store: unable to flag stale feeds: %v`, err)
+	}
+
+	count, _ := result.RowsAffected()
+	return count, nil
+}
+
+// UpdateFeedRedirection records the new location of a feed permanently redirected, or clears it with an empty URL.
+func (s *Storage) UpdateFeedRedirection(userID, feedID int64, redirectedURL string) error {
+	_, err := s.db.Exec(`
+		UPDATE
+			feeds
+		SET
+			redirected_url=$1,
+			redirected_at=CASE WHEN $1='' THEN NULL ELSE now() END
+		WHERE
+			id=$2 AND user_id=$3
+	`, redirectedURL, feedID, userID)
+	if err != nil {
+		return fmt.Errorf(`store: unable to update redirection of feed #%d: %v`, feedID, err)
+	}
+
+	return nil
+}
+
+// DismissFeedSuggestions hides the current suggestions of a feed.
+func (s *Storage) DismissFeedSuggestions(userID, feedID int64) error {
+	_, err := s.db.Exec(`UPDATE feeds SET suggestions_dismissed_at=now() WHERE id=$1 AND user_id=$2`, feedID, userID)
+	if err != nil {
+		return fmt.Errorf(`store: unable to dismiss suggestions of feed #%d: %v`, feedID, err)
+	}
+
+	return nil
+}
+
+// FeedSuggestions returns the suggestions about the feeds of a user.
+func (s *Storage) FeedSuggestions(userID int64) (model.FeedSuggestions, error) {
+	rows, err := s.db.Query(feedSuggestionsQuery+` ORDER BY 2, 1`, userID)
+	if err != nil {
+		return nil, fmt.Errorf(`store: unable to fetch feed suggestions: %v`, err)
+	}
+	defer rows.Close()
+
+	suggestions := make(model.FeedSuggestions, 0)
+	for rows.Next() {
+		var suggestion model.FeedSuggestion
+		if err := rows.Scan(
+			&suggestion.FeedID,
+			&suggestion.FeedTitle,
+			&suggestion.FeedURL,
+			&suggestion.SiteURL,
+			&suggestion.Reason,
+			&suggestion.LastEntryAt,
+			&suggestion.NewFeedURL,
+		); err != nil {
+			return nil, fmt.Errorf(`store: unable to fetch feed suggestion row: %v`, err)
+		}
+		suggestions = append(suggestions, &suggestion)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf(`store: unable to fetch feed suggestions: %v`, err)
+	}
+
+	return suggestions, nil
+}
+
+// CountFeedSuggestions returns the number of suggestions about the feeds of a user.
+func (s *Storage) CountFeedSuggestions(userID int64) int {
+	var count int
+	if err := s.db.QueryRow(`SELECT count(*) FROM (`+feedSuggestionsQuery+`) s`, userID).Scan(&count); err != nil {
+		return 0
+	}
+	return count
+}
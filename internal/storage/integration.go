@@ -539,3 +539,13 @@ func (s *Storage) HasSaveEntry(userID int64) (result bool) {
 
 	return result
 }
+
+// HasAnnotationsExport returns true if the user can export the highlights of an entry to a third-party service.
+func (s *Storage) HasAnnotationsExport(userID int64) (result bool) {
+	query := `SELECT true FROM integrations WHERE user_id=$1 AND readwise_enabled='t'`
+	if err := s.db.QueryRow(query, userID).Scan(&result); err != nil {
+		result = false
+	}
+
+	return result
+}
@@ -0,0 +1,190 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package storage // import "miniflux.app/v2/internal/storage"
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/lib/pq"
+
+	"miniflux.app/v2/internal/model"
+)
+
+const integrationDeliveryColumns = `
+	id, user_id, integration, event_type, feed_id, entry_ids, status, attempts, last_error, created_at, next_attempt_at, delivered_at
+`
+
+// CreateIntegrationDelivery records a new event for a third-party integration.
+// The delivery is leased for a few minutes, giving time to the caller to attempt it before the background worker does.
+func (s *Storage) CreateIntegrationDelivery(delivery *model.IntegrationDelivery) error {
+	err := s.db.QueryRow(`
+		INSERT INTO integration_deliveries
+			(user_id, integration, event_type, feed_id, entry_ids, next_attempt_at)
+		VALUES
+			($1, $2, $3, $4, $5, now() + interval '5 minutes')
+		RETURNING
+			id, status, created_at, next_attempt_at
+	`,
+		delivery.UserID,
+		delivery.Integration,
+		delivery.EventType,
+		delivery.FeedID,
+		pq.Array(delivery.EntryIDs),
+	).Scan(&delivery.ID, &delivery.Status, &delivery.CreatedAt, &delivery.NextAttemptAt)
+	if err != nil {
+		return fmt.Errorf(`store: unable to create %s delivery: %v`, delivery.Integration, err)
+	}
+
+	return nil
+}
+
+// UpdateIntegrationDelivery saves the outcome of a delivery attempt.
+func (s *Storage) UpdateIntegrationDelivery(delivery *model.IntegrationDelivery) error {
+	_, err := s.db.Exec(`
+		UPDATE
+			integration_deliveries
+		SET
+			status=$1,
+			attempts=$2,
+			last_error=$3,
+			next_attempt_at=$4,
+			delivered_at=$5
+		WHERE
+			id=$6 AND user_id=$7
+	`,
+		delivery.Status,
+		delivery.Attempts,
+		delivery.LastError,
+		delivery.NextAttemptAt,
+		delivery.DeliveredAt,
+		delivery.ID,
+		delivery.UserID,
+	)
+	if err != nil {
+		return fmt.Errorf(`store: unable to update delivery #%d: %v`, delivery.ID, err)
+	}
+
+	return nil
+}
+
+// ClaimIntegrationDeliveries returns the pending deliveries due for a new attempt,
+// and leases them for a few minutes so that concurrent workers skip them.
+func (s *Storage) ClaimIntegrationDeliveries(limit int) (model.IntegrationDeliveries, error) {
+	rows, err := s.db.Query(`
+		UPDATE
+			integration_deliveries
+		SET
+			next_attempt_at=now() + interval '5 minutes'
+		WHERE
+			id IN (
+				SELECT
+					id
+				FROM
+					integration_deliveries
+				WHERE
+					status='pending' AND next_attempt_at <= now()
+				ORDER BY
+					next_attempt_at ASC
+				LIMIT $1
+				FOR UPDATE SKIP LOCKED
+			)
+		RETURNING `+integrationDeliveryColumns,
+		limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf(`store: unable to claim integration deliveries: %v`, err)
+	}
+	defer rows.Close()
+
+	return scanIntegrationDeliveries(rows)
+}
+
+// IntegrationDeliveries returns the most recent deliveries of a user.
+func (s *Storage) IntegrationDeliveries(userID int64, limit int) (model.IntegrationDeliveries, error) {
+	rows, err := s.db.Query(`
+		SELECT `+integrationDeliveryColumns+`
+		FROM
+			integration_deliveries
+		WHERE
+			user_id=$1
+		ORDER BY
+			id DESC
+		LIMIT $2
+	`, userID, limit)
+	if err != nil {
+		return nil, fmt.Errorf(`store: unable to fetch integration deliveries: %v`, err)
+	}
+	defer rows.Close()
+
+	return scanIntegrationDeliveries(rows)
+}
+
+// IntegrationDeliveryByID returns a delivery of a user.
+func (s *Storage) IntegrationDeliveryByID(userID, deliveryID int64) (*model.IntegrationDelivery, error) {
+	rows, err := s.db.Query(`
+		SELECT `+integrationDeliveryColumns+`
+		FROM
+			integration_deliveries
+		WHERE
+			user_id=$1 AND id=$2
+	`, userID, deliveryID)
+	if err != nil {
+		return nil, fmt.Errorf(`store: unable to fetch integration delivery #%d: %v`, deliveryID, err)
+	}
+	defer rows.Close()
+
+	deliveries, err := scanIntegrationDeliveries(rows)
+	if err != nil || len(deliveries) == 0 {
+		return nil, err
+	}
+
+	return deliveries[0], nil
+}
+
+// CleanOldIntegrationDeliveries removes the deliveries completed or given up more than the given number of days ago.
+func (s *Storage) CleanOldIntegrationDeliveries(days int) (int64, error) {
+	result, err := s.db.Exec(`
+		DELETE FROM
+			integration_deliveries
+		WHERE
+			status <> 'pending' AND created_at < now() - $1::interval
+	`, fmt.Sprintf("%d days", days))
+	if err != nil {
+		return 0, fmt.Errorf(`store: unable to remove old integration deliveries: %v`, err)
+	}
+
+	count, _ := result.RowsAffected()
+	return count, nil
+}
+
+func scanIntegrationDeliveries(rows *sql.Rows) (model.IntegrationDeliveries, error) {
+	deliveries := make(model.IntegrationDeliveries, 0)
+	for rows.Next() {
+		var delivery model.IntegrationDelivery
+		if err := rows.Scan(
+			&delivery.ID,
+			&delivery.UserID,
+			&delivery.Integration,
+			&delivery.EventType,
+			&delivery.FeedID,
+			pq.Array(&delivery.EntryIDs),
+			&delivery.Status,
+			&delivery.Attempts,
+			&delivery.LastError,
+			&delivery.CreatedAt,
+			&delivery.NextAttemptAt,
+			&delivery.DeliveredAt,
+		); err != nil {
+			return nil, fmt.Errorf(`store: unable to fetch integration delivery row: %v`, err)
+		}
+		deliveries = append(deliveries, &delivery)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf(`store: unable to fetch integration deliveries: %v`, err)
+	}
+
+	return deliveries, nil
+}
@@ -0,0 +1,144 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package storage // import "miniflux.app/v2/internal/storage"
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"miniflux.app/v2/internal/model"
+
+	"github.com/lib/pq"
+)
+
+// EnqueueJobs adds the given feed refreshes to the job queue.
+// Feeds already queued or being refreshed are skipped. It returns the number of queued jobs.
+func (s *Storage) EnqueueJobs(jobs model.JobList) (int64, error) {
+	if len(jobs) == 0 {
+		return 0, nil
+	}
+
+	feedIDs := make([]int64, 0, len(jobs))
+	for _, job := range jobs {
+		feedIDs = append(feedIDs, job.FeedID)
+	}
+
+	query := `
+		INSERT INTO jobs
+			(user_id, feed_id, host)
+		SELECT
+			f.user_id, f.id, lower(coalesce(substring(f.feed_url from '://([^/:?#]+)'), ''))
+		FROM
+			feeds f
+		WHERE
+			f.id=ANY($1)
+		ON CONFLICT (feed_id) WHERE state IN ('queued', 'running') DO NOTHING
+	`
+	result, err := s.db.Exec(query, pq.Array(feedIDs))
+	if err != nil {
+		return 0, fmt.Errorf(`store: unable to enqueue jobs: %v`, err)
+	}
+
+	count, _ := result.RowsAffected()
+	return count, nil
+}
+
+// ClaimJob marks the next due job as running and returns it, or nil when no job is due.
+// Jobs running for longer than the lease are considered abandoned and claimed again.
+// Hosts with hostLimit running jobs are skipped. Concurrent claims may briefly exceed the limit.
+func (s *Storage) ClaimJob(hostLimit int, lease time.Duration) (*model.Job, error) {
+	query := `
+		UPDATE
+			jobs
+		SET
+			state='running', attempts=attempts + 1, locked_at=now()
+		WHERE
+			id=(
+				SELECT
+					j.id
+				FROM
+					jobs j
+				WHERE
+					((j.state='queued' AND j.run_at <= now()) OR (j.state='running' AND j.locked_at < $2)) AND
+					(
+						$1 <= 0 OR
+						(SELECT count(*) FROM jobs r WHERE r.host=j.host AND r.state='running' AND r.locked_at >= $2) < $1
+					)
+				ORDER BY
+					j.run_at ASC, j.id ASC
+				LIMIT 1
+				FOR UPDATE SKIP LOCKED
+			)
+		RETURNING
+			id, user_id, feed_id, attempts
+	`
+
+	var job model.Job
+	err := s.db.QueryRow(query, hostLimit, time.Now().Add(-lease)).Scan(&job.ID, &job.UserID, &job.FeedID, &job.Attempts)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return nil, nil
+	case err != nil:
+		return nil, fmt.Errorf(`store: unable to claim job: %v`, err)
+	}
+
+	return &job, nil
+}
+
+// CompleteJob removes a successful job from the queue.
+func (s *Storage) CompleteJob(jobID int64) error {
+	if _, err := s.db.Exec(`DELETE FROM jobs WHERE id=$1`, jobID); err != nil {
+		return fmt.Errorf(`store: unable to complete job #%d: %v`, jobID, err)
+	}
+	return nil
+}
+
+// RetryJob queues a failed job again at the given date.
+func (s *Storage) RetryJob(jobID int64, runAt time.Time, lastError string) error {
+	query := `UPDATE jobs SET state='queued', run_at=$2, locked_at=NULL, last_error=$3 WHERE id=$1`
+	if _, err := s.db.Exec(query, jobID, runAt, lastError); err != nil {
+		return fmt.Errorf(`store: unable to retry job #%d: %v`, jobID, err)
+	}
+	return nil
+}
+
+// FailJob marks a job as failed after its last attempt. Only the last failed job of a feed is kept.
+func (s *Storage) FailJob(jobID int64, lastError string) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf(`store: unable to start transaction: %v`, err)
+	}
+
+	query := `UPDATE jobs SET state='failed', locked_at=NULL, last_error=$2 WHERE id=$1`
+	if _, err := tx.Exec(query, jobID, lastError); err != nil {
+		tx.Rollback()
+		return fmt.Errorf(`store: unable to fail job #%d: %v`, jobID, err)
+	}
+
+	query = `DELETE FROM jobs WHERE state='failed' AND id <> $1 AND feed_id=(SELECT feed_id FROM jobs WHERE id=$1)`
+	if _, err := tx.Exec(query, jobID); err != nil {
+		tx.Rollback()
+		return fmt.Errorf(`store: unable to remove previous failed jobs: %v`, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf(`store: unable to commit transaction: %v`, err)
+	}
+
+	return nil
+}
+
+// CleanOldFailedJobs removes the failed jobs queued more than the given number of days ago.
+func (s *Storage) CleanOldFailedJobs(days int) (int64, error) {
+	query := `DELETE FROM jobs WHERE state='failed' AND created_at < now() - $1::interval`
+	result, err := s.db.Exec(query, fmt.Sprintf("%d days", days))
+	if err != nil {
+		return 0, fmt.Errorf(`store: unable to remove old failed jobs: %v`, err)
+	}
+
+	count, _ := result.RowsAffected()
+	return count, nil
+}
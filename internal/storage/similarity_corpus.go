@@ -0,0 +1,110 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package storage // import "miniflux.app/v2/internal/storage"
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/lib/pq"
+)
+
+// AddSimilarityDocument adds the terms of an entry to the user's similarity corpus.
+// Entries already part of the corpus are ignored.
+func (s *Storage) AddSimilarityDocument(userID, entryID int64, terms []string) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf(`store: unable to start transaction: %v`, err)
+	}
+
+	query := `
+		INSERT INTO similarity_documents
+			(entry_id, user_id, terms)
+		VALUES
+			($1, $2, $3)
+		ON CONFLICT DO NOTHING
+	`
+	result, err := tx.Exec(query, entryID, userID, pq.Array(terms))
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf(`store: unable to add entry #%d to the similarity corpus: %v`, entryID, err)
+	}
+
+	if inserted, _ := result.RowsAffected(); inserted > 0 && len(terms) > 0 {
+		query = `
+			INSERT INTO similarity_terms
+				(user_id, term, document_count)
+			SELECT
+				$1, term, 1
+			FROM
+				unnest($2::text[]) AS term
+			ON CONFLICT (user_id, term) DO UPDATE SET document_count=similarity_terms.document_count + 1
+		`
+		if _, err := tx.Exec(query, userID, pq.Array(terms)); err != nil {
+			tx.Rollback()
+			return fmt.Errorf(`store: unable to update the similarity corpus terms: %v`, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf(`store: unable to commit transaction: %v`, err)
+	}
+
+	return nil
+}
+
+// SimilarityDocumentCount returns the number of documents in the user's similarity corpus.
+func (s *Storage) SimilarityDocumentCount(userID int64) (int, error) {
+	var count int
+	err := s.db.QueryRow(`SELECT count(*) FROM similarity_documents WHERE user_id=$1`, userID).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf(`store: unable to count similarity documents: %v`, err)
+	}
+	return count, nil
+}
+
+// SimilarityTermFrequencies returns the number of documents containing each of the given terms.
+// Unknown terms are not part of the result.
+func (s *Storage) SimilarityTermFrequencies(userID int64, terms []string) (map[string]int, error) {
+	query := `
+		SELECT
+			term, document_count
+		FROM
+			similarity_terms
+		WHERE
+			user_id=$1 AND term=ANY($2)
+	`
+	rows, err := s.db.Query(query, userID, pq.Array(terms))
+	if err != nil {
+		return nil, fmt.Errorf(`store: unable to fetch similarity term frequencies: %v`, err)
+	}
+	defer rows.Close()
+
+	frequencies := make(map[string]int, len(terms))
+	for rows.Next() {
+		var term string
+		var count int
+		if err := rows.Scan(&term, &count); err != nil {
+			return nil, fmt.Errorf(`store: unable to fetch similarity term frequency row: %v`, err)
+		}
+		frequencies[term] = count
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf(`store: unable to fetch similarity term frequencies: %v`, err)
+	}
+
+	return frequencies, nil
+}
+
+// removeSimilarityDocuments removes the given entries from the similarity corpus.
+// The term document counts are maintained by a trigger, which also covers
+// the documents removed when their entry is deleted.
+func removeSimilarityDocuments(tx *sql.Tx, entryIDs []int64) error {
+	if _, err := tx.Exec(`DELETE FROM similarity_documents WHERE entry_id=ANY($1)`, pq.Array(entryIDs)); err != nil {
+		return fmt.Errorf(`store: unable to remove entries from the similarity corpus: %v`, err)
+	}
+
+	return nil
+}
@@ -92,7 +92,14 @@ func (s *Storage) CreateUser(userCreationRequest *model.UserCreationRequest) (*m
 			default_home_page,
 			categories_sorting_order,
 			mark_read_on_view,
-			media_playback_rate
+			media_playback_rate,
+			collapse_similar_entries,
+			duplicate_entries_action,
+			duplicate_entries_threshold,
+			duplicate_entries_category_ids,
+			digest_frequency,
+			digest_entries,
+			digest_email
 	`
 
 	tx, err := s.db.Begin()
@@ -132,6 +139,13 @@ func (s *Storage) CreateUser(userCreationRequest *model.UserCreationRequest) (*m
 		&user.CategoriesSortingOrder,
 		&user.MarkReadOnView,
 		&user.MediaPlaybackRate,
+		&user.CollapseSimilarEntries,
+		&user.DuplicateEntriesAction,
+		&user.DuplicateEntriesThreshold,
+		pq.Array(&user.DuplicateEntriesCategoryIDs),
+		&user.DigestFrequency,
+		&user.DigestEntries,
+		&user.DigestEmail,
 	)
 	if err != nil {
 		tx.Rollback()
@@ -189,9 +203,16 @@ func (s *Storage) UpdateUser(user *model.User) error {
 				default_home_page=$20,
 				categories_sorting_order=$21,
 				mark_read_on_view=$22,
-				media_playback_rate=$23
+				media_playback_rate=$23,
+				collapse_similar_entries=$24,
+				duplicate_entries_action=$25,
+				duplicate_entries_threshold=$26,
+				duplicate_entries_category_ids=$27,
+				digest_frequency=$28,
+				digest_entries=$29,
+				digest_email=$30
 			WHERE
-				id=$24
+				id=$31
 		`
 
 		_, err = s.db.Exec(
@@ -219,6 +240,13 @@ func (s *Storage) UpdateUser(user *model.User) error {
 			user.CategoriesSortingOrder,
 			user.MarkReadOnView,
 			user.MediaPlaybackRate,
+			user.CollapseSimilarEntries,
+			user.DuplicateEntriesAction,
+			user.DuplicateEntriesThreshold,
+			pq.Array(user.DuplicateEntriesCategoryIDs),
+			user.DigestFrequency,
+			user.DigestEntries,
+			user.DigestEmail,
 			user.ID,
 		)
 		if err != nil {
@@ -248,9 +276,16 @@ func (s *Storage) UpdateUser(user *model.User) error {
 				default_home_page=$19,
 				categories_sorting_order=$20,
 				mark_read_on_view=$21,
-				media_playback_rate=$22
+				media_playback_rate=$22,
+				collapse_similar_entries=$23,
+				duplicate_entries_action=$24,
+				duplicate_entries_threshold=$25,
+				duplicate_entries_category_ids=$26,
+				digest_frequency=$27,
+				digest_entries=$28,
+				digest_email=$29
 			WHERE
-				id=$23
+				id=$30
 		`
 
 		_, err := s.db.Exec(
@@ -277,6 +312,13 @@ func (s *Storage) UpdateUser(user *model.User) error {
 			user.CategoriesSortingOrder,
 			user.MarkReadOnView,
 			user.MediaPlaybackRate,
+			user.CollapseSimilarEntries,
+			user.DuplicateEntriesAction,
+			user.DuplicateEntriesThreshold,
+			pq.Array(user.DuplicateEntriesCategoryIDs),
+			user.DigestFrequency,
+			user.DigestEntries,
+			user.DigestEmail,
 			user.ID,
 		)
 
@@ -325,7 +367,14 @@ func (s *Storage) UserByID(userID int64) (*model.User, error) {
 			default_home_page,
 			categories_sorting_order,
 			mark_read_on_view,
-			media_playback_rate
+			media_playback_rate,
+			collapse_similar_entries,
+			duplicate_entries_action,
+			duplicate_entries_threshold,
+			duplicate_entries_category_ids,
+			digest_frequency,
+			digest_entries,
+			digest_email
 		FROM
 			users
 		WHERE
@@ -361,7 +410,14 @@ func (s *Storage) UserByUsername(username string) (*model.User, error) {
 			default_home_page,
 			categories_sorting_order,
 			mark_read_on_view,
-			media_playback_rate
+			media_playback_rate,
+			collapse_similar_entries,
+			duplicate_entries_action,
+			duplicate_entries_threshold,
+			duplicate_entries_category_ids,
+			digest_frequency,
+			digest_entries,
+			digest_email
 		FROM
 			users
 		WHERE
@@ -397,7 +453,14 @@ func (s *Storage) UserByField(field, value string) (*model.User, error) {
 			default_home_page,
 			categories_sorting_order,
 			mark_read_on_view,
-			media_playback_rate
+			media_playback_rate,
+			collapse_similar_entries,
+			duplicate_entries_action,
+			duplicate_entries_threshold,
+			duplicate_entries_category_ids,
+			digest_frequency,
+			digest_entries,
+			digest_email
 		FROM
 			users
 		WHERE
@@ -440,7 +503,14 @@ func (s *Storage) UserByAPIKey(token string) (*model.User, error) {
 			u.default_home_page,
 			u.categories_sorting_order,
 			u.mark_read_on_view,
-			media_playback_rate
+			media_playback_rate,
+			collapse_similar_entries,
+			duplicate_entries_action,
+			duplicate_entries_threshold,
+			duplicate_entries_category_ids,
+			digest_frequency,
+			digest_entries,
+			digest_email
 		FROM
 			users u
 		LEFT JOIN
@@ -478,6 +548,13 @@ func (s *Storage) fetchUser(query string, args ...interface{}) (*model.User, err
 		&user.CategoriesSortingOrder,
 		&user.MarkReadOnView,
 		&user.MediaPlaybackRate,
+		&user.CollapseSimilarEntries,
+		&user.DuplicateEntriesAction,
+		&user.DuplicateEntriesThreshold,
+		pq.Array(&user.DuplicateEntriesCategoryIDs),
+		&user.DigestFrequency,
+		&user.DigestEntries,
+		&user.DigestEmail,
 	)
 
 	if err == sql.ErrNoRows {
@@ -586,7 +663,14 @@ func (s *Storage) Users() (model.Users, error) {
 			default_home_page,
 			categories_sorting_order,
 			mark_read_on_view,
-			media_playback_rate
+			media_playback_rate,
+			collapse_similar_entries,
+			duplicate_entries_action,
+			duplicate_entries_threshold,
+			duplicate_entries_category_ids,
+			digest_frequency,
+			digest_entries,
+			digest_email
 		FROM
 			users
 		ORDER BY username ASC
@@ -625,6 +709,13 @@ func (s *Storage) Users() (model.Users, error) {
 			&user.CategoriesSortingOrder,
 			&user.MarkReadOnView,
 			&user.MediaPlaybackRate,
+			&user.CollapseSimilarEntries,
+			&user.DuplicateEntriesAction,
+			&user.DuplicateEntriesThreshold,
+			pq.Array(&user.DuplicateEntriesCategoryIDs),
+			&user.DigestFrequency,
+			&user.DigestEntries,
+			&user.DigestEmail,
 		)
 
 		if err != nil {
@@ -0,0 +1,164 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package storage // import "miniflux.app/v2/internal/storage"
+
+import (
+	"fmt"
+
+	"github.com/lib/pq"
+
+	"miniflux.app/v2/internal/model"
+)
+
+// UserTags returns the tags added by a user to the entries, with the number of tagged entries.
+func (s *Storage) UserTags(userID int64) (model.UserTags, error) {
+	rows, err := s.db.Query(`
+		SELECT
+			tag, count(*)
+		FROM
+			entries, unnest(entries.user_tags) AS tag
+		WHERE
+			user_id=$1 AND status <> $2
+		GROUP BY
+			tag
+		ORDER BY
+			lower(tag) ASC
+	`, userID, model.EntryStatusRemoved)
+	if err != nil {
+		return nil, fmt.Errorf(`store: unable to fetch user tags: %v`, err)
+	}
+	defer rows.Close()
+
+	tags := make(model.UserTags, 0)
+	for rows.Next() {
+		var tag model.UserTag
+		if err := rows.Scan(&tag.Name, &tag.EntryCount); err != nil {
+			return nil, fmt.Errorf(`store: unable to fetch user tag row: %v`, err)
+		}
+		tags = append(tags, &tag)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf(`store: unable to fetch user tags: %v`, err)
+	}
+
+	return tags, nil
+}
+
+// UserTagExists checks if at least one entry of the user has the given tag.
+func (s *Storage) UserTagExists(userID int64, name string) bool {
+	var result bool
+	query := `SELECT true FROM entries WHERE user_id=$1 AND user_tags @> ARRAY[$2]::text[] LIMIT 1`
+	s.db.QueryRow(query, userID, name).Scan(&result)
+	return result
+}
+
+// SetEntryUserTags replaces the user tags of an entry.
+func (s *Storage) SetEntryUserTags(userID, entryID int64, tags []string) error {
+	tags = model.NormalizeUserTags(tags)
+	if err := model.ValidateUserTags(tags); err != nil {
+		return fmt.Errorf(`store: unable to update the user tags of entry #%d: %v`, entryID, err)
+	}
+
+	query := `UPDATE entries SET user_tags=$1, changed_at=now() WHERE user_id=$2 AND id=$3`
+	if _, err := s.db.Exec(query, pq.Array(tags), userID, entryID); err != nil {
+		return fmt.Errorf(`store: unable to update the user tags of entry #%d: %v`, entryID, err)
+	}
+
+	return nil
+}
+
+// AddEntriesUserTags adds user tags to several entries, the existing tags are kept in place.
+// The entries that would end up with too many tags are left unchanged.
+func (s *Storage) AddEntriesUserTags(userID int64, entryIDs []int64, tags []string) error {
+	tags = model.NormalizeUserTags(tags)
+	if err := model.ValidateUserTags(tags); err != nil {
+		return fmt.Errorf(`store: unable to add user tags to entries %v: %v`, entryIDs, err)
+	}
+
+	query := `
+		UPDATE
+			entries
+		SET
+			user_tags=ARRAY(
+				SELECT tag FROM unnest(user_tags || $1::text[]) WITH ORDINALITY AS t(tag, position)
+				GROUP BY tag ORDER BY min(position)
+			),
+			changed_at=now()
+		WHERE
+			user_id=$2 AND id=ANY($3) AND (
+				SELECT count(DISTINCT tag) FROM unnest(user_tags || $1::text[]) AS tag
+			) <= $4
+	`
+	if _, err := s.db.Exec(query, pq.Array(tags), userID, pq.Array(entryIDs), model.MaxEntryUserTags); err != nil {
+		return fmt.Errorf(`store: unable to add user tags to entries %v: %v`, entryIDs, err)
+	}
+
+	return nil
+}
+
+// RemoveEntriesUserTags removes user tags from several entries.
+func (s *Storage) RemoveEntriesUserTags(userID int64, entryIDs []int64, tags []string) error {
+	query := `
+		UPDATE
+			entries
+		SET
+			user_tags=ARRAY(
+				SELECT tag FROM unnest(user_tags) WITH ORDINALITY AS t(tag, position)
+				WHERE tag <> ALL($1::text[]) ORDER BY position
+			),
+			changed_at=now()
+		WHERE
+			user_id=$2 AND id=ANY($3) AND user_tags && $1::text[]
+	`
+	if _, err := s.db.Exec(query, pq.Array(tags), userID, pq.Array(entryIDs)); err != nil {
+		return fmt.Errorf(`store: unable to remove user tags from entries %v: %v`, entryIDs, err)
+	}
+
+	return nil
+}
+
+// RenameUserTag renames a user tag on all the entries.
+// When the new name is already used, both tags are merged.
+func (s *Storage) RenameUserTag(userID int64, name, newName string) error {
+	if err := model.ValidateUserTags([]string{newName}); err != nil {
+		return fmt.Errorf(`store: unable to rename the user tag %q: %v`, name, err)
+	}
+
+	query := `
+		UPDATE
+			entries
+		SET
+			user_tags=ARRAY(
+				SELECT tag FROM unnest(array_replace(user_tags, $1, $2)) WITH ORDINALITY AS t(tag, position)
+				GROUP BY tag ORDER BY min(position)
+			),
+			changed_at=now()
+		WHERE
+			user_id=$3 AND user_tags @> ARRAY[$1]::text[]
+	`
+	if _, err := s.db.Exec(query, name, newName, userID); err != nil {
+		return fmt.Errorf(`store: unable to rename the user tag %q: %v`, name, err)
+	}
+
+	return nil
+}
+
+// RemoveUserTag removes a user tag from all the entries.
+func (s *Storage) RemoveUserTag(userID int64, name string) error {
+	query := `
+		UPDATE
+			entries
+		SET
+			user_tags=array_remove(user_tags, $1),
+			changed_at=now()
+		WHERE
+			user_id=$2 AND user_tags @> ARRAY[$1]::text[]
+	`
+	if _, err := s.db.Exec(query, name, userID); err != nil {
+		return fmt.Errorf(`store: unable to remove the user tag %q: %v`, name, err)
+	}
+
+	return nil
+}
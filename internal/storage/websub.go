@@ -0,0 +1,189 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package storage // import "miniflux.app/v2/internal/storage"
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"miniflux.app/v2/internal/model"
+)
+
+const webSubSubscriptionColumns = `feed_id, user_id, hub_url, topic_url, secret, state, pending_mode, lease_expires_at, created_at`
+
+// CreateWebSubSubscription stores a pending WebSub subscription, replacing the previous subscription of the feed.
+// The subscription request is pending until the hub verifies it.
+func (s *Storage) CreateWebSubSubscription(subscription *model.WebSubSubscription) error {
+	query := `
+		INSERT INTO websub_subscriptions
+			(feed_id, user_id, hub_url, topic_url, secret, state, pending_mode)
+		VALUES
+			($1, $2, $3, $4, $5, 'pending', 'subscribe')
+		ON CONFLICT (feed_id) DO UPDATE SET
+			hub_url=EXCLUDED.hub_url,
+			topic_url=EXCLUDED.topic_url,
+			secret=EXCLUDED.secret,
+			state='pending',
+			pending_mode='subscribe',
+			lease_expires_at=NULL,
+			created_at=now()
+	`
+	_, err := s.db.Exec(
+		query,
+		subscription.FeedID,
+		subscription.UserID,
+		subscription.HubURL,
+		subscription.TopicURL,
+		subscription.Secret,
+	)
+	if err != nil {
+		return fmt.Errorf(`store: unable to create WebSub subscription of feed #%d: %v`, subscription.FeedID, err)
+	}
+	return nil
+}
+
+// WebSubSubscription returns the WebSub subscription of a feed, or nil when the feed is not subscribed.
+func (s *Storage) WebSubSubscription(feedID int64) (*model.WebSubSubscription, error) {
+	query := `SELECT ` + webSubSubscriptionColumns + ` FROM websub_subscriptions WHERE feed_id=$1`
+
+	subscription, err := scanWebSubSubscription(s.db.QueryRow(query, feedID))
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return nil, nil
+	case err != nil:
+		return nil, fmt.Errorf(`store: unable to fetch WebSub subscription of feed #%d: %v`, feedID, err)
+	}
+	return subscription, nil
+}
+
+// RenewWebSubSubscription marks a new subscription request as pending, the current lease is kept until the hub verifies it.
+func (s *Storage) RenewWebSubSubscription(feedID int64) error {
+	query := `UPDATE websub_subscriptions SET pending_mode='subscribe' WHERE feed_id=$1`
+	if _, err := s.db.Exec(query, feedID); err != nil {
+		return fmt.Errorf(`store: unable to renew WebSub subscription of feed #%d: %v`, feedID, err)
+	}
+	return nil
+}
+
+// ActivateWebSubSubscription marks a subscription as verified by the hub for the given lease.
+// It returns false when no subscription request was pending.
+func (s *Storage) ActivateWebSubSubscription(feedID int64, lease time.Duration) (bool, error) {
+	query := `
+		UPDATE websub_subscriptions
+		SET state='active', pending_mode='', lease_expires_at=$2
+		WHERE feed_id=$1 AND pending_mode='subscribe'
+	`
+	result, err := s.db.Exec(query, feedID, time.Now().Add(lease))
+	if err != nil {
+		return false, fmt.Errorf(`store: unable to activate WebSub subscription of feed #%d: %v`, feedID, err)
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf(`store: unable to activate WebSub subscription of feed #%d: %v`, feedID, err)
+	}
+	return count > 0, nil
+}
+
+// DenyWebSubSubscription marks a subscription as refused by the hub, the feed is polled again.
+// It returns false when no subscription request was pending.
+func (s *Storage) DenyWebSubSubscription(feedID int64) (bool, error) {
+	query := `
+		UPDATE websub_subscriptions
+		SET state='denied', pending_mode='', lease_expires_at=NULL
+		WHERE feed_id=$1 AND pending_mode='subscribe'
+	`
+	result, err := s.db.Exec(query, feedID)
+	if err != nil {
+		return false, fmt.Errorf(`store: unable to deny WebSub subscription of feed #%d: %v`, feedID, err)
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf(`store: unable to deny WebSub subscription of feed #%d: %v`, feedID, err)
+	}
+	return count > 0, nil
+}
+
+// UnsubscribeWebSubSubscription marks a subscription as being unsubscribed, until the hub verifies the intent.
+func (s *Storage) UnsubscribeWebSubSubscription(feedID int64) error {
+	query := `UPDATE websub_subscriptions SET state='unsubscribing', pending_mode='unsubscribe', lease_expires_at=NULL WHERE feed_id=$1`
+	if _, err := s.db.Exec(query, feedID); err != nil {
+		return fmt.Errorf(`store: unable to unsubscribe WebSub subscription of feed #%d: %v`, feedID, err)
+	}
+	return nil
+}
+
+// RemoveWebSubSubscription removes the WebSub subscription of a feed once the hub verified the unsubscription.
+// It returns false when no unsubscription request was pending.
+func (s *Storage) RemoveWebSubSubscription(feedID int64) (bool, error) {
+	result, err := s.db.Exec(`DELETE FROM websub_subscriptions WHERE feed_id=$1 AND pending_mode='unsubscribe'`, feedID)
+	if err != nil {
+		return false, fmt.Errorf(`store: unable to remove WebSub subscription of feed #%d: %v`, feedID, err)
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf(`store: unable to remove WebSub subscription of feed #%d: %v`, feedID, err)
+	}
+	return count > 0, nil
+}
+
+// WebSubSubscriptionsToRenew returns the active subscriptions whose lease expires before the given date,
+// and the subscriptions never verified by their hub since the given date.
+func (s *Storage) WebSubSubscriptionsToRenew(expiresBefore, pendingBefore time.Time) ([]*model.WebSubSubscription, error) {
+	query := `
+		SELECT ` + webSubSubscriptionColumns + `
+		FROM
+			websub_subscriptions
+		WHERE
+			(state='active' AND lease_expires_at < $1) OR
+			(state='pending' AND created_at < $2)
+	`
+	rows, err := s.db.Query(query, expiresBefore, pendingBefore)
+	if err != nil {
+		return nil, fmt.Errorf(`store: unable to fetch WebSub subscriptions to renew: %v`, err)
+	}
+	defer rows.Close()
+
+	subscriptions := make([]*model.WebSubSubscription, 0)
+	for rows.Next() {
+		subscription, err := scanWebSubSubscription(rows)
+		if err != nil {
+			return nil, fmt.Errorf(`store: unable to fetch WebSub subscription row: %v`, err)
+		}
+		subscriptions = append(subscriptions, subscription)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf(`store: unable to fetch WebSub subscriptions to renew: %v`, err)
+	}
+
+	return subscriptions, nil
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanWebSubSubscription(row rowScanner) (*model.WebSubSubscription, error) {
+	var subscription model.WebSubSubscription
+	err := row.Scan(
+		&subscription.FeedID,
+		&subscription.UserID,
+		&subscription.HubURL,
+		&subscription.TopicURL,
+		&subscription.Secret,
+		&subscription.State,
+		&subscription.PendingMode,
+		&subscription.LeaseExpiresAt,
+		&subscription.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &subscription, nil
+}
@@ -0,0 +1,51 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package template // import "miniflux.app/v2/internal/template"
+
+import (
+	"bytes"
+	"embed"
+	"html/template"
+	text_template "text/template"
+
+	"miniflux.app/v2/internal/locale"
+)
+
+//go:embed templates/email/*
+var emailTemplateFiles embed.FS
+
+// RenderEmail renders the HTML and plain text versions of an email, the data must contain the language of the recipient.
+func RenderEmail(name string, data map[string]interface{}) (htmlBody, textBody []byte, err error) {
+	printer := locale.NewPrinter(data["language"].(string))
+	funcs := map[string]interface{}{
+		"t": func(key string, args ...interface{}) string {
+			return printer.Printf(key, args...)
+		},
+		"plural": func(key string, n int, args ...interface{}) string {
+			return printer.Plural(key, n, args...)
+		},
+	}
+
+	htmlTemplate, err := template.New(name+".html").Funcs(funcs).ParseFS(emailTemplateFiles, "templates/email/"+name+".html")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var htmlBuffer bytes.Buffer
+	if err := htmlTemplate.Execute(&htmlBuffer, data); err != nil {
+		return nil, nil, err
+	}
+
+	textTemplate, err := text_template.New(name+".txt").Funcs(funcs).ParseFS(emailTemplateFiles, "templates/email/"+name+".txt")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var textBuffer bytes.Buffer
+	if err := textTemplate.Execute(&textBuffer, data); err != nil {
+		return nil, nil, err
+	}
+
+	return htmlBuffer.Bytes(), textBuffer.Bytes(), nil
+}
@@ -36,6 +36,8 @@ func (f *funcMap) Map() template.FuncMap {
 		"dict":           dict,
 		"hasKey":         hasKey,
 		"truncate":       truncate,
+		"percent":        percent,
+		"timestamp":      timestamp,
 		"isEmail":        isEmail,
 		"baseURL":        config.Opts.BaseURL,
 		"rootURL":        config.Opts.RootURL,
@@ -142,6 +144,20 @@ func truncate(str string, max int) string {
 	return str
 }
 
+func percent(ratio float64) int {
+	return int(math.Round(ratio * 100))
+}
+
+// timestamp formats a position in a media file, for example "1:02:03" or "4:05".
+func timestamp(seconds float64) string {
+	total := int(seconds)
+	hours, minutes, secs := total/3600, total%3600/60, total%60
+	if hours > 0 {
+		return fmt.Sprintf("%d:%02d:%02d", hours, minutes, secs)
+	}
+	return fmt.Sprintf("%d:%02d", minutes, secs)
+}
+
 func isEmail(str string) bool {
 	_, err := mail.ParseAddress(str)
 	return err == nil
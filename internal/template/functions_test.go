@@ -55,6 +55,26 @@ func TestHasKey(t *testing.T) {
 	}
 }
 
+func TestPercent(t *testing.T) {
+	scenarios := map[float64]int{0: 0, 0.424: 42, 0.875: 88, 1: 100}
+
+	for input, expected := range scenarios {
+		if result := percent(input); result != expected {
+			t.Fatalf(`Unexpected output for %v, got %d instead of %d`, input, result, expected)
+		}
+	}
+}
+
+func TestTimestamp(t *testing.T) {
+	scenarios := map[float64]string{0: "0:00", 65.9: "1:05", 3723: "1:02:03"}
+
+	for input, expected := range scenarios {
+		if result := timestamp(input); result != expected {
+			t.Fatalf(`Unexpected output for %v, got %q instead of %q`, input, result, expected)
+		}
+	}
+}
+
 func TestTruncateWithShortTexts(t *testing.T) {
 	scenarios := []string{"Short text", "Короткий текст"}
 
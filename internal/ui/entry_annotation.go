@@ -0,0 +1,126 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package ui // import "miniflux.app/v2/internal/ui"
+
+import (
+	json_parser "encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"miniflux.app/v2/internal/http/request"
+	"miniflux.app/v2/internal/http/response"
+	"miniflux.app/v2/internal/http/response/html"
+	"miniflux.app/v2/internal/http/response/json"
+	"miniflux.app/v2/internal/integration"
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/validator"
+)
+
+func (h *handler) createEntryAnnotation(w http.ResponseWriter, r *http.Request) {
+	var annotationRequest model.EntryAnnotationRequest
+	if err := json_parser.NewDecoder(r.Body).Decode(&annotationRequest); err != nil {
+		json.BadRequest(w, r, err)
+		return
+	}
+
+	if validationErr := validator.ValidateEntryAnnotationCreation(&annotationRequest); validationErr != nil {
+		json.BadRequest(w, r, validationErr.Error())
+		return
+	}
+
+	annotation, err := h.store.CreateEntryAnnotation(request.UserID(r), request.RouteInt64Param(r, "entryID"), &annotationRequest)
+	if err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	if annotation == nil {
+		json.NotFound(w, r)
+		return
+	}
+
+	json.Created(w, r, annotation)
+}
+
+func (h *handler) removeEntryAnnotation(w http.ResponseWriter, r *http.Request) {
+	removed, err := h.store.RemoveEntryAnnotation(request.UserID(r), request.RouteInt64Param(r, "entryID"), request.RouteInt64Param(r, "annotationID"))
+	if err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	if !removed {
+		json.NotFound(w, r)
+		return
+	}
+
+	json.NoContent(w, r)
+}
+
+func (h *handler) saveEntryAnnotations(w http.ResponseWriter, r *http.Request) {
+	userID := request.UserID(r)
+
+	builder := h.store.NewEntryQueryBuilder(userID)
+	builder.WithEntryID(request.RouteInt64Param(r, "entryID"))
+	builder.WithoutStatus(model.EntryStatusRemoved)
+
+	entry, err := builder.GetEntry()
+	if err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	if entry == nil {
+		json.NotFound(w, r)
+		return
+	}
+
+	if err := integration.ExportAnnotations(h.store, entry); err != nil {
+		if errors.Is(err, integration.ErrAnnotationsExportDisabled) || errors.Is(err, integration.ErrNoAnnotation) {
+			json.BadRequest(w, r, err)
+		} else {
+			json.ServerError(w, r, err)
+		}
+		return
+	}
+
+	json.Created(w, r, map[string]string{"message": "saved"})
+}
+
+func (h *handler) exportEntryAnnotations(w http.ResponseWriter, r *http.Request) {
+	userID := request.UserID(r)
+	entryID := request.RouteInt64Param(r, "entryID")
+
+	if !h.store.EntryIDExists(userID, entryID) {
+		html.NotFound(w, r)
+		return
+	}
+
+	annotations, err := h.store.EntryAnnotations(userID, entryID)
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	markdownAttachment(w, r, fmt.Sprintf("highlights-%d.md", entryID), annotations.Markdown())
+}
+
+func (h *handler) exportAnnotations(w http.ResponseWriter, r *http.Request) {
+	annotations, err := h.store.UserEntryAnnotations(request.UserID(r))
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	markdownAttachment(w, r, "highlights.md", annotations.Markdown())
+}
+
+func markdownAttachment(w http.ResponseWriter, r *http.Request, filename, body string) {
+	builder := response.New(w, r)
+	builder.WithHeader("Content-Type", "text/markdown; charset=utf-8")
+	builder.WithAttachment(filename)
+	builder.WithBody(body)
+	builder.Write()
+}
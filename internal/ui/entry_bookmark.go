@@ -66,9 +66,31 @@ func (h *handler) showStarredEntryPage(w http.ResponseWriter, r *http.Request) {
 		prevEntryRoute = route.Path(h.router, "starredEntry", "entryID", prevEntry.ID)
 	}
 
+	similarEntries, err := h.similarEntries(user.ID, entry.ID)
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	annotations, err := h.store.EntryAnnotations(user.ID, entry.ID)
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	revisionCount, err := h.store.CountEntryRevisions(user.ID, entry.ID)
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
 	sess := session.New(h.store, request.SessionID(r))
 	view := view.New(h.tpl, r, sess)
 	view.Set("entry", entry)
+	view.Set("similarEntries", similarEntries)
+	view.Set("annotations", annotations)
+	view.Set("hasAnnotationsExport", h.store.HasAnnotationsExport(user.ID))
+	view.Set("revisionCount", revisionCount)
 	view.Set("prevEntry", prevEntry)
 	view.Set("nextEntry", nextEntry)
 	view.Set("nextEntryRoute", nextEntryRoute)
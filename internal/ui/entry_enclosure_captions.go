@@ -0,0 +1,66 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package ui // import "miniflux.app/v2/internal/ui"
+
+import (
+	"net/http"
+	"time"
+
+	"miniflux.app/v2/internal/config"
+	"miniflux.app/v2/internal/crypto"
+	"miniflux.app/v2/internal/http/request"
+	"miniflux.app/v2/internal/http/response"
+	"miniflux.app/v2/internal/http/response/html"
+	"miniflux.app/v2/internal/reader/fetcher"
+	"miniflux.app/v2/internal/reader/podcast"
+)
+
+// showEnclosureCaptions serves the captions of an enclosure as WebVTT from the same origin, as required by the media players.
+func (h *handler) showEnclosureCaptions(w http.ResponseWriter, r *http.Request) {
+	enclosure, err := h.store.GetEnclosure(request.RouteInt64Param(r, "enclosureID"))
+	if err != nil || enclosure.UserID != request.UserID(r) {
+		html.NotFound(w, r)
+		return
+	}
+
+	index := request.RouteInt64Param(r, "index")
+	if index < 0 || index >= int64(len(enclosure.Transcripts)) || !enclosure.Transcripts[index].IsCaptions() {
+		html.NotFound(w, r)
+		return
+	}
+	transcript := enclosure.Transcripts[index]
+
+	requestBuilder := fetcher.NewRequestBuilder()
+	requestBuilder.WithUserAgent("", config.Opts.HTTPClientUserAgent())
+	requestBuilder.WithTimeout(config.Opts.HTTPClientTimeout())
+	requestBuilder.WithProxy(config.Opts.HTTPClientProxy())
+
+	responseHandler := fetcher.NewResponseHandler(requestBuilder.ExecuteRequest(transcript.URL))
+	defer responseHandler.Close()
+
+	if localizedError := responseHandler.LocalizedError(); localizedError != nil {
+		html.NotFound(w, r)
+		return
+	}
+
+	body, localizedError := responseHandler.ReadBody(config.Opts.HTTPClientMaxBodySize())
+	if localizedError != nil {
+		html.ServerError(w, r, localizedError.Error())
+		return
+	}
+
+	captions, err := podcast.ConvertCaptions(transcript.MimeType, body)
+	if err != nil {
+		html.NotFound(w, r)
+		return
+	}
+
+	etag := crypto.HashFromBytes([]byte(transcript.URL))
+	response.New(w, r).WithCaching(etag, 24*time.Hour, func(b *response.Builder) {
+		b.WithHeader("Content-Type", "text/vtt; charset=utf-8")
+		b.WithHeader("X-Content-Type-Options", "nosniff")
+		b.WithBody(captions)
+		b.Write()
+	})
+}
@@ -0,0 +1,122 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package ui // import "miniflux.app/v2/internal/ui"
+
+import (
+	"net/http"
+
+	"miniflux.app/v2/internal/http/request"
+	"miniflux.app/v2/internal/http/response/html"
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/ui/form"
+	"miniflux.app/v2/internal/ui/session"
+	"miniflux.app/v2/internal/ui/view"
+)
+
+// entryRuleOption is a choice of the entry rule form, the label is a translation key except for integrations.
+type entryRuleOption struct {
+	Value string
+	Label string
+}
+
+var (
+	entryRuleFieldOptions = []entryRuleOption{
+		{model.EntryRuleFieldFeed, "form.entry_rule.field.feed"},
+		{model.EntryRuleFieldCategory, "form.entry_rule.field.category"},
+		{model.EntryRuleFieldTitle, "form.entry_rule.field.title"},
+		{model.EntryRuleFieldAuthor, "form.entry_rule.field.author"},
+		{model.EntryRuleFieldContent, "form.entry_rule.field.content"},
+		{model.EntryRuleFieldTags, "form.entry_rule.field.tags"},
+		{model.EntryRuleFieldURL, "form.entry_rule.field.url"},
+		{model.EntryRuleFieldReadingTime, "form.entry_rule.field.reading_time"},
+	}
+
+	entryRuleOperatorOptions = []entryRuleOption{
+		{model.EntryRuleOperatorContains, "form.entry_rule.operator.contains"},
+		{model.EntryRuleOperatorNotContains, "form.entry_rule.operator.not_contains"},
+		{model.EntryRuleOperatorEquals, "form.entry_rule.operator.equals"},
+		{model.EntryRuleOperatorNotEquals, "form.entry_rule.operator.not_equals"},
+		{model.EntryRuleOperatorMatches, "form.entry_rule.operator.matches"},
+		{model.EntryRuleOperatorGreaterThan, "form.entry_rule.operator.greater_than"},
+		{model.EntryRuleOperatorLessThan, "form.entry_rule.operator.less_than"},
+	}
+
+	entryRuleActionOptions = []entryRuleOption{
+		{model.EntryRuleActionMarkAsRead, "form.entry_rule.action.mark_as_read"},
+		{model.EntryRuleActionStar, "form.entry_rule.action.star"},
+		{model.EntryRuleActionAddTag, "form.entry_rule.action.add_tag"},
+		{model.EntryRuleActionMoveToCategory, "form.entry_rule.action.move_to_category"},
+		{model.EntryRuleActionPushToIntegration, "form.entry_rule.action.push_to_integration"},
+		{model.EntryRuleActionNotify, "form.entry_rule.action.notify"},
+	}
+)
+
+func (h *handler) showCreateEntryRulePage(w http.ResponseWriter, r *http.Request) {
+	user, err := h.store.UserByID(request.UserID(r))
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	sess := session.New(h.store, request.SessionID(r))
+	view := view.New(h.tpl, r, sess)
+	if err := h.setEntryRuleFormOptions(view, user.ID); err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	view.Set("form", &form.EntryRuleForm{MatchAll: true})
+	view.Set("menu", "settings")
+	view.Set("user", user)
+	view.Set("countUnread", h.store.CountUnreadEntries(user.ID))
+	view.Set("countErrorFeeds", h.store.CountUserFeedsWithErrors(user.ID))
+
+	html.OK(w, r, view.Render("create_entry_rule"))
+}
+
+// setEntryRuleFormOptions adds the choices of the entry rule form to the view.
+func (h *handler) setEntryRuleFormOptions(view *view.View, userID int64) error {
+	categories, err := h.store.Categories(userID)
+	if err != nil {
+		return err
+	}
+
+	feeds, err := h.store.Feeds(userID)
+	if err != nil {
+		return err
+	}
+
+	integrations := make([]entryRuleOption, 0, len(model.SaveEntryIntegrations))
+	for _, integration := range model.SaveEntryIntegrations {
+		integrations = append(integrations, entryRuleOption{integration, model.IntegrationName(integration)})
+	}
+
+	view.Set("categories", categories)
+	view.Set("feeds", feeds)
+	view.Set("integrations", integrations)
+	view.Set("fieldOptions", entryRuleFieldOptions)
+	view.Set("operatorOptions", entryRuleOperatorOptions)
+	view.Set("actionOptions", entryRuleActionOptions)
+	return nil
+}
+
+// previewEntryRule adds the recent entries matching the rule to the view.
+func (h *handler) previewEntryRule(view *view.View, userID int64, ruleRequest *model.EntryRuleRequest) error {
+	rule := &model.EntryRule{UserID: userID}
+	ruleRequest.Patch(rule)
+
+	builder := h.store.NewEntryQueryBuilder(userID)
+	builder.WithoutStatus(model.EntryStatusRemoved)
+	builder.WithSorting("e.created_at", "DESC")
+	builder.WithLimit(100)
+
+	entries, err := builder.GetEntries()
+	if err != nil {
+		return err
+	}
+
+	view.Set("previewEntries", rule.Preview(entries))
+	view.Set("preview", true)
+	return nil
+}
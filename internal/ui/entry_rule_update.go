@@ -0,0 +1,75 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package ui // import "miniflux.app/v2/internal/ui"
+
+import (
+	"net/http"
+
+	"miniflux.app/v2/internal/http/request"
+	"miniflux.app/v2/internal/http/response/html"
+	"miniflux.app/v2/internal/http/route"
+	"miniflux.app/v2/internal/ui/form"
+	"miniflux.app/v2/internal/ui/session"
+	"miniflux.app/v2/internal/ui/view"
+	"miniflux.app/v2/internal/validator"
+)
+
+func (h *handler) updateEntryRule(w http.ResponseWriter, r *http.Request) {
+	loggedUser, err := h.store.UserByID(request.UserID(r))
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	rule, err := h.store.EntryRuleByID(loggedUser.ID, request.RouteInt64Param(r, "ruleID"))
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	if rule == nil {
+		html.NotFound(w, r)
+		return
+	}
+
+	ruleForm := form.NewEntryRuleForm(r)
+
+	sess := session.New(h.store, request.SessionID(r))
+	view := view.New(h.tpl, r, sess)
+	if err := h.setEntryRuleFormOptions(view, loggedUser.ID); err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	view.Set("form", ruleForm)
+	view.Set("rule", rule)
+	view.Set("menu", "settings")
+	view.Set("user", loggedUser)
+	view.Set("countUnread", h.store.CountUnreadEntries(loggedUser.ID))
+	view.Set("countErrorFeeds", h.store.CountUserFeedsWithErrors(loggedUser.ID))
+
+	ruleRequest := ruleForm.Request()
+	if validationErr := validator.ValidateEntryRule(h.store, loggedUser.ID, ruleRequest); validationErr != nil {
+		view.Set("errorMessage", validationErr.Translate(loggedUser.Language))
+		html.OK(w, r, view.Render("edit_entry_rule"))
+		return
+	}
+
+	if r.FormValue("preview") == "1" {
+		if err := h.previewEntryRule(view, loggedUser.ID, ruleRequest); err != nil {
+			html.ServerError(w, r, err)
+			return
+		}
+		html.OK(w, r, view.Render("edit_entry_rule"))
+		return
+	}
+
+	ruleRequest.Patch(rule)
+	if err := h.store.UpdateEntryRule(rule); err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	html.Redirect(w, r, route.Path(h.router, "entryRules"))
+}
@@ -4,6 +4,7 @@
 package ui // import "miniflux.app/v2/internal/ui"
 
 import (
+	"errors"
 	"net/http"
 
 	"miniflux.app/v2/internal/http/request"
@@ -11,6 +12,7 @@ import (
 	"miniflux.app/v2/internal/locale"
 	"miniflux.app/v2/internal/mediaproxy"
 	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/reader/fetcher"
 	"miniflux.app/v2/internal/reader/processor"
 	"miniflux.app/v2/internal/storage"
 )
@@ -54,6 +56,10 @@ func (h *handler) fetchContent(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err := processor.ProcessEntryWebPage(feed, entry, user); err != nil {
+		if errors.Is(err, fetcher.ErrDisallowedByRobotsTxt) {
+			json.BadRequest(w, r, errors.New(locale.NewPrinter(user.Language).Printf("error.robots_txt_disallowed")))
+			return
+		}
 		json.ServerError(w, r, err)
 		return
 	}
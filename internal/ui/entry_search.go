@@ -10,6 +10,7 @@ import (
 	"miniflux.app/v2/internal/http/response/html"
 	"miniflux.app/v2/internal/http/route"
 	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/similarity"
 	"miniflux.app/v2/internal/storage"
 	"miniflux.app/v2/internal/ui/session"
 	"miniflux.app/v2/internal/ui/view"
@@ -24,8 +25,15 @@ func (h *handler) showSearchEntryPage(w http.ResponseWriter, r *http.Request) {
 
 	entryID := request.RouteInt64Param(r, "entryID")
 	searchQuery := request.QueryStringParam(r, "q", "")
+	searchMode := ""
+	if request.QueryStringParam(r, "search_mode", "") == model.SearchModeSemantic {
+		searchMode = model.SearchModeSemantic
+	}
+
 	builder := h.store.NewEntryQueryBuilder(user.ID)
-	builder.WithSearchQuery(searchQuery)
+	if searchMode != model.SearchModeSemantic {
+		builder.WithSearchQuery(searchQuery)
+	}
 	builder.WithEntryID(entryID)
 	builder.WithoutStatus(model.EntryStatusRemoved)
 
@@ -51,7 +59,18 @@ func (h *handler) showSearchEntryPage(w http.ResponseWriter, r *http.Request) {
 	}
 
 	entryPaginationBuilder := storage.NewEntryPaginationBuilder(h.store, user.ID, entry.ID, user.EntryOrder, user.EntryDirection)
-	entryPaginationBuilder.WithSearchQuery(searchQuery)
+	if searchMode == model.SearchModeSemantic {
+		if searchQuery != "" {
+			terms, weights, err := similarity.SemanticQuery(h.store, user.ID, searchQuery)
+			if err != nil {
+				html.ServerError(w, r, err)
+				return
+			}
+			entryPaginationBuilder.WithSemanticQuery(terms, weights)
+		}
+	} else {
+		entryPaginationBuilder.WithSearchQuery(searchQuery)
+	}
 	prevEntry, nextEntry, err := entryPaginationBuilder.Entries()
 	if err != nil {
 		html.ServerError(w, r, err)
@@ -68,10 +87,33 @@ func (h *handler) showSearchEntryPage(w http.ResponseWriter, r *http.Request) {
 		prevEntryRoute = route.Path(h.router, "searchEntry", "entryID", prevEntry.ID)
 	}
 
+	similarEntries, err := h.similarEntries(user.ID, entry.ID)
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	annotations, err := h.store.EntryAnnotations(user.ID, entry.ID)
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	revisionCount, err := h.store.CountEntryRevisions(user.ID, entry.ID)
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
 	sess := session.New(h.store, request.SessionID(r))
 	view := view.New(h.tpl, r, sess)
 	view.Set("searchQuery", searchQuery)
+	view.Set("searchMode", searchMode)
 	view.Set("entry", entry)
+	view.Set("similarEntries", similarEntries)
+	view.Set("annotations", annotations)
+	view.Set("hasAnnotationsExport", h.store.HasAnnotationsExport(user.ID))
+	view.Set("revisionCount", revisionCount)
 	view.Set("prevEntry", prevEntry)
 	view.Set("nextEntry", nextEntry)
 	view.Set("nextEntryRoute", nextEntryRoute)
@@ -0,0 +1,52 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package ui // import "miniflux.app/v2/internal/ui"
+
+import (
+	"net/http"
+
+	"miniflux.app/v2/internal/http/request"
+	"miniflux.app/v2/internal/http/response/json"
+	"miniflux.app/v2/internal/model"
+)
+
+// similarEntriesLimit is the maximum number of entries shown in the related coverage panel.
+const similarEntriesLimit = 10
+
+// similarEntries returns the entries similar to the given entry, the most similar first.
+func (h *handler) similarEntries(userID, entryID int64) (model.Entries, error) {
+	builder := h.store.NewEntryQueryBuilder(userID)
+	builder.WithSimilarTo(entryID)
+	builder.WithoutStatus(model.EntryStatusRemoved)
+	builder.WithLimit(similarEntriesLimit)
+	return builder.GetEntries()
+}
+
+func (h *handler) markSimilarEntriesAsRead(w http.ResponseWriter, r *http.Request) {
+	userID := request.UserID(r)
+	entryID := request.RouteInt64Param(r, "entryID")
+
+	builder := h.store.NewEntryQueryBuilder(userID)
+	builder.WithEntryID(entryID)
+	builder.WithoutStatus(model.EntryStatusRemoved)
+
+	entry, err := builder.GetEntry()
+	if err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	if entry == nil {
+		json.NotFound(w, r)
+		return
+	}
+
+	count, err := h.store.MarkSimilarEntriesAsRead(userID, entryID)
+	if err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	json.OK(w, r, count)
+}
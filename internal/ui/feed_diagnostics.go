@@ -0,0 +1,121 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package ui // import "miniflux.app/v2/internal/ui"
+
+import (
+	"net/http"
+
+	"miniflux.app/v2/internal/http/request"
+	"miniflux.app/v2/internal/http/response/html"
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/ui/session"
+	"miniflux.app/v2/internal/ui/view"
+)
+
+const (
+	// feedDiagnosticsLimit is the number of refreshes shown on the diagnostics page.
+	feedDiagnosticsLimit = 100
+
+	diagnosticsChartWidth  = 600
+	diagnosticsChartHeight = 120
+)
+
+type diagnosticsChart struct {
+	Width  int
+	Height int
+	Max    int64
+	Bars   []*diagnosticsChartBar
+}
+
+type diagnosticsChartBar struct {
+	X          float64
+	Y          float64
+	Width      float64
+	Height     float64
+	Value      int64
+	Status     string
+	Diagnostic *model.FeedDiagnostic
+}
+
+// newDiagnosticsChart draws one bar per refresh, the oldest on the left.
+func newDiagnosticsChart(diagnostics model.FeedDiagnostics, value func(*model.FeedDiagnostic) int64) *diagnosticsChart {
+	chart := &diagnosticsChart{Width: diagnosticsChartWidth, Height: diagnosticsChartHeight}
+	if len(diagnostics) == 0 {
+		return chart
+	}
+
+	for _, diagnostic := range diagnostics {
+		chart.Max = max(chart.Max, value(diagnostic))
+	}
+
+	barWidth := float64(chart.Width) / float64(len(diagnostics))
+	for i := range diagnostics {
+		diagnostic := diagnostics[len(diagnostics)-1-i]
+		bar := &diagnosticsChartBar{
+			X:          float64(i) * barWidth,
+			Width:      max(barWidth-1, 1),
+			Value:      value(diagnostic),
+			Status:     "success",
+			Diagnostic: diagnostic,
+		}
+
+		switch {
+		case diagnostic.Failed():
+			bar.Status = "error"
+		case diagnostic.NotModified:
+			bar.Status = "not-modified"
+		}
+
+		// Failed and empty refreshes are drawn with a minimal height to remain visible.
+		bar.Height = 2
+		if chart.Max > 0 {
+			bar.Height = max(float64(bar.Value)/float64(chart.Max)*float64(chart.Height), 2)
+		}
+		bar.Y = float64(chart.Height) - bar.Height
+
+		chart.Bars = append(chart.Bars, bar)
+	}
+
+	return chart
+}
+
+func (h *handler) showFeedDiagnosticsPage(w http.ResponseWriter, r *http.Request) {
+	user, err := h.store.UserByID(request.UserID(r))
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	feedID := request.RouteInt64Param(r, "feedID")
+	feed, err := h.store.FeedByID(user.ID, feedID)
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	if feed == nil {
+		html.NotFound(w, r)
+		return
+	}
+
+	diagnostics, err := h.store.FeedDiagnostics(user.ID, feed.ID, feedDiagnosticsLimit)
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	sess := session.New(h.store, request.SessionID(r))
+	view := view.New(h.tpl, r, sess)
+	view.Set("feed", feed)
+	view.Set("diagnostics", diagnostics)
+	view.Set("summary", diagnostics.Summary())
+	view.Set("durationChart", newDiagnosticsChart(diagnostics, func(d *model.FeedDiagnostic) int64 { return d.Duration }))
+	view.Set("newEntriesChart", newDiagnosticsChart(diagnostics, func(d *model.FeedDiagnostic) int64 { return int64(d.NewEntries) }))
+	view.Set("menu", "feeds")
+	view.Set("user", user)
+	view.Set("countUnread", h.store.CountUnreadEntries(user.ID))
+	view.Set("countErrorFeeds", h.store.CountUserFeedsWithErrors(user.ID))
+
+	html.OK(w, r, view.Render("feed_diagnostics"))
+}
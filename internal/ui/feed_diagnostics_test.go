@@ -0,0 +1,47 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package ui // import "miniflux.app/v2/internal/ui"
+
+import (
+	"testing"
+
+	"miniflux.app/v2/internal/model"
+)
+
+func TestDiagnosticsChart(t *testing.T) {
+	diagnostics := model.FeedDiagnostics{
+		{ID: 3, Duration: 50, ErrorMsg: "timeout"},
+		{ID: 2, Duration: 100, NotModified: true},
+		{ID: 1, Duration: 0},
+	}
+
+	chart := newDiagnosticsChart(diagnostics, func(d *model.FeedDiagnostic) int64 { return d.Duration })
+
+	if chart.Max != 100 || len(chart.Bars) != 3 {
+		t.Fatalf(`Unexpected chart: max=%d bars=%d`, chart.Max, len(chart.Bars))
+	}
+
+	if chart.Bars[0].Diagnostic.ID != 1 || chart.Bars[2].Diagnostic.ID != 3 {
+		t.Errorf(`The oldest refresh should be drawn first`)
+	}
+
+	if chart.Bars[1].Height != float64(chart.Height) || chart.Bars[1].Y != 0 || chart.Bars[1].Status != "not-modified" {
+		t.Errorf(`Unexpected bar: %+v`, chart.Bars[1])
+	}
+
+	if chart.Bars[0].Height != 2 || chart.Bars[2].Status != "error" {
+		t.Errorf(`Unexpected bars: %+v %+v`, chart.Bars[0], chart.Bars[2])
+	}
+
+	if chart.Bars[2].X != float64(chart.Width)*2/3 {
+		t.Errorf(`Unexpected bar position, got %v`, chart.Bars[2].X)
+	}
+}
+
+func TestEmptyDiagnosticsChart(t *testing.T) {
+	chart := newDiagnosticsChart(model.FeedDiagnostics{}, func(d *model.FeedDiagnostic) int64 { return d.Duration })
+	if len(chart.Bars) != 0 {
+		t.Fatalf(`An empty chart should not have any bar`)
+	}
+}
@@ -64,6 +64,9 @@ func (h *handler) showEditFeedPage(w http.ResponseWriter, r *http.Request) {
 		CategoryHidden:              feed.Category.HideGlobally,
 		AppriseServiceURLs:          feed.AppriseServiceURLs,
 		DisableHTTP2:                feed.DisableHTTP2,
+		PageWatcherRules:            feed.PageWatcherRules,
+		JSONMapping:                 feed.JSONMapping,
+		MarkUnreadOnChange:          feed.MarkUnreadOnChange,
 	}
 
 	sess := session.New(h.store, request.SessionID(r))
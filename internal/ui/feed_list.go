@@ -29,6 +29,7 @@ func (h *handler) showFeedsPage(w http.ResponseWriter, r *http.Request) {
 	view := view.New(h.tpl, r, sess)
 	view.Set("feeds", feeds)
 	view.Set("total", len(feeds))
+	view.Set("countFeedSuggestions", h.store.CountFeedSuggestions(user.ID))
 	view.Set("menu", "feeds")
 	view.Set("user", user)
 	view.Set("countUnread", h.store.CountUnreadEntries(user.ID))
@@ -0,0 +1,81 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package ui // import "miniflux.app/v2/internal/ui"
+
+import (
+	"net/http"
+
+	"miniflux.app/v2/internal/http/request"
+	"miniflux.app/v2/internal/http/response/html"
+	"miniflux.app/v2/internal/http/route"
+	"miniflux.app/v2/internal/ui/session"
+	"miniflux.app/v2/internal/ui/view"
+)
+
+func (h *handler) showFeedSuggestionsPage(w http.ResponseWriter, r *http.Request) {
+	user, err := h.store.UserByID(request.UserID(r))
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	suggestions, err := h.store.FeedSuggestions(user.ID)
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	sess := session.New(h.store, request.SessionID(r))
+	view := view.New(h.tpl, r, sess)
+	view.Set("suggestions", suggestions)
+	view.Set("menu", "feeds")
+	view.Set("user", user)
+	view.Set("countUnread", h.store.CountUnreadEntries(user.ID))
+	view.Set("countErrorFeeds", h.store.CountUserFeedsWithErrors(user.ID))
+
+	html.OK(w, r, view.Render("feed_suggestions"))
+}
+
+func (h *handler) useNewFeedURL(w http.ResponseWriter, r *http.Request) {
+	userID := request.UserID(r)
+
+	feed, err := h.store.FeedByID(userID, request.RouteInt64Param(r, "feedID"))
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	if feed == nil {
+		html.NotFound(w, r)
+		return
+	}
+
+	// The new location is ignored when another subscription already uses it.
+	if feed.RedirectedURL != "" && !h.store.AnotherFeedURLExists(userID, feed.ID, feed.RedirectedURL) {
+		feed.FeedURL = feed.RedirectedURL
+		if err := h.store.UpdateFeed(feed); err != nil {
+			html.ServerError(w, r, err)
+			return
+		}
+	}
+
+	html.Redirect(w, r, route.Path(h.router, "feedSuggestions"))
+}
+
+func (h *handler) dismissFeedSuggestions(w http.ResponseWriter, r *http.Request) {
+	userID := request.UserID(r)
+	feedID := request.RouteInt64Param(r, "feedID")
+
+	if !h.store.FeedExists(userID, feedID) {
+		html.NotFound(w, r)
+		return
+	}
+
+	if err := h.store.DismissFeedSuggestions(userID, feedID); err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	html.Redirect(w, r, route.Path(h.router, "feedSuggestions"))
+}
@@ -56,14 +56,16 @@ func (h *handler) updateFeed(w http.ResponseWriter, r *http.Request) {
 	view.Set("defaultUserAgent", config.Opts.HTTPClientUserAgent())
 
 	feedModificationRequest := &model.FeedModificationRequest{
-		FeedURL:         model.OptionalString(feedForm.FeedURL),
-		SiteURL:         model.OptionalString(feedForm.SiteURL),
-		Title:           model.OptionalString(feedForm.Title),
-		Description:     model.OptionalString(feedForm.Description),
-		CategoryID:      model.OptionalNumber(feedForm.CategoryID),
-		BlocklistRules:  model.OptionalString(feedForm.BlocklistRules),
-		KeeplistRules:   model.OptionalString(feedForm.KeeplistRules),
-		UrlRewriteRules: model.OptionalString(feedForm.UrlRewriteRules),
+		FeedURL:          model.OptionalString(feedForm.FeedURL),
+		SiteURL:          model.OptionalString(feedForm.SiteURL),
+		Title:            model.OptionalString(feedForm.Title),
+		Description:      model.OptionalString(feedForm.Description),
+		CategoryID:       model.OptionalNumber(feedForm.CategoryID),
+		BlocklistRules:   model.OptionalString(feedForm.BlocklistRules),
+		KeeplistRules:    model.OptionalString(feedForm.KeeplistRules),
+		UrlRewriteRules:  model.OptionalString(feedForm.UrlRewriteRules),
+		PageWatcherRules: &feedForm.PageWatcherRules,
+		JSONMapping:      &feedForm.JSONMapping,
 	}
 
 	if validationErr := validator.ValidateFeedModification(h.store, loggedUser.ID, feed.ID, feedModificationRequest); validationErr != nil {
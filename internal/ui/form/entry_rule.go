@@ -0,0 +1,93 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package form // import "miniflux.app/v2/internal/ui/form"
+
+import (
+	"net/http"
+	"strings"
+
+	"miniflux.app/v2/internal/model"
+)
+
+// entryRuleBlankRows is the number of empty condition and action rows displayed in the form.
+const entryRuleBlankRows = 2
+
+// EntryRuleForm represents an entry rule form in the UI.
+type EntryRuleForm struct {
+	Title      string
+	Disabled   bool
+	MatchAll   bool
+	Conditions model.EntryRuleConditions
+	Actions    model.EntryRuleActions
+}
+
+// Request returns the API request built from the form fields.
+func (f *EntryRuleForm) Request() *model.EntryRuleRequest {
+	return &model.EntryRuleRequest{
+		Title:      f.Title,
+		Disabled:   f.Disabled,
+		MatchAll:   f.MatchAll,
+		Conditions: f.Conditions,
+		Actions:    f.Actions,
+	}
+}
+
+// ConditionRows returns the conditions followed by empty rows to add new ones.
+func (f *EntryRuleForm) ConditionRows() model.EntryRuleConditions {
+	return append(f.Conditions[:len(f.Conditions):len(f.Conditions)], make(model.EntryRuleConditions, entryRuleBlankRows)...)
+}
+
+// ActionRows returns the actions followed by empty rows to add new ones.
+func (f *EntryRuleForm) ActionRows() model.EntryRuleActions {
+	return append(f.Actions[:len(f.Actions):len(f.Actions)], make(model.EntryRuleActions, entryRuleBlankRows)...)
+}
+
+// NewEntryRuleForm returns a new EntryRuleForm.
+// Rows without a field or an action type are ignored.
+func NewEntryRuleForm(r *http.Request) *EntryRuleForm {
+	r.ParseForm()
+
+	ruleForm := &EntryRuleForm{
+		Title:    strings.TrimSpace(r.FormValue("title")),
+		Disabled: r.FormValue("disabled") == "1",
+		MatchAll: r.FormValue("match_all") != "0",
+	}
+
+	operators := r.Form["condition_operator"]
+	values := r.Form["condition_value"]
+	for i, field := range r.Form["condition_field"] {
+		if field == "" || i >= len(operators) || i >= len(values) {
+			continue
+		}
+		ruleForm.Conditions = append(ruleForm.Conditions, model.EntryRuleCondition{
+			Field:    field,
+			Operator: operators[i],
+			Value:    strings.TrimSpace(values[i]),
+		})
+	}
+
+	actionValues := r.Form["action_value"]
+	for i, actionType := range r.Form["action_type"] {
+		if actionType == "" || i >= len(actionValues) {
+			continue
+		}
+		ruleForm.Actions = append(ruleForm.Actions, model.EntryRuleAction{
+			Type:  actionType,
+			Value: strings.TrimSpace(actionValues[i]),
+		})
+	}
+
+	return ruleForm
+}
+
+// NewEntryRuleFormFromRule returns a form filled with the rule fields.
+func NewEntryRuleFormFromRule(rule *model.EntryRule) *EntryRuleForm {
+	return &EntryRuleForm{
+		Title:      rule.Title,
+		Disabled:   rule.Disabled,
+		MatchAll:   rule.MatchAll,
+		Conditions: rule.Conditions,
+		Actions:    rule.Actions,
+	}
+}
@@ -6,6 +6,7 @@ package form // import "miniflux.app/v2/internal/ui/form"
 import (
 	"net/http"
 	"strconv"
+	"strings"
 
 	"miniflux.app/v2/internal/model"
 )
@@ -36,6 +37,9 @@ type FeedForm struct {
 	CategoryHidden              bool // Category has "hide_globally"
 	AppriseServiceURLs          string
 	DisableHTTP2                bool
+	PageWatcherRules            model.PageWatcherRules
+	JSONMapping                 model.JSONMapping
+	MarkUnreadOnChange          bool
 }
 
 // Merge updates the fields of the given feed.
@@ -65,6 +69,9 @@ func (f FeedForm) Merge(feed *model.Feed) *model.Feed {
 	feed.HideGlobally = f.HideGlobally
 	feed.AppriseServiceURLs = f.AppriseServiceURLs
 	feed.DisableHTTP2 = f.DisableHTTP2
+	feed.PageWatcherRules = f.PageWatcherRules
+	feed.JSONMapping = f.JSONMapping
+	feed.MarkUnreadOnChange = f.MarkUnreadOnChange
 	return feed
 }
 
@@ -98,5 +105,29 @@ func NewFeedForm(r *http.Request) *FeedForm {
 		HideGlobally:                r.FormValue("hide_globally") == "1",
 		AppriseServiceURLs:          r.FormValue("apprise_service_urls"),
 		DisableHTTP2:                r.FormValue("disable_http2") == "1",
+		PageWatcherRules:            newPageWatcherRules(r),
+		JSONMapping:                 newJSONMapping(r),
+		MarkUnreadOnChange:          r.FormValue("mark_unread_on_change") == "1",
+	}
+}
+
+func newPageWatcherRules(r *http.Request) model.PageWatcherRules {
+	return model.PageWatcherRules{
+		ItemSelector:    strings.TrimSpace(r.FormValue("page_item_selector")),
+		TitleSelector:   strings.TrimSpace(r.FormValue("page_title_selector")),
+		LinkSelector:    strings.TrimSpace(r.FormValue("page_link_selector")),
+		DateSelector:    strings.TrimSpace(r.FormValue("page_date_selector")),
+		ContentSelector: strings.TrimSpace(r.FormValue("page_content_selector")),
+	}
+}
+
+func newJSONMapping(r *http.Request) model.JSONMapping {
+	return model.JSONMapping{
+		ItemsPath:   strings.TrimSpace(r.FormValue("json_items_path")),
+		IDPath:      strings.TrimSpace(r.FormValue("json_id_path")),
+		TitlePath:   strings.TrimSpace(r.FormValue("json_title_path")),
+		URLPath:     strings.TrimSpace(r.FormValue("json_url_path")),
+		DatePath:    strings.TrimSpace(r.FormValue("json_date_path")),
+		ContentPath: strings.TrimSpace(r.FormValue("json_content_path")),
 	}
 }
@@ -6,34 +6,43 @@ package form // import "miniflux.app/v2/internal/ui/form"
 import (
 	"net/http"
 	"strconv"
+	"strings"
 
+	"miniflux.app/v2/internal/config"
 	"miniflux.app/v2/internal/locale"
 	"miniflux.app/v2/internal/model"
 )
 
 // SettingsForm represents the settings form.
 type SettingsForm struct {
-	Username               string
-	Password               string
-	Confirmation           string
-	Theme                  string
-	Language               string
-	Timezone               string
-	EntryDirection         string
-	EntryOrder             string
-	EntriesPerPage         int
-	KeyboardShortcuts      bool
-	ShowReadingTime        bool
-	CustomCSS              string
-	EntrySwipe             bool
-	GestureNav             string
-	DisplayMode            string
-	DefaultReadingSpeed    int
-	CJKReadingSpeed        int
-	DefaultHomePage        string
-	CategoriesSortingOrder string
-	MarkReadOnView         bool
-	MediaPlaybackRate      float64
+	Username                    string
+	Password                    string
+	Confirmation                string
+	Theme                       string
+	Language                    string
+	Timezone                    string
+	EntryDirection              string
+	EntryOrder                  string
+	EntriesPerPage              int
+	KeyboardShortcuts           bool
+	ShowReadingTime             bool
+	CustomCSS                   string
+	EntrySwipe                  bool
+	GestureNav                  string
+	DisplayMode                 string
+	DefaultReadingSpeed         int
+	CJKReadingSpeed             int
+	DefaultHomePage             string
+	CategoriesSortingOrder      string
+	MarkReadOnView              bool
+	MediaPlaybackRate           float64
+	CollapseSimilarEntries      bool
+	DuplicateEntriesAction      string
+	DuplicateEntriesThreshold   float64
+	DuplicateEntriesCategoryIDs []int64
+	DigestFrequency             string
+	DigestEntries               string
+	DigestEmail                 string
 }
 
 // Merge updates the fields of the given user.
@@ -57,6 +66,13 @@ func (s *SettingsForm) Merge(user *model.User) *model.User {
 	user.CategoriesSortingOrder = s.CategoriesSortingOrder
 	user.MarkReadOnView = s.MarkReadOnView
 	user.MediaPlaybackRate = s.MediaPlaybackRate
+	user.CollapseSimilarEntries = s.CollapseSimilarEntries
+	user.DuplicateEntriesAction = s.DuplicateEntriesAction
+	user.DuplicateEntriesThreshold = s.DuplicateEntriesThreshold
+	user.DuplicateEntriesCategoryIDs = s.DuplicateEntriesCategoryIDs
+	user.DigestFrequency = s.DigestFrequency
+	user.DigestEntries = s.DigestEntries
+	user.DigestEmail = s.DigestEmail
 
 	if s.Password != "" {
 		user.Password = s.Password
@@ -90,9 +106,31 @@ func (s *SettingsForm) Validate() *locale.LocalizedError {
 		return locale.NewLocalizedError("error.settings_media_playback_rate_range")
 	}
 
+	if s.DuplicateEntriesThreshold <= 0 || s.DuplicateEntriesThreshold > 1 {
+		return locale.NewLocalizedError("error.settings_duplicate_entries_threshold_range")
+	}
+
+	if minThreshold := config.Opts.SimilarityThreshold(); s.DuplicateEntriesThreshold < minThreshold {
+		return locale.NewLocalizedError("error.settings_duplicate_entries_threshold_min", minThreshold)
+	}
+
+	if model.DigestPeriod(s.DigestFrequency) > 0 && s.DigestEmail == "" {
+		return locale.NewLocalizedError("error.settings_digest_email_required")
+	}
+
 	return nil
 }
 
+// HasDuplicateEntriesCategory returns true if duplicate entries are handled in the given category.
+func (s *SettingsForm) HasDuplicateEntriesCategory(categoryID int64) bool {
+	for _, id := range s.DuplicateEntriesCategoryIDs {
+		if id == categoryID {
+			return true
+		}
+	}
+	return false
+}
+
 // NewSettingsForm returns a new SettingsForm.
 func NewSettingsForm(r *http.Request) *SettingsForm {
 	entriesPerPage, err := strconv.ParseInt(r.FormValue("entries_per_page"), 10, 0)
@@ -111,27 +149,44 @@ func NewSettingsForm(r *http.Request) *SettingsForm {
 	if err != nil {
 		mediaPlaybackRate = 1
 	}
+	duplicateEntriesThreshold, err := strconv.ParseFloat(r.FormValue("duplicate_entries_threshold"), 64)
+	if err != nil {
+		duplicateEntriesThreshold = 0
+	}
+	duplicateEntriesCategoryIDs := make([]int64, 0, len(r.Form["duplicate_entries_category_ids"]))
+	for _, value := range r.Form["duplicate_entries_category_ids"] {
+		if categoryID, err := strconv.ParseInt(value, 10, 64); err == nil {
+			duplicateEntriesCategoryIDs = append(duplicateEntriesCategoryIDs, categoryID)
+		}
+	}
 	return &SettingsForm{
-		Username:               r.FormValue("username"),
-		Password:               r.FormValue("password"),
-		Confirmation:           r.FormValue("confirmation"),
-		Theme:                  r.FormValue("theme"),
-		Language:               r.FormValue("language"),
-		Timezone:               r.FormValue("timezone"),
-		EntryDirection:         r.FormValue("entry_direction"),
-		EntryOrder:             r.FormValue("entry_order"),
-		EntriesPerPage:         int(entriesPerPage),
-		KeyboardShortcuts:      r.FormValue("keyboard_shortcuts") == "1",
-		ShowReadingTime:        r.FormValue("show_reading_time") == "1",
-		CustomCSS:              r.FormValue("custom_css"),
-		EntrySwipe:             r.FormValue("entry_swipe") == "1",
-		GestureNav:             r.FormValue("gesture_nav"),
-		DisplayMode:            r.FormValue("display_mode"),
-		DefaultReadingSpeed:    int(defaultReadingSpeed),
-		CJKReadingSpeed:        int(cjkReadingSpeed),
-		DefaultHomePage:        r.FormValue("default_home_page"),
-		CategoriesSortingOrder: r.FormValue("categories_sorting_order"),
-		MarkReadOnView:         r.FormValue("mark_read_on_view") == "1",
-		MediaPlaybackRate:      mediaPlaybackRate,
+		Username:                    r.FormValue("username"),
+		Password:                    r.FormValue("password"),
+		Confirmation:                r.FormValue("confirmation"),
+		Theme:                       r.FormValue("theme"),
+		Language:                    r.FormValue("language"),
+		Timezone:                    r.FormValue("timezone"),
+		EntryDirection:              r.FormValue("entry_direction"),
+		EntryOrder:                  r.FormValue("entry_order"),
+		EntriesPerPage:              int(entriesPerPage),
+		KeyboardShortcuts:           r.FormValue("keyboard_shortcuts") == "1",
+		ShowReadingTime:             r.FormValue("show_reading_time") == "1",
+		CustomCSS:                   r.FormValue("custom_css"),
+		EntrySwipe:                  r.FormValue("entry_swipe") == "1",
+		GestureNav:                  r.FormValue("gesture_nav"),
+		DisplayMode:                 r.FormValue("display_mode"),
+		DefaultReadingSpeed:         int(defaultReadingSpeed),
+		CJKReadingSpeed:             int(cjkReadingSpeed),
+		DefaultHomePage:             r.FormValue("default_home_page"),
+		CategoriesSortingOrder:      r.FormValue("categories_sorting_order"),
+		MarkReadOnView:              r.FormValue("mark_read_on_view") == "1",
+		MediaPlaybackRate:           mediaPlaybackRate,
+		CollapseSimilarEntries:      r.FormValue("collapse_similar_entries") == "1",
+		DuplicateEntriesAction:      r.FormValue("duplicate_entries_action"),
+		DuplicateEntriesThreshold:   duplicateEntriesThreshold,
+		DuplicateEntriesCategoryIDs: duplicateEntriesCategoryIDs,
+		DigestFrequency:             r.FormValue("digest_frequency"),
+		DigestEntries:               r.FormValue("digest_entries"),
+		DigestEmail:                 strings.TrimSpace(r.FormValue("digest_email")),
 	}
 }
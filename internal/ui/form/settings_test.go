@@ -4,25 +4,35 @@
 package form // import "miniflux.app/v2/internal/ui/form"
 
 import (
+	"os"
 	"testing"
+
+	"miniflux.app/v2/internal/config"
 )
 
+func TestMain(m *testing.M) {
+	config.Opts = config.NewOptions()
+	exitCode := m.Run()
+	os.Exit(exitCode)
+}
+
 func TestValid(t *testing.T) {
 	settings := &SettingsForm{
-		Username:            "user",
-		Password:            "hunter2",
-		Confirmation:        "hunter2",
-		Theme:               "default",
-		Language:            "en_US",
-		Timezone:            "UTC",
-		EntryDirection:      "asc",
-		EntriesPerPage:      50,
-		DisplayMode:         "standalone",
-		GestureNav:          "tap",
-		DefaultReadingSpeed: 35,
-		CJKReadingSpeed:     25,
-		DefaultHomePage:     "unread",
-		MediaPlaybackRate:   1.25,
+		Username:                  "user",
+		Password:                  "hunter2",
+		Confirmation:              "hunter2",
+		Theme:                     "default",
+		Language:                  "en_US",
+		Timezone:                  "UTC",
+		EntryDirection:            "asc",
+		EntriesPerPage:            50,
+		DisplayMode:               "standalone",
+		GestureNav:                "tap",
+		DefaultReadingSpeed:       35,
+		CJKReadingSpeed:           25,
+		DefaultHomePage:           "unread",
+		MediaPlaybackRate:         1.25,
+		DuplicateEntriesThreshold: 0.8,
 	}
 
 	err := settings.Validate()
@@ -33,20 +43,21 @@ func TestValid(t *testing.T) {
 
 func TestConfirmationEmpty(t *testing.T) {
 	settings := &SettingsForm{
-		Username:            "user",
-		Password:            "hunter2",
-		Confirmation:        "",
-		Theme:               "default",
-		Language:            "en_US",
-		Timezone:            "UTC",
-		EntryDirection:      "asc",
-		EntriesPerPage:      50,
-		DisplayMode:         "standalone",
-		GestureNav:          "tap",
-		DefaultReadingSpeed: 35,
-		CJKReadingSpeed:     25,
-		DefaultHomePage:     "unread",
-		MediaPlaybackRate:   1.25,
+		Username:                  "user",
+		Password:                  "hunter2",
+		Confirmation:              "",
+		Theme:                     "default",
+		Language:                  "en_US",
+		Timezone:                  "UTC",
+		EntryDirection:            "asc",
+		EntriesPerPage:            50,
+		DisplayMode:               "standalone",
+		GestureNav:                "tap",
+		DefaultReadingSpeed:       35,
+		CJKReadingSpeed:           25,
+		DefaultHomePage:           "unread",
+		MediaPlaybackRate:         1.25,
+		DuplicateEntriesThreshold: 0.8,
 	}
 
 	err := settings.Validate()
@@ -61,20 +72,21 @@ func TestConfirmationEmpty(t *testing.T) {
 
 func TestConfirmationIncorrect(t *testing.T) {
 	settings := &SettingsForm{
-		Username:            "user",
-		Password:            "hunter2",
-		Confirmation:        "unter2",
-		Theme:               "default",
-		Language:            "en_US",
-		Timezone:            "UTC",
-		EntryDirection:      "asc",
-		EntriesPerPage:      50,
-		DisplayMode:         "standalone",
-		GestureNav:          "tap",
-		DefaultReadingSpeed: 35,
-		CJKReadingSpeed:     25,
-		DefaultHomePage:     "unread",
-		MediaPlaybackRate:   1.25,
+		Username:                  "user",
+		Password:                  "hunter2",
+		Confirmation:              "unter2",
+		Theme:                     "default",
+		Language:                  "en_US",
+		Timezone:                  "UTC",
+		EntryDirection:            "asc",
+		EntriesPerPage:            50,
+		DisplayMode:               "standalone",
+		GestureNav:                "tap",
+		DefaultReadingSpeed:       35,
+		CJKReadingSpeed:           25,
+		DefaultHomePage:           "unread",
+		MediaPlaybackRate:         1.25,
+		DuplicateEntriesThreshold: 0.8,
 	}
 
 	err := settings.Validate()
@@ -82,3 +94,83 @@ func TestConfirmationIncorrect(t *testing.T) {
 		t.Error("Validate should return an error")
 	}
 }
+
+func TestDuplicateEntriesThresholdOutOfRange(t *testing.T) {
+	for _, threshold := range []float64{0, -0.5, 1.5} {
+		settings := &SettingsForm{
+			Username:                  "user",
+			Theme:                     "default",
+			Language:                  "en_US",
+			Timezone:                  "UTC",
+			EntryDirection:            "asc",
+			EntriesPerPage:            50,
+			DisplayMode:               "standalone",
+			GestureNav:                "tap",
+			DefaultReadingSpeed:       35,
+			CJKReadingSpeed:           25,
+			DefaultHomePage:           "unread",
+			MediaPlaybackRate:         1.25,
+			DuplicateEntriesThreshold: threshold,
+		}
+
+		if err := settings.Validate(); err == nil {
+			t.Errorf("Validate should return an error for the threshold %f", threshold)
+		}
+	}
+}
+
+func TestDuplicateEntriesThresholdBelowSimilarityThreshold(t *testing.T) {
+	settings := &SettingsForm{
+		Username:                  "user",
+		Theme:                     "default",
+		Language:                  "en_US",
+		Timezone:                  "UTC",
+		EntryDirection:            "asc",
+		EntriesPerPage:            50,
+		DisplayMode:               "standalone",
+		GestureNav:                "tap",
+		DefaultReadingSpeed:       35,
+		CJKReadingSpeed:           25,
+		DefaultHomePage:           "unread",
+		MediaPlaybackRate:         1.25,
+		DuplicateEntriesThreshold: config.Opts.SimilarityThreshold() - 0.01,
+	}
+
+	if err := settings.Validate(); err == nil {
+		t.Error("Validate should return an error when the threshold is below the similarity threshold of the server")
+	}
+
+	settings.DuplicateEntriesThreshold = config.Opts.SimilarityThreshold()
+	if err := settings.Validate(); err != nil {
+		t.Errorf("Validate should not return an error: %v", err)
+	}
+}
+
+func TestDigestWithoutEmail(t *testing.T) {
+	settings := &SettingsForm{
+		Username:                  "user",
+		Theme:                     "default",
+		Language:                  "en_US",
+		Timezone:                  "UTC",
+		EntryDirection:            "asc",
+		EntriesPerPage:            50,
+		DisplayMode:               "standalone",
+		GestureNav:                "tap",
+		DefaultReadingSpeed:       35,
+		CJKReadingSpeed:           25,
+		DefaultHomePage:           "unread",
+		MediaPlaybackRate:         1.25,
+		DuplicateEntriesThreshold: 0.8,
+		DigestFrequency:           "daily",
+		DigestEntries:             "unread",
+	}
+
+	if err := settings.Validate(); err == nil {
+		t.Error("Validate should return an error when the digest email is missing")
+	}
+
+	settings.DigestEmail = "user@example.org"
+	if err := settings.Validate(); err != nil {
+		t.Errorf("Validate should not return an error: %v", err)
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"strconv"
 
 	"miniflux.app/v2/internal/locale"
+	"miniflux.app/v2/internal/model"
 	"miniflux.app/v2/internal/validator"
 )
 
@@ -28,6 +29,8 @@ type SubscriptionForm struct {
 	KeeplistRules               string
 	UrlRewriteRules             string
 	DisableHTTP2                bool
+	PageWatcherRules            model.PageWatcherRules
+	JSONMapping                 model.JSONMapping
 }
 
 // Validate makes sure the form values locale.are valid.
@@ -52,6 +55,18 @@ func (s *SubscriptionForm) Validate() *locale.LocalizedError {
 		return locale.NewLocalizedError("error.feed_invalid_urlrewrite_rule")
 	}
 
+	if err := validator.ValidatePageWatcherRules(&s.PageWatcherRules); err != nil {
+		return err
+	}
+
+	if err := validator.ValidateJSONMapping(&s.JSONMapping); err != nil {
+		return err
+	}
+
+	if err := validator.ValidateFeedSource(&s.PageWatcherRules, &s.JSONMapping); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -78,5 +93,7 @@ func NewSubscriptionForm(r *http.Request) *SubscriptionForm {
 		KeeplistRules:               r.FormValue("keeplist_rules"),
 		UrlRewriteRules:             r.FormValue("urlrewrite_rules"),
 		DisableHTTP2:                r.FormValue("disable_http2") == "1",
+		PageWatcherRules:            newPageWatcherRules(r),
+		JSONMapping:                 newJSONMapping(r),
 	}
 }
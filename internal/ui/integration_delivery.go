@@ -0,0 +1,36 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package ui // import "miniflux.app/v2/internal/ui"
+
+import (
+	"net/http"
+
+	"miniflux.app/v2/internal/http/request"
+	"miniflux.app/v2/internal/http/response/html"
+	"miniflux.app/v2/internal/http/route"
+)
+
+func (h *handler) resendIntegrationDelivery(w http.ResponseWriter, r *http.Request) {
+	delivery, err := h.store.IntegrationDeliveryByID(request.UserID(r), request.RouteInt64Param(r, "deliveryID"))
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	if delivery == nil {
+		html.NotFound(w, r)
+		return
+	}
+
+	// The delivery is sent again by the retry scheduler, the integration may be slow to answer.
+	if delivery.CanBeResent() {
+		delivery.Reset()
+		if err := h.store.UpdateIntegrationDelivery(delivery); err != nil {
+			html.ServerError(w, r, err)
+			return
+		}
+	}
+
+	html.Redirect(w, r, route.Path(h.router, "integrations"))
+}
@@ -116,9 +116,16 @@ func (h *handler) showIntegrationPage(w http.ResponseWriter, r *http.Request) {
 		RaindropTags:                     integration.RaindropTags,
 	}
 
+	deliveries, err := h.store.IntegrationDeliveries(user.ID, 50)
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
 	sess := session.New(h.store, request.SessionID(r))
 	view := view.New(h.tpl, r, sess)
 	view.Set("form", integrationForm)
+	view.Set("deliveries", deliveries)
 	view.Set("menu", "settings")
 	view.Set("user", user)
 	view.Set("countUnread", h.store.CountUnreadEntries(user.ID))
@@ -13,6 +13,7 @@ type pagination struct {
 	NextOffset   int
 	PrevOffset   int
 	SearchQuery  string
+	SearchMode   string
 }
 
 func getPagination(route string, total, offset, nbItemsPerPage int) pagination {
@@ -10,6 +10,7 @@ import (
 	"miniflux.app/v2/internal/http/response/html"
 	"miniflux.app/v2/internal/http/route"
 	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/similarity"
 	"miniflux.app/v2/internal/ui/session"
 	"miniflux.app/v2/internal/ui/view"
 )
@@ -24,12 +25,29 @@ func (h *handler) showSearchPage(w http.ResponseWriter, r *http.Request) {
 	searchQuery := request.QueryStringParam(r, "q", "")
 	offset := request.QueryIntParam(r, "offset", 0)
 
+	// Only the semantic mode is kept in the links, the full-text search being the default.
+	searchMode := ""
+	if request.QueryStringParam(r, "search_mode", "") == model.SearchModeSemantic {
+		searchMode = model.SearchModeSemantic
+	}
+
 	var entries model.Entries
 	var entriesCount int
 
 	if searchQuery != "" {
 		builder := h.store.NewEntryQueryBuilder(user.ID)
-		builder.WithSearchQuery(searchQuery)
+		if searchMode == model.SearchModeSemantic {
+			terms, weights, err := similarity.SemanticQuery(h.store, user.ID, searchQuery)
+			if err != nil {
+				html.ServerError(w, r, err)
+				return
+			}
+			builder.WithSemanticQuery(terms, weights)
+			// Ties are ordered like in the pagination of the entry page.
+			builder.WithSorting("e.id", "ASC")
+		} else {
+			builder.WithSearchQuery(searchQuery)
+		}
 		builder.WithoutStatus(model.EntryStatusRemoved)
 		builder.WithOffset(offset)
 		builder.WithLimit(user.EntriesPerPage)
@@ -51,8 +69,10 @@ func (h *handler) showSearchPage(w http.ResponseWriter, r *http.Request) {
 	view := view.New(h.tpl, r, sess)
 	pagination := getPagination(route.Path(h.router, "search"), entriesCount, offset, user.EntriesPerPage)
 	pagination.SearchQuery = searchQuery
+	pagination.SearchMode = searchMode
 
 	view.Set("searchQuery", searchQuery)
+	view.Set("searchMode", searchMode)
 	view.Set("entries", entries)
 	view.Set("total", entriesCount)
 	view.Set("pagination", pagination)
@@ -6,6 +6,7 @@ package ui // import "miniflux.app/v2/internal/ui"
 import (
 	"net/http"
 
+	"miniflux.app/v2/internal/config"
 	"miniflux.app/v2/internal/http/request"
 	"miniflux.app/v2/internal/http/response/html"
 	"miniflux.app/v2/internal/locale"
@@ -23,25 +24,32 @@ func (h *handler) showSettingsPage(w http.ResponseWriter, r *http.Request) {
 	}
 
 	settingsForm := form.SettingsForm{
-		Username:               user.Username,
-		Theme:                  user.Theme,
-		Language:               user.Language,
-		Timezone:               user.Timezone,
-		EntryDirection:         user.EntryDirection,
-		EntryOrder:             user.EntryOrder,
-		EntriesPerPage:         user.EntriesPerPage,
-		KeyboardShortcuts:      user.KeyboardShortcuts,
-		ShowReadingTime:        user.ShowReadingTime,
-		CustomCSS:              user.Stylesheet,
-		EntrySwipe:             user.EntrySwipe,
-		GestureNav:             user.GestureNav,
-		DisplayMode:            user.DisplayMode,
-		DefaultReadingSpeed:    user.DefaultReadingSpeed,
-		CJKReadingSpeed:        user.CJKReadingSpeed,
-		DefaultHomePage:        user.DefaultHomePage,
-		CategoriesSortingOrder: user.CategoriesSortingOrder,
-		MarkReadOnView:         user.MarkReadOnView,
-		MediaPlaybackRate:      user.MediaPlaybackRate,
+		Username:                    user.Username,
+		Theme:                       user.Theme,
+		Language:                    user.Language,
+		Timezone:                    user.Timezone,
+		EntryDirection:              user.EntryDirection,
+		EntryOrder:                  user.EntryOrder,
+		EntriesPerPage:              user.EntriesPerPage,
+		KeyboardShortcuts:           user.KeyboardShortcuts,
+		ShowReadingTime:             user.ShowReadingTime,
+		CustomCSS:                   user.Stylesheet,
+		EntrySwipe:                  user.EntrySwipe,
+		GestureNav:                  user.GestureNav,
+		DisplayMode:                 user.DisplayMode,
+		DefaultReadingSpeed:         user.DefaultReadingSpeed,
+		CJKReadingSpeed:             user.CJKReadingSpeed,
+		DefaultHomePage:             user.DefaultHomePage,
+		CategoriesSortingOrder:      user.CategoriesSortingOrder,
+		MarkReadOnView:              user.MarkReadOnView,
+		MediaPlaybackRate:           user.MediaPlaybackRate,
+		CollapseSimilarEntries:      user.CollapseSimilarEntries,
+		DuplicateEntriesAction:      user.DuplicateEntriesAction,
+		DuplicateEntriesThreshold:   user.DuplicateEntriesThreshold,
+		DuplicateEntriesCategoryIDs: user.DuplicateEntriesCategoryIDs,
+		DigestFrequency:             user.DigestFrequency,
+		DigestEntries:               user.DigestEntries,
+		DigestEmail:                 user.DigestEmail,
 	}
 
 	timezones, err := h.store.Timezones()
@@ -56,6 +64,12 @@ func (h *handler) showSettingsPage(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	categories, err := h.store.Categories(user.ID)
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
 	sess := session.New(h.store, request.SessionID(r))
 	view := view.New(h.tpl, r, sess)
 	view.Set("form", settingsForm)
@@ -68,6 +82,13 @@ func (h *handler) showSettingsPage(w http.ResponseWriter, r *http.Request) {
 	view.Set("countErrorFeeds", h.store.CountUserFeedsWithErrors(user.ID))
 	view.Set("default_home_pages", model.HomePages())
 	view.Set("categories_sorting_options", model.CategoriesSortingOptions())
+	view.Set("duplicate_entries_actions", model.DuplicateEntriesActions())
+	view.Set("similarityThreshold", config.Opts.SimilarityThreshold())
+	view.Set("hasSimilarity", config.Opts.SimilarityWindowHours() > 0)
+	view.Set("digest_frequencies", model.DigestFrequencies())
+	view.Set("digest_entries_options", model.DigestEntriesOptions())
+	view.Set("hasDigestEmails", config.Opts.HasDigestEmails())
+	view.Set("categories", categories)
 	view.Set("countWebAuthnCerts", h.store.CountWebAuthnCredentialsByUserID(user.ID))
 	view.Set("webAuthnCerts", creds)
 
@@ -6,6 +6,7 @@ package ui // import "miniflux.app/v2/internal/ui"
 import (
 	"net/http"
 
+	"miniflux.app/v2/internal/config"
 	"miniflux.app/v2/internal/http/request"
 	"miniflux.app/v2/internal/http/response/html"
 	"miniflux.app/v2/internal/http/route"
@@ -36,6 +37,12 @@ func (h *handler) updateSettings(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	categories, err := h.store.Categories(loggedUser.ID)
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
 	settingsForm := form.NewSettingsForm(r)
 
 	sess := session.New(h.store, request.SessionID(r))
@@ -50,6 +57,13 @@ func (h *handler) updateSettings(w http.ResponseWriter, r *http.Request) {
 	view.Set("countErrorFeeds", h.store.CountUserFeedsWithErrors(loggedUser.ID))
 	view.Set("default_home_pages", model.HomePages())
 	view.Set("categories_sorting_options", model.CategoriesSortingOptions())
+	view.Set("duplicate_entries_actions", model.DuplicateEntriesActions())
+	view.Set("similarityThreshold", config.Opts.SimilarityThreshold())
+	view.Set("hasSimilarity", config.Opts.SimilarityWindowHours() > 0)
+	view.Set("digest_frequencies", model.DigestFrequencies())
+	view.Set("digest_entries_options", model.DigestEntriesOptions())
+	view.Set("hasDigestEmails", config.Opts.HasDigestEmails())
+	view.Set("categories", categories)
 	view.Set("countWebAuthnCerts", h.store.CountWebAuthnCredentialsByUserID(loggedUser.ID))
 	view.Set("webAuthnCerts", creds)
 
@@ -60,19 +74,25 @@ func (h *handler) updateSettings(w http.ResponseWriter, r *http.Request) {
 	}
 
 	userModificationRequest := &model.UserModificationRequest{
-		Username:            model.OptionalString(settingsForm.Username),
-		Password:            model.OptionalString(settingsForm.Password),
-		Theme:               model.OptionalString(settingsForm.Theme),
-		Language:            model.OptionalString(settingsForm.Language),
-		Timezone:            model.OptionalString(settingsForm.Timezone),
-		EntryDirection:      model.OptionalString(settingsForm.EntryDirection),
-		EntriesPerPage:      model.OptionalNumber(settingsForm.EntriesPerPage),
-		DisplayMode:         model.OptionalString(settingsForm.DisplayMode),
-		GestureNav:          model.OptionalString(settingsForm.GestureNav),
-		DefaultReadingSpeed: model.OptionalNumber(settingsForm.DefaultReadingSpeed),
-		CJKReadingSpeed:     model.OptionalNumber(settingsForm.CJKReadingSpeed),
-		DefaultHomePage:     model.OptionalString(settingsForm.DefaultHomePage),
-		MediaPlaybackRate:   model.OptionalNumber(settingsForm.MediaPlaybackRate),
+		Username:                    model.OptionalString(settingsForm.Username),
+		Password:                    model.OptionalString(settingsForm.Password),
+		Theme:                       model.OptionalString(settingsForm.Theme),
+		Language:                    model.OptionalString(settingsForm.Language),
+		Timezone:                    model.OptionalString(settingsForm.Timezone),
+		EntryDirection:              model.OptionalString(settingsForm.EntryDirection),
+		EntriesPerPage:              model.OptionalNumber(settingsForm.EntriesPerPage),
+		DisplayMode:                 model.OptionalString(settingsForm.DisplayMode),
+		GestureNav:                  model.OptionalString(settingsForm.GestureNav),
+		DefaultReadingSpeed:         model.OptionalNumber(settingsForm.DefaultReadingSpeed),
+		CJKReadingSpeed:             model.OptionalNumber(settingsForm.CJKReadingSpeed),
+		DefaultHomePage:             model.OptionalString(settingsForm.DefaultHomePage),
+		MediaPlaybackRate:           model.OptionalNumber(settingsForm.MediaPlaybackRate),
+		DuplicateEntriesAction:      model.OptionalString(settingsForm.DuplicateEntriesAction),
+		DuplicateEntriesThreshold:   model.OptionalNumber(settingsForm.DuplicateEntriesThreshold),
+		DuplicateEntriesCategoryIDs: &settingsForm.DuplicateEntriesCategoryIDs,
+		DigestFrequency:             model.OptionalString(settingsForm.DigestFrequency),
+		DigestEntries:               model.OptionalString(settingsForm.DigestEntries),
+		DigestEmail:                 &settingsForm.DigestEmail,
 	}
 
 	if validationErr := validator.ValidateUserModification(h.store, loggedUser.ID, userModificationRequest); validationErr != nil {
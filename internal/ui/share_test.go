@@ -0,0 +1,128 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package ui // import "miniflux.app/v2/internal/ui"
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"miniflux.app/v2/internal/config"
+	"miniflux.app/v2/internal/locale"
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/template"
+
+	"github.com/gorilla/mux"
+)
+
+func parseTestTemplates(t *testing.T) *template.Engine {
+	t.Helper()
+	os.Clearenv()
+
+	var err error
+	config.Opts, err = config.NewParser().ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	if err := locale.LoadCatalogMessages(); err != nil {
+		t.Fatalf(`Unable to load translations: %v`, err)
+	}
+
+	router := mux.NewRouter()
+	Serve(router, nil, nil)
+
+	engine := template.NewEngine(router)
+	if err := engine.ParseTemplates(); err != nil {
+		t.Fatalf(`Unable to parse templates: %v`, err)
+	}
+	return engine
+}
+
+func TestRenderSharedEntry(t *testing.T) {
+	engine := parseTestTemplates(t)
+
+	entry := &model.Entry{
+		ID:      1,
+		FeedID:  1,
+		Title:   "Shared entry",
+		URL:     "https://example.org/entry",
+		Content: "<p>Shared content</p>",
+		Date:    time.Now(),
+		Feed: &model.Feed{
+			ID:       1,
+			Title:    "Feed",
+			Category: &model.Category{ID: 1, Title: "Category"},
+			Icon:     &model.FeedIcon{},
+		},
+	}
+
+	// The public share page only sets the entry, there is no user nor any user specific data.
+	output := string(engine.Render("entry.html", map[string]interface{}{
+		"language":             "en_US",
+		"theme":                "light_serif",
+		"theme_checksum":       "checksum",
+		"app_js_checksum":      "checksum",
+		"sw_js_checksum":       "checksum",
+		"webauthn_js_checksum": "checksum",
+		"entry":                entry,
+	}))
+
+	if !strings.Contains(output, "Shared content") {
+		t.Errorf(`The shared entry content should be rendered`)
+	}
+
+	if strings.Contains(output, `class="entry-revisions"`) {
+		t.Errorf(`The shared entry should not link to its revisions`)
+	}
+}
+
+func TestRenderEnclosureCaptions(t *testing.T) {
+	engine := parseTestTemplates(t)
+
+	entry := &model.Entry{
+		ID:     1,
+		FeedID: 1,
+		Title:  "Episode",
+		URL:    "https://example.org/episode",
+		Date:   time.Now(),
+		Feed: &model.Feed{
+			ID:       1,
+			Title:    "Podcast",
+			Category: &model.Category{ID: 1, Title: "Category"},
+			Icon:     &model.FeedIcon{},
+		},
+		Enclosures: model.EnclosureList{{
+			ID:       42,
+			URL:      "https://example.org/episode.mp3",
+			MimeType: "audio/mpeg",
+			Transcripts: model.PodcastTranscripts{
+				{URL: "https://example.org/episode.html", MimeType: "text/html"},
+				{URL: "https://example.org/episode.srt", MimeType: "application/x-subrip", Language: "en"},
+			},
+		}},
+	}
+
+	output := string(engine.Render("entry.html", map[string]interface{}{
+		"language":             "en_US",
+		"theme":                "light_serif",
+		"theme_checksum":       "checksum",
+		"app_js_checksum":      "checksum",
+		"sw_js_checksum":       "checksum",
+		"webauthn_js_checksum": "checksum",
+		"user":                 &model.User{ID: 1, DefaultHomePage: "unread"},
+		"countUnread":          0,
+		"countErrorFeeds":      0,
+		"entry":                entry,
+	}))
+
+	if !strings.Contains(output, `<track kind="captions" src="/entry/enclosure/42/captions/1" srclang="en" label="en">`) {
+		t.Errorf(`The captions should be available in the media player`)
+	}
+
+	if strings.Contains(output, `/entry/enclosure/42/captions/0`) {
+		t.Errorf(`Transcripts without timing should not be used as captions`)
+	}
+}
@@ -284,6 +284,88 @@ function saveEntry(element, toasting) {
     request.execute();
 }
 
+// Send the Ajax request to mark the entries of the related coverage panel as read.
+function markRelatedEntriesAsRead(element) {
+    if (!element || element.dataset.completed) {
+        return;
+    }
+
+    element.textContent = "";
+    appendIconLabel(element, element.dataset.labelLoading);
+
+    const request = new RequestBuilder(element.dataset.url);
+    request.withCallback((response) => {
+        response.json().then((count) => {
+            element.textContent = "";
+            appendIconLabel(element, element.dataset.labelDone);
+            element.dataset.completed = true;
+
+            document.querySelectorAll(".related-entries .item-status-unread").forEach((item) => {
+                item.classList.replace("item-status-unread", "item-status-read");
+            });
+            updateUnreadCounterValue((current) => current - count);
+        });
+    });
+    request.execute();
+}
+
+// Keep the last text selected in the entry content, clicking a button may collapse the selection.
+let lastEntrySelection = "";
+
+function trackEntrySelection() {
+    const selection = window.getSelection();
+    if (!selection || selection.isCollapsed || selection.rangeCount === 0) {
+        return;
+    }
+
+    const contentElement = document.querySelector(".entry-content");
+    if (contentElement && contentElement.contains(selection.getRangeAt(0).commonAncestorContainer)) {
+        lastEntrySelection = selection.toString().trim();
+    }
+}
+
+// Send the Ajax request to save the selected text as a highlight.
+function createEntryAnnotation(element) {
+    if (lastEntrySelection === "") {
+        showToast(element.dataset.toastEmpty, document.querySelector("template#icon-edit"));
+        return;
+    }
+
+    const note = window.prompt(element.dataset.labelNote, "");
+    if (note === null) {
+        return;
+    }
+
+    const request = new RequestBuilder(element.dataset.url);
+    request.withBody({text: lastEntrySelection, note: note.trim()});
+    request.withCallback(() => window.location.reload());
+    request.execute();
+}
+
+// Mark the highlighted passages found in a single text node of the entry content.
+function markEntryAnnotations() {
+    const contentElement = document.querySelector(".entry-content");
+    if (!contentElement) {
+        return;
+    }
+
+    document.querySelectorAll("[data-annotation-text]").forEach((element) => {
+        const text = element.dataset.annotationText;
+        const walker = document.createTreeWalker(contentElement, NodeFilter.SHOW_TEXT);
+        while (walker.nextNode()) {
+            const node = walker.currentNode;
+            const index = node.nodeValue.indexOf(text);
+            if (index >= 0) {
+                const range = document.createRange();
+                range.setStart(node, index);
+                range.setEnd(node, index + text.length);
+                range.surroundContents(document.createElement("mark"));
+                break;
+            }
+        }
+    });
+}
+
 // Handle bookmark from the list view and entry view.
 function handleBookmark(element) {
     const toasting = !element;
@@ -761,6 +843,10 @@ function handleMediaControl(button) {
         case "seek":
             enclosure.currentTime = enclosure.currentTime + value > 0 ? enclosure.currentTime + value : 0;
             break;
+        case "jump":
+            enclosure.currentTime = value;
+            enclosure.play();
+            break;
         case "speed":
             // I set a floor speed of 0.25 to avoid too slow speed where it gives the impression it stopped.
             // 0.25 was chosen because it will allow to get back to 1x in two "faster" click, and lower value with same property would be 0.
@@ -84,6 +84,9 @@ document.addEventListener("DOMContentLoaded", () => {
 
     onClick(":is(a, button)[data-save-entry]", (event) => handleSaveEntry(event.target));
     onClick(":is(a, button)[data-toggle-bookmark]", (event) => handleBookmark(event.target));
+    onClick(":is(a, button)[data-mark-related-as-read]", (event) => markRelatedEntriesAsRead(event.currentTarget));
+    onClick(":is(a, button)[data-create-annotation]", (event) => createEntryAnnotation(event.currentTarget));
+    onClick(":is(a, button)[data-save-annotations]", (event) => saveEntry(event.currentTarget, true));
     onClick(":is(a, button)[data-fetch-content-entry]", handleFetchOriginalContent);
     onClick(":is(a, button)[data-share-status]", handleShare);
     onClick(":is(a, button)[data-action=markPageAsRead]", (event) => handleConfirmationMessage(event.target, markPageAsRead));
@@ -113,6 +116,9 @@ document.addEventListener("DOMContentLoaded", () => {
         }
     }, true);
 
+    markEntryAnnotations();
+    document.addEventListener("selectionchange", trackEntrySelection);
+
     checkMenuToggleModeByLayout();
     window.addEventListener("resize", checkMenuToggleModeByLayout, { passive: true });
 
@@ -178,6 +184,22 @@ document.addEventListener("DOMContentLoaded", () => {
         }
     });
 
+    // Show the captions of the audio enclosures below the player, audio players don't display them
+    document.querySelectorAll("audio[data-enclosure-id]").forEach((element) => {
+        const captions = document.querySelector(`p.media-captions[data-enclosure-id="${element.dataset.enclosureId}"]`);
+        const track = Array.from(element.textTracks).find((textTrack) => textTrack.kind === "captions");
+        if (!captions || !track) {
+            return;
+        }
+
+        track.mode = "hidden";
+        track.addEventListener("cuechange", () => {
+            const cues = Array.from(track.activeCues || []);
+            captions.replaceChildren(...cues.map((cue) => cue.getCueAsHTML()));
+            captions.hidden = cues.length === 0;
+        });
+    });
+
     // Set enclosure media controls handlers
     const mediaControlsElements = document.querySelectorAll("button[data-enclosure-action]");
     mediaControlsElements.forEach((element) => {
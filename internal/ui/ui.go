@@ -46,6 +46,7 @@ func Serve(router *mux.Router, store *storage.Storage, pool *worker.Pool) {
 	uiRouter.HandleFunc("/mark-all-as-read", handler.markAllAsRead).Name("markAllAsRead").Methods(http.MethodPost)
 	uiRouter.HandleFunc("/unread", handler.showUnreadPage).Name("unread").Methods(http.MethodGet)
 	uiRouter.HandleFunc("/unread/entry/{entryID}", handler.showUnreadEntryPage).Name("unreadEntry").Methods(http.MethodGet)
+	uiRouter.HandleFunc("/unread/story/{entryID}", handler.showStoryEntriesPage).Name("storyEntries").Methods(http.MethodGet)
 
 	// History pages.
 	uiRouter.HandleFunc("/history", handler.showHistoryPage).Name("history").Methods(http.MethodGet)
@@ -63,12 +64,16 @@ func Serve(router *mux.Router, store *storage.Storage, pool *worker.Pool) {
 	// Feed listing pages.
 	uiRouter.HandleFunc("/feeds", handler.showFeedsPage).Name("feeds").Methods(http.MethodGet)
 	uiRouter.HandleFunc("/feeds/refresh", handler.refreshAllFeeds).Name("refreshAllFeeds").Methods(http.MethodGet)
+	uiRouter.HandleFunc("/feeds/suggestions", handler.showFeedSuggestionsPage).Name("feedSuggestions").Methods(http.MethodGet)
 
 	// Individual feed pages.
 	uiRouter.HandleFunc("/feed/{feedID}/refresh", handler.refreshFeed).Name("refreshFeed").Methods(http.MethodGet, http.MethodPost)
 	uiRouter.HandleFunc("/feed/{feedID}/refresh", handler.refreshFeed).Queries("forceRefresh", "{forceRefresh:true|false}").Name("refreshFeed").Methods(http.MethodGet, http.MethodPost)
 	uiRouter.HandleFunc("/feed/{feedID}/edit", handler.showEditFeedPage).Name("editFeed").Methods(http.MethodGet)
+	uiRouter.HandleFunc("/feed/{feedID}/diagnostics", handler.showFeedDiagnosticsPage).Name("feedDiagnostics").Methods(http.MethodGet)
 	uiRouter.HandleFunc("/feed/{feedID}/remove", handler.removeFeed).Name("removeFeed").Methods(http.MethodPost)
+	uiRouter.HandleFunc("/feed/{feedID}/use-new-url", handler.useNewFeedURL).Name("useNewFeedURL").Methods(http.MethodPost)
+	uiRouter.HandleFunc("/feed/{feedID}/dismiss-suggestions", handler.dismissFeedSuggestions).Name("dismissFeedSuggestions").Methods(http.MethodPost)
 	uiRouter.HandleFunc("/feed/{feedID}/update", handler.updateFeed).Name("updateFeed").Methods(http.MethodPost)
 	uiRouter.HandleFunc("/feed/{feedID}/entries", handler.showFeedEntriesPage).Name("feedEntries").Methods(http.MethodGet)
 	uiRouter.HandleFunc("/feed/{feedID}/entries/all", handler.showFeedEntriesAllPage).Name("feedEntriesAll").Methods(http.MethodGet)
@@ -97,13 +102,30 @@ func Serve(router *mux.Router, store *storage.Storage, pool *worker.Pool) {
 	uiRouter.HandleFunc("/tags/{tagName}/entries/all", handler.showTagEntriesAllPage).Name("tagEntriesAll").Methods(http.MethodGet)
 	uiRouter.HandleFunc("/tags/{tagName}/entry/{entryID}", handler.showTagEntryPage).Name("tagEntry").Methods(http.MethodGet)
 
+	// User tag pages.
+	uiRouter.HandleFunc("/user-tags", handler.showUserTagsPage).Name("userTags").Methods(http.MethodGet)
+	uiRouter.HandleFunc("/user-tags/{tagName}/entries/all", handler.showUserTagEntriesAllPage).Name("userTagEntriesAll").Methods(http.MethodGet)
+	uiRouter.HandleFunc("/user-tags/{tagName}/entry/{entryID}", handler.showUserTagEntryPage).Name("userTagEntry").Methods(http.MethodGet)
+	uiRouter.HandleFunc("/user-tags/{tagName}/rename", handler.renameUserTag).Name("renameUserTag").Methods(http.MethodPost)
+	uiRouter.HandleFunc("/user-tags/{tagName}/remove", handler.removeUserTag).Name("removeUserTag").Methods(http.MethodPost)
+
 	// Entry pages.
 	uiRouter.HandleFunc("/entry/status", handler.updateEntriesStatus).Name("updateEntriesStatus").Methods(http.MethodPost)
 	uiRouter.HandleFunc("/entry/save/{entryID}", handler.saveEntry).Name("saveEntry").Methods(http.MethodPost)
 	uiRouter.HandleFunc("/entry/enclosure/{enclosureID}/save-progression", handler.saveEnclosureProgression).Name("saveEnclosureProgression").Methods(http.MethodPost)
+	uiRouter.HandleFunc("/entry/enclosure/{enclosureID}/captions/{index}", handler.showEnclosureCaptions).Name("enclosureCaptions").Methods(http.MethodGet)
 	uiRouter.HandleFunc("/entry/download/{entryID}", handler.fetchContent).Name("fetchContent").Methods(http.MethodPost)
 	uiRouter.HandleFunc("/proxy/{encodedDigest}/{encodedURL}", handler.mediaProxy).Name("proxy").Methods(http.MethodGet)
 	uiRouter.HandleFunc("/entry/bookmark/{entryID}", handler.toggleBookmark).Name("toggleBookmark").Methods(http.MethodPost)
+	uiRouter.HandleFunc("/entry/similar/{entryID}/mark-all-as-read", handler.markSimilarEntriesAsRead).Name("markSimilarEntriesAsRead").Methods(http.MethodPost)
+	uiRouter.HandleFunc("/entry/revisions/{entryID}", handler.showEntryRevisionsPage).Name("entryRevisions").Methods(http.MethodGet)
+	uiRouter.HandleFunc("/entry/user-tags/{entryID}", handler.showEditEntryUserTagsPage).Name("editEntryUserTags").Methods(http.MethodGet)
+	uiRouter.HandleFunc("/entry/user-tags/{entryID}", handler.updateEntryUserTags).Name("updateEntryUserTags").Methods(http.MethodPost)
+	uiRouter.HandleFunc("/entry/annotations/{entryID}", handler.createEntryAnnotation).Name("createEntryAnnotation").Methods(http.MethodPost)
+	uiRouter.HandleFunc("/entry/annotations/{entryID}/save", handler.saveEntryAnnotations).Name("saveEntryAnnotations").Methods(http.MethodPost)
+	uiRouter.HandleFunc("/entry/annotations/{entryID}/export", handler.exportEntryAnnotations).Name("exportEntryAnnotations").Methods(http.MethodGet)
+	uiRouter.HandleFunc("/entry/annotations/{entryID}/{annotationID}/remove", handler.removeEntryAnnotation).Name("removeEntryAnnotation").Methods(http.MethodPost)
+	uiRouter.HandleFunc("/annotations/export", handler.exportAnnotations).Name("exportAnnotations").Methods(http.MethodGet)
 
 	// Share pages.
 	uiRouter.HandleFunc("/entry/share/{entryID}", handler.createSharedEntry).Name("shareEntry").Methods(http.MethodGet)
@@ -124,6 +146,7 @@ func Serve(router *mux.Router, store *storage.Storage, pool *worker.Pool) {
 	uiRouter.HandleFunc("/settings", handler.updateSettings).Name("updateSettings").Methods(http.MethodPost)
 	uiRouter.HandleFunc("/integrations", handler.showIntegrationPage).Name("integrations").Methods(http.MethodGet)
 	uiRouter.HandleFunc("/integration", handler.updateIntegration).Name("updateIntegration").Methods(http.MethodPost)
+	uiRouter.HandleFunc("/integration/delivery/{deliveryID}/resend", handler.resendIntegrationDelivery).Name("resendIntegrationDelivery").Methods(http.MethodPost)
 	uiRouter.HandleFunc("/integration/pocket/authorize", handler.pocketAuthorize).Name("pocketAuthorize").Methods(http.MethodGet)
 	uiRouter.HandleFunc("/integration/pocket/callback", handler.pocketCallback).Name("pocketCallback").Methods(http.MethodGet)
 	uiRouter.HandleFunc("/about", handler.showAboutPage).Name("about").Methods(http.MethodGet)
@@ -138,6 +161,14 @@ func Serve(router *mux.Router, store *storage.Storage, pool *worker.Pool) {
 	uiRouter.HandleFunc("/keys/create", handler.showCreateAPIKeyPage).Name("createAPIKey").Methods(http.MethodGet)
 	uiRouter.HandleFunc("/keys/save", handler.saveAPIKey).Name("saveAPIKey").Methods(http.MethodPost)
 
+	// Entry rules pages.
+	uiRouter.HandleFunc("/rules", handler.showEntryRulesPage).Name("entryRules").Methods(http.MethodGet)
+	uiRouter.HandleFunc("/rules/create", handler.showCreateEntryRulePage).Name("createEntryRule").Methods(http.MethodGet)
+	uiRouter.HandleFunc("/rules/save", handler.saveEntryRule).Name("saveEntryRule").Methods(http.MethodPost)
+	uiRouter.HandleFunc("/rules/{ruleID}/edit", handler.showEditEntryRulePage).Name("editEntryRule").Methods(http.MethodGet)
+	uiRouter.HandleFunc("/rules/{ruleID}/update", handler.updateEntryRule).Name("updateEntryRule").Methods(http.MethodPost)
+	uiRouter.HandleFunc("/rules/{ruleID}/remove", handler.removeEntryRule).Name("removeEntryRule").Methods(http.MethodPost)
+
 	// OPML pages.
 	uiRouter.HandleFunc("/export", handler.exportFeeds).Name("export").Methods(http.MethodGet)
 	uiRouter.HandleFunc("/import", handler.showImportPage).Name("import").Methods(http.MethodGet)
@@ -30,6 +30,9 @@ func (h *handler) showUnreadPage(w http.ResponseWriter, r *http.Request) {
 	builder := h.store.NewEntryQueryBuilder(user.ID)
 	builder.WithStatus(model.EntryStatusUnread)
 	builder.WithGloballyVisible()
+	if user.CollapseSimilarEntries {
+		builder.WithCollapsedStories()
+	}
 	countUnread, err := builder.CountEntries()
 	if err != nil {
 		html.ServerError(w, r, err)
@@ -48,6 +51,9 @@ func (h *handler) showUnreadPage(w http.ResponseWriter, r *http.Request) {
 	builder.WithOffset(offset)
 	builder.WithLimit(user.EntriesPerPage)
 	builder.WithGloballyVisible()
+	if user.CollapseSimilarEntries {
+		builder.WithCollapsedStories()
+	}
 	entries, err := builder.GetEntries()
 	if err != nil {
 		html.ServerError(w, r, err)
@@ -0,0 +1,50 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package ui // import "miniflux.app/v2/internal/ui"
+
+import (
+	"net/http"
+	"net/url"
+
+	"miniflux.app/v2/internal/http/request"
+	"miniflux.app/v2/internal/http/response/html"
+	"miniflux.app/v2/internal/http/route"
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/ui/session"
+	"miniflux.app/v2/internal/validator"
+)
+
+func (h *handler) renameUserTag(w http.ResponseWriter, r *http.Request) {
+	loggedUser, err := h.store.UserByID(request.UserID(r))
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	tagName, err := url.PathUnescape(request.RouteStringParam(r, "tagName"))
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	if !h.store.UserTagExists(loggedUser.ID, tagName) {
+		html.NotFound(w, r)
+		return
+	}
+
+	tagRequest := &model.UserTagModificationRequest{Name: r.FormValue("name")}
+	if validationErr := validator.ValidateUserTagModification(tagRequest); validationErr != nil {
+		sess := session.New(h.store, request.SessionID(r))
+		sess.NewFlashErrorMessage(validationErr.Translate(loggedUser.Language))
+		html.Redirect(w, r, route.Path(h.router, "userTags"))
+		return
+	}
+
+	if err := h.store.RenameUserTag(loggedUser.ID, tagName, tagRequest.Name); err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	html.Redirect(w, r, route.Path(h.router, "userTags"))
+}
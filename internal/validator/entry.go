@@ -38,6 +38,16 @@ func ValidateEntryOrder(order string) error {
 	return fmt.Errorf(`invalid entry order, valid order values are: "id", "status", "changed_at", "published_at", "created_at", "category_title", "category_id", "title", "author"`)
 }
 
+// ValidateSearchMode makes sure the search mode is valid.
+func ValidateSearchMode(mode string) error {
+	switch mode {
+	case model.SearchModeFullText, model.SearchModeSemantic:
+		return nil
+	}
+
+	return fmt.Errorf(`invalid search mode, valid search mode values are: "%s" and "%s"`, model.SearchModeFullText, model.SearchModeSemantic)
+}
+
 // ValidateEntryModification makes sure the entry modification is valid.
 func ValidateEntryModification(request *model.EntryUpdateRequest) error {
 	if request.Title != nil && *request.Title == "" {
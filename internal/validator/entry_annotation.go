@@ -0,0 +1,29 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package validator // import "miniflux.app/v2/internal/validator"
+
+import (
+	"strings"
+
+	"miniflux.app/v2/internal/locale"
+	"miniflux.app/v2/internal/model"
+)
+
+// ValidateEntryAnnotationCreation validates annotation creation.
+func ValidateEntryAnnotationCreation(request *model.EntryAnnotationRequest) *locale.LocalizedError {
+	if strings.TrimSpace(request.Text) == "" {
+		return locale.NewLocalizedError("error.entry_annotation_text_required")
+	}
+
+	return nil
+}
+
+// ValidateEntryAnnotationModification validates annotation modification.
+func ValidateEntryAnnotationModification(request *model.EntryAnnotationModificationRequest) *locale.LocalizedError {
+	if request.Text != nil && strings.TrimSpace(*request.Text) == "" {
+		return locale.NewLocalizedError("error.entry_annotation_text_required")
+	}
+
+	return nil
+}
@@ -0,0 +1,60 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package validator // import "miniflux.app/v2/internal/validator"
+
+import (
+	"strconv"
+
+	"miniflux.app/v2/internal/locale"
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/storage"
+)
+
+// ValidateEntryRule validates entry rule creation and modification.
+func ValidateEntryRule(store *storage.Storage, userID int64, request *model.EntryRuleRequest) *locale.LocalizedError {
+	if request.Title == "" {
+		return locale.NewLocalizedError("error.title_required")
+	}
+
+	if len(request.Conditions) == 0 {
+		return locale.NewLocalizedError("error.entry_rule_condition_required")
+	}
+
+	if len(request.Actions) == 0 {
+		return locale.NewLocalizedError("error.entry_rule_action_required")
+	}
+
+	for _, condition := range request.Conditions {
+		if err := condition.Validate(); err != nil {
+			return locale.NewLocalizedError("error.entry_rule_invalid", err)
+		}
+
+		id, _ := strconv.ParseInt(condition.Value, 10, 64)
+		switch condition.Field {
+		case model.EntryRuleFieldFeed:
+			if !store.FeedExists(userID, id) {
+				return locale.NewLocalizedError("error.feed_not_found")
+			}
+		case model.EntryRuleFieldCategory:
+			if !store.CategoryIDExists(userID, id) {
+				return locale.NewLocalizedError("error.category_not_found")
+			}
+		}
+	}
+
+	for _, action := range request.Actions {
+		if err := action.Validate(); err != nil {
+			return locale.NewLocalizedError("error.entry_rule_invalid", err)
+		}
+
+		if action.Type == model.EntryRuleActionMoveToCategory {
+			categoryID, _ := strconv.ParseInt(action.Value, 10, 64)
+			if !store.CategoryIDExists(userID, categoryID) {
+				return locale.NewLocalizedError("error.category_not_found")
+			}
+		}
+	}
+
+	return nil
+}
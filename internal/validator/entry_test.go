@@ -46,6 +46,18 @@ func TestValidateEntryStatus(t *testing.T) {
 	}
 }
 
+func TestValidateSearchMode(t *testing.T) {
+	for _, mode := range []string{model.SearchModeFullText, model.SearchModeSemantic} {
+		if err := ValidateSearchMode(mode); err != nil {
+			t.Error(`A valid search mode should not generate any error`)
+		}
+	}
+
+	if err := ValidateSearchMode("invalid"); err == nil {
+		t.Error(`An invalid search mode should generate a error`)
+	}
+}
+
 func TestValidateEntryOrder(t *testing.T) {
 	for _, status := range []string{"id", "status", "changed_at", "published_at", "created_at", "category_title", "category_id"} {
 		if err := ValidateEntryOrder(status); err != nil {
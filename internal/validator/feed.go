@@ -6,7 +6,10 @@ package validator // import "miniflux.app/v2/internal/validator"
 import (
 	"miniflux.app/v2/internal/locale"
 	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/reader/jsonmapping"
 	"miniflux.app/v2/internal/storage"
+
+	"github.com/andybalholm/cascadia"
 )
 
 // ValidateFeedCreation validates feed creation.
@@ -35,6 +38,18 @@ func ValidateFeedCreation(store *storage.Storage, userID int64, request *model.F
 		return locale.NewLocalizedError("error.feed_invalid_keeplist_rule")
 	}
 
+	if err := ValidatePageWatcherRules(&request.PageWatcherRules); err != nil {
+		return err
+	}
+
+	if err := ValidateJSONMapping(&request.JSONMapping); err != nil {
+		return err
+	}
+
+	if err := ValidateFeedSource(&request.PageWatcherRules, &request.JSONMapping); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -88,5 +103,80 @@ func ValidateFeedModification(store *storage.Storage, userID, feedID int64, requ
 		}
 	}
 
+	if request.PageWatcherRules != nil {
+		if err := ValidatePageWatcherRules(request.PageWatcherRules); err != nil {
+			return err
+		}
+	}
+
+	if request.JSONMapping != nil {
+		if err := ValidateJSONMapping(request.JSONMapping); err != nil {
+			return err
+		}
+	}
+
+	if request.PageWatcherRules != nil || request.JSONMapping != nil {
+		rules, mapping := request.PageWatcherRules, request.JSONMapping
+		if rules == nil || mapping == nil {
+			feed, err := store.FeedByID(userID, feedID)
+			if err != nil {
+				return locale.NewLocalizedError("error.database_error", err)
+			}
+			if feed == nil {
+				return locale.NewLocalizedError("error.feed_not_found")
+			}
+			if rules == nil {
+				rules = &feed.PageWatcherRules
+			}
+			if mapping == nil {
+				mapping = &feed.JSONMapping
+			}
+		}
+
+		if err := ValidateFeedSource(rules, mapping); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ValidatePageWatcherRules makes sure the item selector is given with the other selectors, and that every selector is valid.
+func ValidatePageWatcherRules(rules *model.PageWatcherRules) *locale.LocalizedError {
+	hasSelectors := rules.TitleSelector != "" || rules.LinkSelector != "" || rules.DateSelector != "" || rules.ContentSelector != ""
+	if hasSelectors && rules.ItemSelector == "" {
+		return locale.NewLocalizedError("error.page_watcher_item_selector_required")
+	}
+
+	for _, selector := range []string{rules.ItemSelector, rules.TitleSelector, rules.LinkSelector, rules.DateSelector, rules.ContentSelector} {
+		if selector == "" {
+			continue
+		}
+		if _, err := cascadia.Compile(selector); err != nil {
+			return locale.NewLocalizedError("error.page_watcher_invalid_selector", selector)
+		}
+	}
+
+	return nil
+}
+
+// ValidateJSONMapping makes sure the items path is given with the other paths, and that every path is valid.
+func ValidateJSONMapping(mapping *model.JSONMapping) *locale.LocalizedError {
+	if mapping.ItemsPath == "" && *mapping != (model.JSONMapping{}) {
+		return locale.NewLocalizedError("error.json_mapping_items_path_required")
+	}
+
+	if err := jsonmapping.ValidateMapping(mapping); err != nil {
+		return locale.NewLocalizedError("error.json_mapping_invalid_path", err)
+	}
+
+	return nil
+}
+
+// ValidateFeedSource makes sure a feed is not both generated from a HTML page and mapped from a JSON document.
+func ValidateFeedSource(rules *model.PageWatcherRules, mapping *model.JSONMapping) *locale.LocalizedError {
+	if rules.Enabled() && mapping.ItemsPath != "" {
+		return locale.NewLocalizedError("error.feed_source_conflict")
+	}
 	return nil
 }
@@ -0,0 +1,60 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package validator // import "miniflux.app/v2/internal/validator"
+
+import (
+	"testing"
+
+	"miniflux.app/v2/internal/model"
+)
+
+func TestValidatePageWatcherRules(t *testing.T) {
+	scenarios := []struct {
+		rules model.PageWatcherRules
+		valid bool
+	}{
+		{model.PageWatcherRules{}, true},
+		{model.PageWatcherRules{ItemSelector: "article", TitleSelector: "h2 > a", DateSelector: "time[datetime]"}, true},
+		{model.PageWatcherRules{TitleSelector: "h2"}, false},
+		{model.PageWatcherRules{ItemSelector: "article["}, false},
+		{model.PageWatcherRules{ItemSelector: "article", ContentSelector: "div::"}, false},
+	}
+
+	for _, scenario := range scenarios {
+		err := ValidatePageWatcherRules(&scenario.rules)
+		if scenario.valid && err != nil {
+			t.Errorf(`The rules %+v should be valid, got %q`, scenario.rules, err.String())
+		}
+		if !scenario.valid && err == nil {
+			t.Errorf(`The rules %+v should be rejected`, scenario.rules)
+		}
+	}
+}
+
+func TestValidateJSONMapping(t *testing.T) {
+	if err := ValidateJSONMapping(&model.JSONMapping{}); err != nil {
+		t.Errorf(`An empty mapping should be valid, got %q`, err.String())
+	}
+
+	if err := ValidateJSONMapping(&model.JSONMapping{TitlePath: "title"}); err == nil {
+		t.Error(`A mapping without items path should be rejected`)
+	}
+}
+
+func TestValidateFeedSource(t *testing.T) {
+	rules := &model.PageWatcherRules{ItemSelector: "article"}
+	mapping := &model.JSONMapping{ItemsPath: "items"}
+
+	if err := ValidateFeedSource(rules, &model.JSONMapping{}); err != nil {
+		t.Errorf(`Page watcher rules alone should be valid, got %q`, err.String())
+	}
+
+	if err := ValidateFeedSource(&model.PageWatcherRules{}, mapping); err != nil {
+		t.Errorf(`A JSON mapping alone should be valid, got %q`, err.String())
+	}
+
+	if err := ValidateFeedSource(rules, mapping); err == nil {
+		t.Error(`Page watcher rules and a JSON mapping should not be accepted together`)
+	}
+}
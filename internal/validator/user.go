@@ -4,6 +4,9 @@
 package validator // import "miniflux.app/v2/internal/validator"
 
 import (
+	"net/mail"
+
+	"miniflux.app/v2/internal/config"
 	"miniflux.app/v2/internal/locale"
 	"miniflux.app/v2/internal/model"
 	"miniflux.app/v2/internal/storage"
@@ -108,6 +111,44 @@ func ValidateUserModification(store *storage.Storage, userID int64, changes *mod
 		}
 	}
 
+	if changes.DuplicateEntriesAction != nil {
+		if err := validateDuplicateEntriesAction(*changes.DuplicateEntriesAction); err != nil {
+			return err
+		}
+	}
+
+	if changes.DuplicateEntriesThreshold != nil {
+		if err := validateDuplicateEntriesThreshold(*changes.DuplicateEntriesThreshold); err != nil {
+			return err
+		}
+	}
+
+	if changes.DuplicateEntriesCategoryIDs != nil {
+		for _, categoryID := range *changes.DuplicateEntriesCategoryIDs {
+			if !store.CategoryIDExists(userID, categoryID) {
+				return locale.NewLocalizedError("error.category_not_found")
+			}
+		}
+	}
+
+	if changes.DigestFrequency != nil {
+		if err := validateDigestFrequency(*changes.DigestFrequency); err != nil {
+			return err
+		}
+	}
+
+	if changes.DigestEntries != nil {
+		if err := validateDigestEntries(*changes.DigestEntries); err != nil {
+			return err
+		}
+	}
+
+	if changes.DigestEmail != nil && *changes.DigestEmail != "" {
+		if err := validateDigestEmail(*changes.DigestEmail); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -195,3 +236,43 @@ func validateMediaPlaybackRate(mediaPlaybackRate float64) *locale.LocalizedError
 	}
 	return nil
 }
+
+func validateDuplicateEntriesAction(action string) *locale.LocalizedError {
+	if _, found := model.DuplicateEntriesActions()[action]; !found {
+		return locale.NewLocalizedError("error.invalid_duplicate_entries_action")
+	}
+	return nil
+}
+
+// validateDuplicateEntriesThreshold checks the threshold against the similarity threshold of the server:
+// only the pairs of entries above the latter are stored, so a lower threshold would never match more entries.
+func validateDuplicateEntriesThreshold(threshold float64) *locale.LocalizedError {
+	if threshold <= 0 || threshold > 1 {
+		return locale.NewLocalizedError("error.settings_duplicate_entries_threshold_range")
+	}
+	if minThreshold := config.Opts.SimilarityThreshold(); threshold < minThreshold {
+		return locale.NewLocalizedError("error.settings_duplicate_entries_threshold_min", minThreshold)
+	}
+	return nil
+}
+
+func validateDigestFrequency(frequency string) *locale.LocalizedError {
+	if _, found := model.DigestFrequencies()[frequency]; !found {
+		return locale.NewLocalizedError("error.invalid_digest_frequency")
+	}
+	return nil
+}
+
+func validateDigestEntries(entries string) *locale.LocalizedError {
+	if _, found := model.DigestEntriesOptions()[entries]; !found {
+		return locale.NewLocalizedError("error.invalid_digest_entries")
+	}
+	return nil
+}
+
+func validateDigestEmail(email string) *locale.LocalizedError {
+	if address, err := mail.ParseAddress(email); err != nil || address.Address != email {
+		return locale.NewLocalizedError("error.invalid_digest_email")
+	}
+	return nil
+}
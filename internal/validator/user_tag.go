@@ -0,0 +1,30 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package validator // import "miniflux.app/v2/internal/validator"
+
+import (
+	"strings"
+
+	"miniflux.app/v2/internal/locale"
+	"miniflux.app/v2/internal/model"
+)
+
+// ValidateUserTagModification validates user tag renaming.
+func ValidateUserTagModification(request *model.UserTagModificationRequest) *locale.LocalizedError {
+	request.Name = strings.TrimSpace(request.Name)
+	if request.Name == "" {
+		return locale.NewLocalizedError("error.user_tag_name_required")
+	}
+
+	return ValidateEntryUserTags([]string{request.Name})
+}
+
+// ValidateEntryUserTags validates the user tags added to entries.
+func ValidateEntryUserTags(tags []string) *locale.LocalizedError {
+	if err := model.ValidateUserTags(tags); err != nil {
+		return locale.NewLocalizedError("error.user_tags_invalid", err)
+	}
+
+	return nil
+}
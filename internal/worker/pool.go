@@ -4,31 +4,57 @@
 package worker // import "miniflux.app/v2/internal/worker"
 
 import (
+	"log/slog"
+	"time"
+
+	"miniflux.app/v2/internal/locale"
 	"miniflux.app/v2/internal/model"
+	feedHandler "miniflux.app/v2/internal/reader/handler"
 	"miniflux.app/v2/internal/storage"
 )
 
-// Pool handles a pool of workers.
+// Pool handles a pool of workers consuming the job queue stored in the database.
 type Pool struct {
-	queue chan model.Job
+	store  *storage.Storage
+	wakeUp chan struct{}
 }
 
-// Push send a list of jobs to the queue.
+// Push adds a list of jobs to the queue and wakes up the idle workers.
 func (p *Pool) Push(jobs model.JobList) {
-	for _, job := range jobs {
-		p.queue <- job
+	count, err := p.store.EnqueueJobs(jobs)
+	if err != nil {
+		slog.Error("Unable to enqueue jobs", slog.Int("nb_jobs", len(jobs)), slog.Any("error", err))
+		return
+	}
+
+	slog.Debug("Jobs enqueued",
+		slog.Int("nb_jobs", len(jobs)),
+		slog.Int64("nb_queued_jobs", count),
+	)
+
+	for range count {
+		select {
+		case p.wakeUp <- struct{}{}:
+		default:
+			return
+		}
 	}
 }
 
 // NewPool creates a pool of background workers.
 func NewPool(store *storage.Storage, nbWorkers int) *Pool {
 	workerPool := &Pool{
-		queue: make(chan model.Job),
+		store:  store,
+		wakeUp: make(chan struct{}),
+	}
+
+	refresh := func(userID, feedID int64, lastAttempt bool) (*locale.LocalizedErrorWrapper, time.Duration) {
+		return feedHandler.RefreshFeedAttempt(store, userID, feedID, lastAttempt)
 	}
 
 	for i := range nbWorkers {
-		worker := &Worker{id: i, store: store}
-		go worker.Run(workerPool.queue)
+		worker := &Worker{id: i, queue: store, refresh: refresh}
+		go worker.Run(workerPool.wakeUp)
 	}
 
 	return workerPool
@@ -8,49 +8,135 @@ import (
 	"time"
 
 	"miniflux.app/v2/internal/config"
+	"miniflux.app/v2/internal/locale"
 	"miniflux.app/v2/internal/metric"
 	"miniflux.app/v2/internal/model"
-	feedHandler "miniflux.app/v2/internal/reader/handler"
-	"miniflux.app/v2/internal/storage"
+	"miniflux.app/v2/internal/reader/fetcher"
 )
 
+const (
+	// pollInterval is the delay between two claims of an idle worker, to pick up the jobs
+	// queued by other processes or waiting for their retry date.
+	pollInterval = 5 * time.Second
+
+	// jobLease is the duration after which a running job is considered abandoned by a stopped process.
+	jobLease = 30 * time.Minute
+
+	maxRetryDelay = 24 * time.Hour
+)
+
+// jobQueue is the part of the storage used by the workers to consume the job queue.
+type jobQueue interface {
+	ClaimJob(hostLimit int, lease time.Duration) (*model.Job, error)
+	CompleteJob(jobID int64) error
+	RetryJob(jobID int64, runAt time.Time, lastError string) error
+	FailJob(jobID int64, lastError string) error
+}
+
+// refreshFunc refreshes the feed of a job, the errors of the last attempt are recorded on the feed.
+// It returns the delay asked by the remote server before the next request along with the error, if any.
+type refreshFunc func(userID, feedID int64, lastAttempt bool) (*locale.LocalizedErrorWrapper, time.Duration)
+
 // Worker refreshes a feed in the background.
 type Worker struct {
-	id    int
-	store *storage.Storage
+	id      int
+	queue   jobQueue
+	refresh refreshFunc
 }
 
-// Run wait for a job and refresh the given feed.
-func (w *Worker) Run(c <-chan model.Job) {
+// Run claims the due jobs and refreshes the given feeds, waiting for a wake up or the poll interval when the queue is empty.
+func (w *Worker) Run(wakeUp <-chan struct{}) {
 	slog.Debug("Worker started",
 		slog.Int("worker_id", w.id),
 	)
 
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
 	for {
-		job := <-c
-		slog.Debug("Job received by worker",
+		if w.processNext() {
+			continue
+		}
+
+		select {
+		case <-wakeUp:
+		case <-ticker.C:
+		}
+	}
+}
+
+// processNext claims the next due job and processes it. It returns false when no job was claimed.
+func (w *Worker) processNext() bool {
+	job, err := w.queue.ClaimJob(config.Opts.JobHostConcurrency(), jobLease)
+	if err != nil {
+		slog.Error("Unable to claim a job",
 			slog.Int("worker_id", w.id),
-			slog.Int64("user_id", job.UserID),
-			slog.Int64("feed_id", job.FeedID),
+			slog.Any("error", err),
 		)
+	}
 
-		startTime := time.Now()
-		localizedError := feedHandler.RefreshFeed(w.store, job.UserID, job.FeedID, false)
+	if job == nil {
+		return false
+	}
 
-		if config.Opts.HasMetricsCollector() {
-			status := "success"
-			if localizedError != nil {
-				status = "error"
-			}
-			metric.BackgroundFeedRefreshDuration.WithLabelValues(status).Observe(time.Since(startTime).Seconds())
-		}
+	w.process(job)
+	return true
+}
+
+func (w *Worker) process(job *model.Job) {
+	slog.Debug("Job claimed by worker",
+		slog.Int("worker_id", w.id),
+		slog.Int64("job_id", job.ID),
+		slog.Int64("user_id", job.UserID),
+		slog.Int64("feed_id", job.FeedID),
+		slog.Int("attempts", job.Attempts),
+	)
 
+	lastAttempt := job.Attempts >= config.Opts.JobMaxAttempts()
+	startTime := time.Now()
+	localizedError, retryAfter := w.refresh(job.UserID, job.FeedID, lastAttempt)
+
+	if config.Opts.HasMetricsCollector() {
+		status := "success"
 		if localizedError != nil {
-			slog.Warn("Unable to refresh a feed",
-				slog.Int64("user_id", job.UserID),
-				slog.Int64("feed_id", job.FeedID),
-				slog.Any("error", localizedError.Error()),
-			)
+			status = "error"
+		}
+		metric.BackgroundFeedRefreshDuration.WithLabelValues(status).Observe(time.Since(startTime).Seconds())
+	}
+
+	if localizedError == nil {
+		if err := w.queue.CompleteJob(job.ID); err != nil {
+			slog.Error("Unable to complete a job", slog.Int64("job_id", job.ID), slog.Any("error", err))
 		}
+		return
+	}
+
+	slog.Warn("Unable to refresh a feed",
+		slog.Int64("user_id", job.UserID),
+		slog.Int64("feed_id", job.FeedID),
+		slog.Int("attempts", job.Attempts),
+		slog.Any("error", localizedError.Error()),
+	)
+
+	// Permanent errors, like parsing errors or client errors, are not retried.
+	var err error
+	if lastAttempt || !fetcher.IsTransientError(localizedError.Error()) {
+		err = w.queue.FailJob(job.ID, localizedError.Error().Error())
+	} else {
+		// The remote server may ask to wait longer than the backoff.
+		delay := max(retryDelay(time.Duration(config.Opts.JobRetryDelay())*time.Second, job.Attempts), retryAfter)
+		err = w.queue.RetryJob(job.ID, time.Now().Add(delay), localizedError.Error().Error())
+	}
+	if err != nil {
+		slog.Error("Unable to reschedule a failed job", slog.Int64("job_id", job.ID), slog.Any("error", err))
+	}
+}
+
+// retryDelay returns the exponential backoff delay after the given number of attempts.
+func retryDelay(baseDelay time.Duration, attempts int) time.Duration {
+	delay := baseDelay
+	for i := 1; i < attempts && delay < maxRetryDelay; i++ {
+		delay *= 2
 	}
+	return min(delay, maxRetryDelay)
 }
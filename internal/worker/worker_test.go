@@ -0,0 +1,223 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package worker // import "miniflux.app/v2/internal/worker"
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"miniflux.app/v2/internal/config"
+	"miniflux.app/v2/internal/locale"
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/reader/fetcher"
+)
+
+// memoryQueue is a jobQueue recording the outcome of the claimed jobs.
+type memoryQueue struct {
+	jobs      []*model.Job
+	completed []int64
+	retried   map[int64]time.Time
+	failed    map[int64]string
+}
+
+func newMemoryQueue(jobs ...*model.Job) *memoryQueue {
+	return &memoryQueue{jobs: jobs, retried: make(map[int64]time.Time), failed: make(map[int64]string)}
+}
+
+func (q *memoryQueue) ClaimJob(hostLimit int, lease time.Duration) (*model.Job, error) {
+	if len(q.jobs) == 0 {
+		return nil, nil
+	}
+	job := q.jobs[0]
+	q.jobs = q.jobs[1:]
+	job.Attempts++
+	return job, nil
+}
+
+func (q *memoryQueue) CompleteJob(jobID int64) error {
+	q.completed = append(q.completed, jobID)
+	return nil
+}
+
+func (q *memoryQueue) RetryJob(jobID int64, runAt time.Time, lastError string) error {
+	q.retried[jobID] = runAt
+	return nil
+}
+
+func (q *memoryQueue) FailJob(jobID int64, lastError string) error {
+	q.failed[jobID] = lastError
+	return nil
+}
+
+func parseTestConfig(t *testing.T, env map[string]string) {
+	t.Helper()
+	os.Clearenv()
+	for key, value := range env {
+		os.Setenv(key, value)
+	}
+
+	var err error
+	config.Opts, err = config.NewParser().ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+}
+
+func refreshWithError(err error) refreshFunc {
+	return refreshWithRetryAfter(err, 0)
+}
+
+func refreshWithRetryAfter(err error, retryAfter time.Duration) refreshFunc {
+	return func(userID, feedID int64, lastAttempt bool) (*locale.LocalizedErrorWrapper, time.Duration) {
+		if err == nil {
+			return nil, 0
+		}
+		return locale.NewLocalizedErrorWrapper(err, ""), retryAfter
+	}
+}
+
+func TestProcessNextWithEmptyQueue(t *testing.T) {
+	parseTestConfig(t, nil)
+
+	worker := &Worker{queue: newMemoryQueue(), refresh: refreshWithError(nil)}
+	if worker.processNext() {
+		t.Fatal(`No job should be claimed from an empty queue`)
+	}
+}
+
+func TestProcessNextCompletesSuccessfulJobs(t *testing.T) {
+	parseTestConfig(t, nil)
+
+	queue := newMemoryQueue(&model.Job{ID: 1, UserID: 1, FeedID: 1}, &model.Job{ID: 2, UserID: 1, FeedID: 2})
+	worker := &Worker{queue: queue, refresh: refreshWithError(nil)}
+
+	for worker.processNext() {
+	}
+
+	if len(queue.completed) != 2 || queue.completed[0] != 1 || queue.completed[1] != 2 {
+		t.Fatalf(`The jobs should be completed in the claim order, got %v`, queue.completed)
+	}
+}
+
+func TestProcessNextRetriesTransientErrors(t *testing.T) {
+	parseTestConfig(t, map[string]string{"JOB_MAX_ATTEMPTS": "3", "JOB_RETRY_DELAY": "60"})
+
+	transientErr := fmt.Errorf("fetcher: %w", &fetcher.RateLimitError{Host: "example.org"})
+	queue := newMemoryQueue(&model.Job{ID: 1, UserID: 1, FeedID: 1, Attempts: 1})
+	worker := &Worker{queue: queue, refresh: refreshWithError(transientErr)}
+
+	before := time.Now()
+	worker.processNext()
+
+	runAt, found := queue.retried[1]
+	if !found {
+		t.Fatalf(`A transient error should be retried, the job was failed: %v`, queue.failed)
+	}
+
+	if delay := runAt.Sub(before); delay < 2*time.Minute || delay > 3*time.Minute {
+		t.Errorf(`The second attempt should be retried after 2 minutes, got %v`, delay)
+	}
+}
+
+func TestProcessNextWaitsForRetryAfter(t *testing.T) {
+	parseTestConfig(t, map[string]string{"JOB_MAX_ATTEMPTS": "3", "JOB_RETRY_DELAY": "60"})
+
+	transientErr := fmt.Errorf("fetcher: %w", &fetcher.RateLimitError{Host: "example.org"})
+	queue := newMemoryQueue(&model.Job{ID: 1, UserID: 1, FeedID: 1})
+	worker := &Worker{queue: queue, refresh: refreshWithRetryAfter(transientErr, time.Hour)}
+
+	before := time.Now()
+	worker.processNext()
+
+	runAt, found := queue.retried[1]
+	if !found {
+		t.Fatalf(`A transient error should be retried, the job was failed: %v`, queue.failed)
+	}
+
+	if delay := runAt.Sub(before); delay < time.Hour || delay > time.Hour+time.Minute {
+		t.Errorf(`The job should be retried after the delay asked by the server, got %v`, delay)
+	}
+}
+
+func TestProcessNextOnlyRecordsTheLastAttempt(t *testing.T) {
+	parseTestConfig(t, map[string]string{"JOB_MAX_ATTEMPTS": "3"})
+
+	transientErr := fmt.Errorf("fetcher: %w", &fetcher.RateLimitError{Host: "example.org"})
+	var lastAttempts []bool
+	refresh := func(userID, feedID int64, lastAttempt bool) (*locale.LocalizedErrorWrapper, time.Duration) {
+		lastAttempts = append(lastAttempts, lastAttempt)
+		return locale.NewLocalizedErrorWrapper(transientErr, ""), 0
+	}
+
+	job := &model.Job{ID: 1, UserID: 1, FeedID: 1}
+	queue := newMemoryQueue()
+	worker := &Worker{queue: queue, refresh: refresh}
+	for range 3 {
+		queue.jobs = append(queue.jobs, job)
+		worker.processNext()
+	}
+
+	if len(lastAttempts) != 3 || lastAttempts[0] || lastAttempts[1] || !lastAttempts[2] {
+		t.Errorf(`Only the third attempt should be the last one, got %v`, lastAttempts)
+	}
+}
+
+func TestProcessNextFailsAfterMaxAttempts(t *testing.T) {
+	parseTestConfig(t, map[string]string{"JOB_MAX_ATTEMPTS": "3"})
+
+	transientErr := fmt.Errorf("fetcher: %w", &fetcher.RateLimitError{Host: "example.org"})
+	queue := newMemoryQueue(&model.Job{ID: 1, UserID: 1, FeedID: 1, Attempts: 2})
+	worker := &Worker{queue: queue, refresh: refreshWithError(transientErr)}
+	worker.processNext()
+
+	if _, found := queue.failed[1]; !found {
+		t.Fatal(`The job should be failed after its last attempt`)
+	}
+}
+
+func TestProcessNextFailsPermanentErrors(t *testing.T) {
+	parseTestConfig(t, map[string]string{"JOB_MAX_ATTEMPTS": "3"})
+
+	scenarios := []error{
+		errors.New("parser: unable to detect feed format"),
+		fetcher.NewResponseHandler(&http.Response{StatusCode: http.StatusNotFound}, nil).LocalizedError().Error(),
+	}
+
+	for _, err := range scenarios {
+		queue := newMemoryQueue(&model.Job{ID: 1, UserID: 1, FeedID: 1})
+		worker := &Worker{queue: queue, refresh: refreshWithError(err)}
+		worker.processNext()
+
+		if len(queue.retried) != 0 {
+			t.Errorf(`The permanent error %q should not be retried`, err)
+		}
+
+		if queue.failed[1] != err.Error() {
+			t.Errorf(`The job should be failed with %q, got %q`, err, queue.failed[1])
+		}
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	scenarios := []struct {
+		attempts int
+		expected time.Duration
+	}{
+		{1, time.Minute},
+		{2, 2 * time.Minute},
+		{3, 4 * time.Minute},
+		{5, 16 * time.Minute},
+		{100, maxRetryDelay},
+	}
+
+	for _, scenario := range scenarios {
+		if delay := retryDelay(time.Minute, scenario.attempts); delay != scenario.expected {
+			t.Errorf(`Unexpected delay after %d attempts, got %v instead of %v`, scenario.attempts, delay, scenario.expected)
+		}
+	}
+}